@@ -3,14 +3,22 @@ package api
 import (
 	"net/http"
 
+	akh "github.com/fajrinajiseno/mygolangapp/internal/module/apikey/handler"
 	ah "github.com/fajrinajiseno/mygolangapp/internal/module/auth/handler"
+	eh "github.com/fajrinajiseno/mygolangapp/internal/module/export/handler"
+	mh "github.com/fajrinajiseno/mygolangapp/internal/module/merchant/handler"
 	ph "github.com/fajrinajiseno/mygolangapp/internal/module/payment/handler"
+	uh "github.com/fajrinajiseno/mygolangapp/internal/module/user/handler"
 	"github.com/fajrinajiseno/mygolangapp/internal/openapigen"
 )
 
 type APIHandler struct {
-	Auth    *ah.AuthHandler
-	Payment *ph.PaymentHandler
+	Auth     *ah.AuthHandler
+	Payment  *ph.PaymentHandler
+	Merchant *mh.MerchantHandler
+	Export   *eh.ExportHandler
+	User     *uh.UserHandler
+	APIKey   *akh.APIKeyHandler
 }
 
 var _ openapigen.ServerInterface = (*APIHandler)(nil)
@@ -19,10 +27,154 @@ func (h *APIHandler) PostDashboardV1AuthLogin(w http.ResponseWriter, r *http.Req
 	h.Auth.PostDashboardV1AuthLogin(w, r)
 }
 
+func (h *APIHandler) PostDashboardV1AuthRefresh(w http.ResponseWriter, r *http.Request) {
+	h.Auth.PostDashboardV1AuthRefresh(w, r)
+}
+
+func (h *APIHandler) PostDashboardV1AuthLogout(w http.ResponseWriter, r *http.Request) {
+	h.Auth.PostDashboardV1AuthLogout(w, r)
+}
+
+func (h *APIHandler) PostDashboardV1AuthLoginVerify(w http.ResponseWriter, r *http.Request) {
+	h.Auth.PostDashboardV1AuthLoginVerify(w, r)
+}
+
+func (h *APIHandler) PostDashboardV1AuthTotp(w http.ResponseWriter, r *http.Request) {
+	h.Auth.PostDashboardV1AuthTotp(w, r)
+}
+
+func (h *APIHandler) PostDashboardV1AuthTotpEnable(w http.ResponseWriter, r *http.Request) {
+	h.Auth.PostDashboardV1AuthTotpEnable(w, r)
+}
+
+func (h *APIHandler) PostDashboardV1AuthTotpDisable(w http.ResponseWriter, r *http.Request) {
+	h.Auth.PostDashboardV1AuthTotpDisable(w, r)
+}
+
+func (h *APIHandler) DeleteDashboardV1UserIdTotp(w http.ResponseWriter, r *http.Request, id string) {
+	h.Auth.DeleteDashboardV1UserIdTotp(w, r, id)
+}
+
+func (h *APIHandler) PostDashboardV1AuthPasswordReset(w http.ResponseWriter, r *http.Request) {
+	h.Auth.PostDashboardV1AuthPasswordReset(w, r)
+}
+
+func (h *APIHandler) PostDashboardV1AuthPasswordResetConfirm(w http.ResponseWriter, r *http.Request) {
+	h.Auth.PostDashboardV1AuthPasswordResetConfirm(w, r)
+}
+
+func (h *APIHandler) DeleteDashboardV1UserIdSessions(w http.ResponseWriter, r *http.Request, id string) {
+	h.Auth.DeleteDashboardV1UserIdSessions(w, r, id)
+}
+
 func (h *APIHandler) GetDashboardV1Payments(w http.ResponseWriter, r *http.Request, body openapigen.GetDashboardV1PaymentsParams) {
 	h.Payment.GetDashboardV1Payments(w, r, body)
 }
 
+func (h *APIHandler) GetDashboardV1PaymentsExport(w http.ResponseWriter, r *http.Request, params openapigen.GetDashboardV1PaymentsExportParams) {
+	h.Payment.GetDashboardV1PaymentsExport(w, r, params)
+}
+
+func (h *APIHandler) GetDashboardV1AnalyticsMerchants(w http.ResponseWriter, r *http.Request, params openapigen.GetDashboardV1AnalyticsMerchantsParams) {
+	h.Payment.GetDashboardV1AnalyticsMerchants(w, r, params)
+}
+
+func (h *APIHandler) GetDashboardV1AnalyticsPayments(w http.ResponseWriter, r *http.Request, params openapigen.GetDashboardV1AnalyticsPaymentsParams) {
+	h.Payment.GetDashboardV1AnalyticsPayments(w, r, params)
+}
+
+func (h *APIHandler) PostDashboardV1Payments(w http.ResponseWriter, r *http.Request, params openapigen.PostDashboardV1PaymentsParams) {
+	h.Payment.PostDashboardV1Payments(w, r, params)
+}
+
+func (h *APIHandler) GetDashboardV1PaymentId(w http.ResponseWriter, r *http.Request, id string) {
+	h.Payment.GetDashboardV1PaymentId(w, r, id)
+}
+
+func (h *APIHandler) PostDashboardV1PaymentIdNotes(w http.ResponseWriter, r *http.Request, id string) {
+	h.Payment.PostDashboardV1PaymentIdNotes(w, r, id)
+}
+
 func (h *APIHandler) PutDashboardV1PaymentIdReview(w http.ResponseWriter, r *http.Request, id string) {
 	h.Payment.PutDashboardV1PaymentIdReview(w, r, id)
 }
+
+func (h *APIHandler) PutDashboardV1PaymentIdStatus(w http.ResponseWriter, r *http.Request, id string) {
+	h.Payment.PutDashboardV1PaymentIdStatus(w, r, id)
+}
+
+func (h *APIHandler) PostDashboardV1PaymentIdRefunds(w http.ResponseWriter, r *http.Request, id string) {
+	h.Payment.PostDashboardV1PaymentIdRefunds(w, r, id)
+}
+
+func (h *APIHandler) GetDashboardV1Merchants(w http.ResponseWriter, r *http.Request, params openapigen.GetDashboardV1MerchantsParams) {
+	h.Merchant.GetDashboardV1Merchants(w, r, params)
+}
+
+func (h *APIHandler) PostDashboardV1Merchants(w http.ResponseWriter, r *http.Request) {
+	h.Merchant.PostDashboardV1Merchants(w, r)
+}
+
+func (h *APIHandler) GetDashboardV1MerchantId(w http.ResponseWriter, r *http.Request, id string) {
+	h.Merchant.GetDashboardV1MerchantId(w, r, id)
+}
+
+func (h *APIHandler) PutDashboardV1MerchantId(w http.ResponseWriter, r *http.Request, id string) {
+	h.Merchant.PutDashboardV1MerchantId(w, r, id)
+}
+
+func (h *APIHandler) DeleteDashboardV1MerchantId(w http.ResponseWriter, r *http.Request, id string) {
+	h.Merchant.DeleteDashboardV1MerchantId(w, r, id)
+}
+
+func (h *APIHandler) PostDashboardV1Exports(w http.ResponseWriter, r *http.Request) {
+	h.Export.PostDashboardV1Exports(w, r)
+}
+
+func (h *APIHandler) GetDashboardV1ExportsId(w http.ResponseWriter, r *http.Request, id string) {
+	h.Export.GetDashboardV1ExportsId(w, r, id)
+}
+
+func (h *APIHandler) GetDashboardV1ExportsIdDownload(w http.ResponseWriter, r *http.Request, id string) {
+	h.Export.GetDashboardV1ExportsIdDownload(w, r, id)
+}
+
+func (h *APIHandler) GetDashboardV1Users(w http.ResponseWriter, r *http.Request, params openapigen.GetDashboardV1UsersParams) {
+	h.User.GetDashboardV1Users(w, r, params)
+}
+
+func (h *APIHandler) PostDashboardV1Users(w http.ResponseWriter, r *http.Request) {
+	h.User.PostDashboardV1Users(w, r)
+}
+
+func (h *APIHandler) PutDashboardV1UserIdRole(w http.ResponseWriter, r *http.Request, id string) {
+	h.User.PutDashboardV1UserIdRole(w, r, id)
+}
+
+func (h *APIHandler) PutDashboardV1UserIdStatus(w http.ResponseWriter, r *http.Request, id string) {
+	h.User.PutDashboardV1UserIdStatus(w, r, id)
+}
+
+func (h *APIHandler) DeleteDashboardV1UserId(w http.ResponseWriter, r *http.Request, id string) {
+	h.User.DeleteDashboardV1UserId(w, r, id)
+}
+
+func (h *APIHandler) GetDashboardV1Lockouts(w http.ResponseWriter, r *http.Request) {
+	h.User.GetDashboardV1Lockouts(w, r)
+}
+
+func (h *APIHandler) DeleteDashboardV1LockoutsScopeKey(w http.ResponseWriter, r *http.Request, scope openapigen.LockoutScope, key string) {
+	h.User.DeleteDashboardV1LockoutsScopeKey(w, r, scope, key)
+}
+
+func (h *APIHandler) GetDashboardV1ApiKeys(w http.ResponseWriter, r *http.Request, params openapigen.GetDashboardV1ApiKeysParams) {
+	h.APIKey.GetDashboardV1ApiKeys(w, r, params)
+}
+
+func (h *APIHandler) PostDashboardV1ApiKeys(w http.ResponseWriter, r *http.Request) {
+	h.APIKey.PostDashboardV1ApiKeys(w, r)
+}
+
+func (h *APIHandler) DeleteDashboardV1ApiKeyId(w http.ResponseWriter, r *http.Request, id string) {
+	h.APIKey.DeleteDashboardV1ApiKeyId(w, r, id)
+}
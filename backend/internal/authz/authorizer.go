@@ -0,0 +1,53 @@
+package authz
+
+import (
+	"context"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/fajrinajiseno/mygolangapp/internal/middleware"
+	authRepository "github.com/fajrinajiseno/mygolangapp/internal/module/auth/repository"
+)
+
+//go:generate mockgen -source authorizer.go -destination mock/authorizer_mock.go -package=mock
+type Authorizer interface {
+	Authorize(ctx context.Context, permissions ...entity.Permission) (*entity.User, error)
+}
+
+// RoleAuthorizer grants the permissions mapped to the role of the user in role_permissions.
+type RoleAuthorizer struct {
+	userRepo authRepository.UserRepository
+}
+
+func NewAuthorizer(ur authRepository.UserRepository) *RoleAuthorizer {
+	return &RoleAuthorizer{userRepo: ur}
+}
+
+// Authorize returns the user of the request, with the permissions of their role, when the
+// role grants every one of permissions. Without permissions any active signed in user
+// passes. A request made with an API key gets the permissions of the role of its creator
+// that are among the scopes of the key.
+func (a *RoleAuthorizer) Authorize(ctx context.Context, permissions ...entity.Permission) (*entity.User, error) {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return nil, entity.ErrorNotFound("user not found")
+	}
+	user, err := a.userRepo.GetUserById(userID)
+	if err != nil {
+		return nil, entity.ErrorNotFound("user not found")
+	}
+	if user.Status == entity.UserStatusDisabled {
+		return nil, entity.ErrorUnauthorized("user disabled")
+	}
+	if user.Permissions, err = a.userRepo.GetRolePermissions(user.Role); err != nil {
+		return nil, err
+	}
+	if key := middleware.GetAPIKey(ctx); key != nil {
+		user.Permissions = key.Restrict(user.Permissions)
+	}
+	for _, p := range permissions {
+		if !user.HasPermission(p) {
+			return nil, entity.ErrorForbidden("missing permission " + string(p))
+		}
+	}
+	return user, nil
+}
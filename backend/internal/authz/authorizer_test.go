@@ -0,0 +1,112 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/config"
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	am "github.com/fajrinajiseno/mygolangapp/internal/module/auth/repository/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoleAuthorizer_Authorize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := am.NewMockUserRepository(ctrl)
+	ctx := context.WithValue(context.Background(), config.ContextUserID, "1")
+
+	t.Run("granted", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserById("1").Return(&entity.User{ID: "1", Role: "operation"}, nil)
+		mockUserRepo.EXPECT().GetRolePermissions("operation").
+			Return([]entity.Permission{entity.PermissionPaymentRead, entity.PermissionPaymentReview}, nil)
+
+		a := NewAuthorizer(mockUserRepo)
+
+		user, err := a.Authorize(ctx, entity.PermissionPaymentRead, entity.PermissionPaymentReview)
+		assert.NoError(t, err)
+		assert.Equal(t, "1", user.ID)
+		assert.True(t, user.HasPermission(entity.PermissionPaymentReview))
+	})
+
+	t.Run("missing one permission", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserById("1").Return(&entity.User{ID: "1", Role: "cs"}, nil)
+		mockUserRepo.EXPECT().GetRolePermissions("cs").
+			Return([]entity.Permission{entity.PermissionPaymentRead}, nil)
+
+		a := NewAuthorizer(mockUserRepo)
+
+		_, err := a.Authorize(ctx, entity.PermissionPaymentRead, entity.PermissionPaymentRefund)
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeForbidden, appErr.Code)
+		assert.Equal(t, "missing permission payment:refund", appErr.Message)
+	})
+
+	t.Run("api key restricts permissions to its scopes", func(t *testing.T) {
+		key := &entity.APIKey{ID: "7", CreatedBy: "1", Scopes: []entity.Permission{entity.PermissionPaymentRead, entity.PermissionPaymentRefund}}
+		keyCtx := context.WithValue(ctx, config.ContextAPIKey, key)
+		mockUserRepo.EXPECT().GetUserById("1").Return(&entity.User{ID: "1", Role: "cs"}, nil).Times(2)
+		mockUserRepo.EXPECT().GetRolePermissions("cs").
+			Return([]entity.Permission{entity.PermissionPaymentRead, entity.PermissionPaymentNote}, nil).Times(2)
+
+		a := NewAuthorizer(mockUserRepo)
+
+		user, err := a.Authorize(keyCtx, entity.PermissionPaymentRead)
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.Permission{entity.PermissionPaymentRead}, user.Permissions)
+
+		// granted to the role but not to the key
+		_, err = a.Authorize(keyCtx, entity.PermissionPaymentNote)
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeForbidden, appErr.Code)
+	})
+
+	t.Run("disabled user", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserById("1").Return(&entity.User{ID: "1", Role: "operation", Status: entity.UserStatusDisabled}, nil)
+
+		a := NewAuthorizer(mockUserRepo)
+
+		_, err := a.Authorize(ctx)
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeUnauthorized, appErr.Code)
+	})
+
+	t.Run("without user in context", func(t *testing.T) {
+		a := NewAuthorizer(mockUserRepo)
+
+		_, err := a.Authorize(context.Background(), entity.PermissionPaymentRead)
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeNotFound, appErr.Code)
+	})
+
+	t.Run("user lookup fails", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserById("1").Return(nil, errors.New("user not found"))
+
+		a := NewAuthorizer(mockUserRepo)
+
+		_, err := a.Authorize(ctx)
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeNotFound, appErr.Code)
+	})
+
+	t.Run("permission lookup fails", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserById("1").Return(&entity.User{ID: "1", Role: "cs"}, nil)
+		mockUserRepo.EXPECT().GetRolePermissions("cs").
+			Return(nil, entity.WrapError(errors.New("db fail"), entity.ErrorCodeInternal, "db error"))
+
+		a := NewAuthorizer(mockUserRepo)
+
+		_, err := a.Authorize(ctx, entity.PermissionPaymentRead)
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeInternal, appErr.Code)
+	})
+}
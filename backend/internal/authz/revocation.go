@@ -0,0 +1,122 @@
+package authz
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	authRepository "github.com/fajrinajiseno/mygolangapp/internal/module/auth/repository"
+)
+
+//go:generate mockgen -source revocation.go -destination mock/revocation_mock.go -package=mock
+type RevocationList interface {
+	IsRevoked(claims *entity.TokenClaims) bool
+	RevokeToken(claims *entity.TokenClaims) error
+	RevokeUser(userID string) error
+}
+
+// CachedRevocationList keeps the revoked_tokens and user_token_revocations tables in
+// memory so checking a token costs no query. Revocations are written to the database
+// first, revocations made by other instances are picked up on the next reload.
+type CachedRevocationList struct {
+	repo   authRepository.RevocationRepository
+	reload time.Duration
+
+	mu     sync.RWMutex
+	tokens map[string]time.Time
+	users  map[string]time.Time
+}
+
+func NewRevocationList(repo authRepository.RevocationRepository, reload time.Duration) (*CachedRevocationList, error) {
+	l := &CachedRevocationList{repo: repo, reload: reload}
+	if err := l.load(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// IsRevoked reports whether the token was revoked by its jti or issued before the tokens
+// of its user were revoked.
+func (l *CachedRevocationList) IsRevoked(claims *entity.TokenClaims) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if _, ok := l.tokens[claims.ID]; ok {
+		return true
+	}
+	before, ok := l.users[claims.Subject]
+	return ok && claims.IssuedAt.Before(before)
+}
+
+// RevokeToken revokes one access token until it expires.
+func (l *CachedRevocationList) RevokeToken(claims *entity.TokenClaims) error {
+	if err := l.repo.RevokeToken(claims.ID, claims.Subject, claims.ExpiresAt); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens[claims.ID] = claims.ExpiresAt
+	return nil
+}
+
+// RevokeUser revokes every token issued to the user so far. iat carries microseconds, so a
+// token issued right after, e.g. when the user logs in again, stays valid.
+func (l *CachedRevocationList) RevokeUser(userID string) error {
+	before := time.Now()
+	if err := l.repo.RevokeUser(userID, before); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.users[userID] = before
+	return nil
+}
+
+// Run reloads the list from the database and removes expired revocations until ctx is done.
+func (l *CachedRevocationList) Run(ctx context.Context) {
+	ticker := time.NewTicker(l.reload)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := l.repo.DeleteExpiredTokens(time.Now()); err != nil {
+			log.Printf("revocation list: %v", err)
+		}
+		if err := l.load(); err != nil {
+			log.Printf("revocation list: %v", err)
+		}
+	}
+}
+
+// load merges the database into the cache. Cached entries are kept, so a revocation made
+// while the tables were read is not lost.
+func (l *CachedRevocationList) load() error {
+	now := time.Now()
+	tokens, err := l.repo.ListRevokedTokens(now)
+	if err != nil {
+		return err
+	}
+	users, err := l.repo.ListUserRevocations()
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for jti, expiresAt := range l.tokens {
+		if _, ok := tokens[jti]; !ok && expiresAt.After(now) {
+			tokens[jti] = expiresAt
+		}
+	}
+	for userID, before := range l.users {
+		if before.After(users[userID]) {
+			users[userID] = before
+		}
+	}
+	l.tokens = tokens
+	l.users = users
+	return nil
+}
@@ -0,0 +1,80 @@
+package authz
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	am "github.com/fajrinajiseno/mygolangapp/internal/module/auth/repository/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachedRevocationList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	issued := &entity.TokenClaims{ID: "a", Subject: "1", IssuedAt: now.Add(-time.Minute), ExpiresAt: now.Add(time.Hour)}
+
+	t.Run("loads revocations", func(t *testing.T) {
+		mockRepo := am.NewMockRevocationRepository(ctrl)
+		mockRepo.EXPECT().ListRevokedTokens(gomock.Any()).Return(map[string]time.Time{"a": now.Add(time.Hour)}, nil)
+		mockRepo.EXPECT().ListUserRevocations().Return(map[string]time.Time{"2": now}, nil)
+
+		l, err := NewRevocationList(mockRepo, time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, l.IsRevoked(issued))
+		assert.False(t, l.IsRevoked(&entity.TokenClaims{ID: "b", Subject: "1", IssuedAt: now.Add(-time.Minute)}))
+		assert.True(t, l.IsRevoked(&entity.TokenClaims{ID: "c", Subject: "2", IssuedAt: now.Add(-time.Minute)}))
+		assert.False(t, l.IsRevoked(&entity.TokenClaims{ID: "d", Subject: "2", IssuedAt: now.Add(time.Minute)}))
+	})
+
+	t.Run("revoke token and user", func(t *testing.T) {
+		mockRepo := am.NewMockRevocationRepository(ctrl)
+		mockRepo.EXPECT().ListRevokedTokens(gomock.Any()).Return(map[string]time.Time{}, nil)
+		mockRepo.EXPECT().ListUserRevocations().Return(map[string]time.Time{}, nil)
+		mockRepo.EXPECT().RevokeToken("a", "1", issued.ExpiresAt).Return(nil)
+		mockRepo.EXPECT().RevokeUser("3", gomock.Any()).Return(nil)
+
+		l, err := NewRevocationList(mockRepo, time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, l.IsRevoked(issued))
+		assert.NoError(t, l.RevokeToken(issued))
+		assert.True(t, l.IsRevoked(issued))
+
+		issuedBefore := time.Now()
+		assert.NoError(t, l.RevokeUser("3"))
+		issuedAfter := time.Now()
+		assert.True(t, l.IsRevoked(&entity.TokenClaims{ID: "e", Subject: "3", IssuedAt: issuedBefore}))
+		// a login right after the revocation, most likely in the same second
+		assert.False(t, l.IsRevoked(&entity.TokenClaims{ID: "f", Subject: "3", IssuedAt: issuedAfter}))
+	})
+
+	t.Run("reload keeps cached revocations", func(t *testing.T) {
+		mockRepo := am.NewMockRevocationRepository(ctrl)
+		mockRepo.EXPECT().ListRevokedTokens(gomock.Any()).Return(map[string]time.Time{}, nil)
+		mockRepo.EXPECT().ListUserRevocations().Return(map[string]time.Time{}, nil)
+		mockRepo.EXPECT().RevokeToken("a", "1", issued.ExpiresAt).Return(nil)
+
+		l, err := NewRevocationList(mockRepo, time.Minute)
+		assert.NoError(t, err)
+		assert.NoError(t, l.RevokeToken(issued))
+
+		// the reload read the tables before the revocation was written
+		mockRepo.EXPECT().ListRevokedTokens(gomock.Any()).Return(map[string]time.Time{"b": now.Add(time.Hour)}, nil)
+		mockRepo.EXPECT().ListUserRevocations().Return(map[string]time.Time{}, nil)
+		assert.NoError(t, l.load())
+		assert.True(t, l.IsRevoked(issued))
+		assert.True(t, l.IsRevoked(&entity.TokenClaims{ID: "b", Subject: "1"}))
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mockRepo := am.NewMockRevocationRepository(ctrl)
+		mockRepo.EXPECT().ListRevokedTokens(gomock.Any()).Return(nil, errors.New("db fail"))
+
+		_, err := NewRevocationList(mockRepo, time.Minute)
+		assert.EqualError(t, err, "db fail")
+	})
+}
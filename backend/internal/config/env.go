@@ -8,17 +8,37 @@ import (
 )
 
 var (
-	JwtSecret           = []byte(getEnv("JWT_SECRET", "dev-secret-replace-me"))
-	JwtExpired          = getEnv("JWT_EXPIRED", "24h")
-	HttpAddress         = getEnv("HTTP_ADDR", ":8080")
-	Cors                = getEnv("CORS", "http://localhost:3000")
+	JwtSecret            = []byte(getEnv("JWT_SECRET", "dev-secret-replace-me"))
+	JwtExpired           = getEnv("JWT_EXPIRED", "15m")
+	RefreshTokenExpired  = getEnv("REFRESH_TOKEN_EXPIRED", "720h")
+	PasswordResetExpired = getEnv("PASSWORD_RESET_EXPIRED", "30m")
+	TotpIssuer           = getEnv("TOTP_ISSUER", "Payment Dashboard")
+	PasswordResetURL     = getEnv("PASSWORD_RESET_URL", "http://localhost:3000/reset-password")
+	CursorSecret         = []byte(getEnv("CURSOR_SECRET", "dev-cursor-secret-replace-me"))
+	HttpAddress          = getEnv("HTTP_ADDR", ":8080")
+	Cors                 = getEnv("CORS", "http://localhost:3000")
+	// TrustProxy takes client IPs from X-Forwarded-For, only set it behind a reverse proxy
+	TrustProxy          = getEnv("TRUST_PROXY", "false") == "true"
 	OpenapiYamlLocation = getEnv("OPENAPIYAML_LOCATION", "../openapi.yaml")
+	ExportDir           = getEnv("EXPORT_DIR", "exports")
+	ExportTTL           = getEnv("EXPORT_TTL", "24h")
+	// mails go through SMTP_ADDR when set and are written to MAIL_DIR otherwise
+	SmtpAddr     = getEnv("SMTP_ADDR", "")
+	SmtpUsername = getEnv("SMTP_USERNAME", "")
+	SmtpPassword = getEnv("SMTP_PASSWORD", "")
+	MailFrom     = getEnv("MAIL_FROM", "no-reply@dashboard.local")
+	MailDir      = getEnv("MAIL_DIR", "mails")
+	// signing keys are rotated in JWT_KEYS_DIR, without it tokens are signed with JWT_SECRET
+	JwtKeysDir  = getEnv("JWT_KEYS_DIR", "")
+	JwtKeyGrace = getEnv("JWT_KEY_GRACE", "1h")
 )
 
 type contextUserId string
 
 const (
-	ContextUserID contextUserId = "user_id"
+	ContextUserID      contextUserId = "user_id"
+	ContextTokenClaims contextUserId = "token_claims"
+	ContextAPIKey      contextUserId = "api_key"
 )
 
 func getEnv(key, fallback string) string {
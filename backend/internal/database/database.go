@@ -0,0 +1,14 @@
+package database
+
+import "database/sql"
+
+// RowScanner is a *sql.Row or *sql.Rows, so one scan function reads a row from either.
+type RowScanner interface {
+	Scan(dest ...any) error
+}
+
+// Execer is a *sql.DB or *sql.Tx, so one insert function writes inside or outside of a
+// transaction.
+type Execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
@@ -0,0 +1,103 @@
+package entity
+
+import "time"
+
+// AnalyticsInterval is the width of the buckets of a time series.
+type AnalyticsInterval string
+
+const (
+	AnalyticsIntervalHour  AnalyticsInterval = "hour"
+	AnalyticsIntervalDay   AnalyticsInterval = "day"
+	AnalyticsIntervalWeek  AnalyticsInterval = "week"
+	AnalyticsIntervalMonth AnalyticsInterval = "month"
+)
+
+func (i AnalyticsInterval) Valid() bool {
+	switch i {
+	case AnalyticsIntervalHour, AnalyticsIntervalDay, AnalyticsIntervalWeek, AnalyticsIntervalMonth:
+		return true
+	default:
+		return false
+	}
+}
+
+// Truncate returns the start of the bucket containing t in the location of t. Days start
+// at local midnight and weeks on Monday, so a day across a DST change lasts 23 or 25 hours.
+func (i AnalyticsInterval) Truncate(t time.Time) time.Time {
+	switch i {
+	case AnalyticsIntervalHour:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+	case AnalyticsIntervalWeek:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case AnalyticsIntervalMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+}
+
+// Next returns the start of the bucket following the one starting at start.
+func (i AnalyticsInterval) Next(start time.Time) time.Time {
+	switch i {
+	case AnalyticsIntervalHour:
+		return start.Add(time.Hour)
+	case AnalyticsIntervalWeek:
+		return start.AddDate(0, 0, 7)
+	case AnalyticsIntervalMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// PaymentAnalyticsQuery selects the payments of a time series and how they are bucketed.
+// From is inclusive and To exclusive, Timezone is an IANA name the buckets are aligned to.
+type PaymentAnalyticsQuery struct {
+	Interval   AnalyticsInterval
+	From       *time.Time
+	To         *time.Time
+	Timezone   string
+	MerchantID string
+}
+
+// PaymentAnalytics is a time series of payment volume, one bucket per interval including
+// the buckets without payments.
+type PaymentAnalytics struct {
+	Interval AnalyticsInterval
+	Timezone string
+	Buckets  []*PaymentBucket
+}
+
+// PaymentBucket counts and sums the payments created from Start up to End per status.
+type PaymentBucket struct {
+	Start    time.Time
+	End      time.Time
+	Count    int
+	Statuses []*PaymentStatusSummary
+}
+
+// MerchantReportFilter narrows the payments a merchant report covers, CreatedFrom is
+// inclusive and CreatedTo exclusive.
+type MerchantReportFilter struct {
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	Currency    string
+}
+
+// MerchantReport aggregates the payments of one merchant in one currency. Payments that
+// reached completed, including the ones refunded since, count as succeeded. Volume sums
+// all payment amounts and the rates are shares of Count.
+type MerchantReport struct {
+	MerchantID    string
+	Merchant      string
+	Currency      string
+	Count         int
+	Succeeded     int
+	Failed        int
+	Volume        int64
+	SuccessRate   float64
+	FailureRate   float64
+	AverageAmount int64
+	LastPaymentAt time.Time
+}
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyticsIntervalTruncate(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	assert.NoError(t, err)
+	at := time.Date(2025, 1, 15, 17, 30, 0, 0, time.UTC).In(jakarta) // Thursday 00:30 in Jakarta
+
+	cases := []struct {
+		interval AnalyticsInterval
+		want     time.Time
+	}{
+		{AnalyticsIntervalHour, time.Date(2025, 1, 16, 0, 0, 0, 0, jakarta)},
+		{AnalyticsIntervalDay, time.Date(2025, 1, 16, 0, 0, 0, 0, jakarta)},
+		{AnalyticsIntervalWeek, time.Date(2025, 1, 13, 0, 0, 0, 0, jakarta)},
+		{AnalyticsIntervalMonth, time.Date(2025, 1, 1, 0, 0, 0, 0, jakarta)},
+	}
+	for _, c := range cases {
+		assert.True(t, c.want.Equal(c.interval.Truncate(at)), "%s: %s", c.interval, c.interval.Truncate(at))
+	}
+
+	sunday := time.Date(2025, 1, 19, 23, 0, 0, 0, jakarta)
+	assert.True(t, time.Date(2025, 1, 13, 0, 0, 0, 0, jakarta).Equal(AnalyticsIntervalWeek.Truncate(sunday)))
+}
+
+func TestAnalyticsIntervalNextAcrossDST(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	start := AnalyticsIntervalDay.Truncate(time.Date(2025, 3, 9, 12, 0, 0, 0, newYork))
+	assert.Equal(t, 23*time.Hour, AnalyticsIntervalDay.Next(start).Sub(start))
+
+	start = AnalyticsIntervalDay.Truncate(time.Date(2025, 11, 2, 12, 0, 0, 0, newYork))
+	assert.Equal(t, 25*time.Hour, AnalyticsIntervalDay.Next(start).Sub(start))
+
+	month := AnalyticsIntervalMonth.Truncate(time.Date(2025, 1, 31, 12, 0, 0, 0, newYork))
+	assert.Equal(t, time.Date(2025, 2, 1, 0, 0, 0, 0, newYork), AnalyticsIntervalMonth.Next(month))
+}
@@ -0,0 +1,49 @@
+package entity
+
+import "time"
+
+// APIKey lets a script call the API without a user session. The key is shown once when
+// created, as dpk_<prefix>_<secret>, and only the prefix and the SHA-256 of the secret are
+// stored. A key acts for the user who created it with at most its scopes, a key with a
+// MerchantID only sees the payments of that merchant.
+type APIKey struct {
+	ID         string
+	Name       string
+	Prefix     string
+	SecretHash string
+	Scopes     []Permission
+	MerchantID string
+	CreatedBy  string
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+	RevokedAt  *time.Time
+}
+
+// APIKeyInput is the client supplied part of a new API key, an empty MerchantID makes a
+// team key seeing every merchant and a nil ExpiresAt a key that does not expire.
+type APIKeyInput struct {
+	Name       string
+	Scopes     []Permission
+	MerchantID string
+	ExpiresAt  *time.Time
+}
+
+// Restrict returns the permissions out of granted that are among the scopes of the key.
+func (k *APIKey) Restrict(granted []Permission) []Permission {
+	res := []Permission{}
+	for _, p := range granted {
+		for _, scope := range k.Scopes {
+			if p == scope {
+				res = append(res, p)
+				break
+			}
+		}
+	}
+	return res
+}
+
+// AllowsMerchant reports whether the key may see the payments of merchantID.
+func (k *APIKey) AllowsMerchant(merchantID string) bool {
+	return k.MerchantID == "" || k.MerchantID == merchantID
+}
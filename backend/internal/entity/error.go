@@ -2,6 +2,8 @@ package entity
 
 import (
 	"fmt"
+	"math"
+	"time"
 )
 
 // Code type (string for readability)
@@ -9,14 +11,15 @@ type Code string
 
 const (
 	// domain/application codes
-	ErrorCodeInternal     Code = "internal_error"
-	ErrorCodeNotFound     Code = "not_found"
-	ErrorCodeValidation   Code = "validation_error"
-	ErrorCodeUnauthorized Code = "unauthorized"
-	ErrorCodeForbidden    Code = "forbidden"
-	ErrorCodeConflict     Code = "conflict"
-	ErrorCodeBadRequest   Code = "bad_request"
-	ErrorCodeUnavailable  Code = "service_unavailable"
+	ErrorCodeInternal        Code = "internal_error"
+	ErrorCodeNotFound        Code = "not_found"
+	ErrorCodeValidation      Code = "validation_error"
+	ErrorCodeUnauthorized    Code = "unauthorized"
+	ErrorCodeForbidden       Code = "forbidden"
+	ErrorCodeConflict        Code = "conflict"
+	ErrorCodeBadRequest      Code = "bad_request"
+	ErrorCodeUnavailable     Code = "service_unavailable"
+	ErrorCodeTooManyRequests Code = "too_many_requests"
 )
 
 type AppError struct {
@@ -52,3 +55,14 @@ func ErrorInternal(msg string) *AppError     { return NewError(ErrorCodeInternal
 func ErrorForbidden(msg string) *AppError    { return NewError(ErrorCodeForbidden, msg) }
 func ErrorConflict(msg string) *AppError     { return NewError(ErrorCodeConflict, msg) }
 func ErrorBadRequest(msg string) *AppError   { return NewError(ErrorCodeBadRequest, msg) }
+
+// RetryAfter is the Details of a too many requests error, sent as the Retry-After header.
+type RetryAfter struct {
+	Seconds int `json:"retry_after"`
+}
+
+func ErrorTooManyRequests(msg string, retryAfter time.Duration) *AppError {
+	err := NewError(ErrorCodeTooManyRequests, msg)
+	err.Details = RetryAfter{Seconds: int(math.Ceil(retryAfter.Seconds()))}
+	return err
+}
@@ -0,0 +1,50 @@
+package entity
+
+import "time"
+
+// ExportFormat is the file format payments are exported to.
+type ExportFormat string
+
+const (
+	ExportFormatCSV  ExportFormat = "csv"
+	ExportFormatXLSX ExportFormat = "xlsx"
+)
+
+func (f ExportFormat) Valid() bool {
+	return f == ExportFormatCSV || f == ExportFormatXLSX
+}
+
+type ExportStatus string
+
+const (
+	ExportStatusPending   ExportStatus = "pending"
+	ExportStatusRunning   ExportStatus = "running"
+	ExportStatusCompleted ExportStatus = "completed"
+	ExportStatusFailed    ExportStatus = "failed"
+	ExportStatusExpired   ExportStatus = "expired"
+)
+
+// ExportJob is a payment export written to a file in the background. TotalRows is counted
+// when the job is created, RowsWritten grows while it runs.
+type ExportJob struct {
+	ID          string
+	UserID      string
+	Format      ExportFormat
+	Filter      PaymentFilter
+	Sort        string
+	Status      ExportStatus
+	TotalRows   int
+	RowsWritten int
+	Error       string
+	FilePath    string
+	CreatedAt   time.Time
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+	ExpiresAt   *time.Time
+}
+
+type ExportJobInput struct {
+	Format ExportFormat
+	Filter PaymentFilter
+	Sort   string
+}
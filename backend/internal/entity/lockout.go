@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// LockoutScope is what failed logins are counted by, the normalized email of the account
+// or the client IP.
+type LockoutScope string
+
+const (
+	LockoutScopeAccount LockoutScope = "account"
+	LockoutScopeIP      LockoutScope = "ip"
+)
+
+func (s LockoutScope) Valid() bool {
+	return s == LockoutScopeAccount || s == LockoutScopeIP
+}
+
+// LoginLockout counts the failed logins of one account or IP. Failures are forgotten once
+// none happened for a while, logins are refused until LockedUntil.
+type LoginLockout struct {
+	Scope         LockoutScope
+	Key           string
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   *time.Time
+}
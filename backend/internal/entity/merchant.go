@@ -0,0 +1,44 @@
+package entity
+
+import "time"
+
+type MerchantStatus string
+
+const (
+	MerchantStatusActive   MerchantStatus = "active"
+	MerchantStatusInactive MerchantStatus = "inactive"
+)
+
+func (s MerchantStatus) Valid() bool {
+	switch s {
+	case MerchantStatusActive, MerchantStatusInactive:
+		return true
+	default:
+		return false
+	}
+}
+
+type Merchant struct {
+	ID                 string         `json:"id"`
+	LegalName          string         `json:"legal_name"`
+	DisplayName        string         `json:"display_name"`
+	Status             MerchantStatus `json:"status"`
+	SettlementCurrency string         `json:"settlement_currency"`
+	ContactEmail       string         `json:"contact_email"`
+	CreatedAt          time.Time      `json:"created_at"`
+}
+
+// MerchantInput is the client supplied part of a merchant when creating or updating it,
+// an empty status keeps the current one or defaults to active for new merchants.
+type MerchantInput struct {
+	LegalName          string
+	DisplayName        string
+	Status             MerchantStatus
+	SettlementCurrency string
+	ContactEmail       string
+}
+
+// MerchantFilter narrows the merchant list, empty fields are ignored.
+type MerchantFilter struct {
+	Status MerchantStatus
+}
@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// currencyExponents maps supported ISO 4217 currency codes to the number of decimal
+// digits of their minor unit. Amounts are stored as integers in that minor unit.
+var currencyExponents = map[string]int{
+	"IDR": 0,
+	"JPY": 0,
+	"KRW": 0,
+	"VND": 0,
+	"USD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"AUD": 2,
+	"SGD": 2,
+	"MYR": 2,
+	"THB": 2,
+	"PHP": 2,
+	"BHD": 3,
+	"JOD": 3,
+	"KWD": 3,
+	"OMR": 3,
+}
+
+// CurrencyExponent returns the minor unit exponent of a supported currency.
+func CurrencyExponent(currency string) (int, bool) {
+	exp, ok := currencyExponents[currency]
+	return exp, ok
+}
+
+// ParseAmount converts a decimal string such as "150.50" into minor units of the
+// currency. More decimal digits than the currency exponent allows is an error.
+func ParseAmount(amount string, currency string) (int64, error) {
+	exp, ok := CurrencyExponent(currency)
+	if !ok {
+		return 0, fmt.Errorf("unsupported currency %q", currency)
+	}
+	whole, frac, hasFrac := strings.Cut(amount, ".")
+	if whole == "" || (hasFrac && frac == "") || !isDigits(whole) || !isDigits(frac) {
+		return 0, fmt.Errorf("invalid amount %q", amount)
+	}
+	frac = strings.TrimRight(frac, "0")
+	if len(frac) > exp {
+		return 0, fmt.Errorf("amount %q has more than %d decimals for %s", amount, exp, currency)
+	}
+	digits := whole + frac + strings.Repeat("0", exp-len(frac))
+	minor, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", amount)
+	}
+	return minor, nil
+}
+
+// FormatAmount renders minor units as a decimal string with exactly as many decimals
+// as the currency exponent, e.g. 15050 USD is "150.50" and 1500 BHD is "1.500".
+func FormatAmount(minor int64, currency string) string {
+	exp, ok := CurrencyExponent(currency)
+	if !ok || exp == 0 {
+		return strconv.FormatInt(minor, 10)
+	}
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+	}
+	abs := uint64(minor)
+	if minor < 0 {
+		abs = uint64(-(minor + 1)) + 1
+	}
+	unit := uint64(math.Pow10(exp))
+	return fmt.Sprintf("%s%d.%0*d", sign, abs/unit, exp, abs%unit)
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAmount(t *testing.T) {
+	cases := []struct {
+		amount   string
+		currency string
+		want     int64
+		wantErr  bool
+	}{
+		{"150", "IDR", 150, false},
+		{"150.00", "IDR", 150, false},
+		{"150.5", "IDR", 0, true},
+		{"150.5", "USD", 15050, false},
+		{"0.01", "USD", 1, false},
+		{"150.505", "USD", 0, true},
+		{"1.5", "BHD", 1500, false},
+		{"1.234", "BHD", 1234, false},
+		{"1.", "USD", 0, true},
+		{".5", "USD", 0, true},
+		{"-1", "USD", 0, true},
+		{"1e3", "USD", 0, true},
+		{"10", "XXX", 0, true},
+		{"99999999999999999999", "IDR", 0, true},
+	}
+	for _, c := range cases {
+		got, err := ParseAmount(c.amount, c.currency)
+		if c.wantErr {
+			assert.Error(t, err, "%s %s", c.amount, c.currency)
+			continue
+		}
+		assert.NoError(t, err, "%s %s", c.amount, c.currency)
+		assert.Equal(t, c.want, got, "%s %s", c.amount, c.currency)
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	assert.Equal(t, "150500", FormatAmount(150500, "IDR"))
+	assert.Equal(t, "150.50", FormatAmount(15050, "USD"))
+	assert.Equal(t, "0.05", FormatAmount(5, "USD"))
+	assert.Equal(t, "1.500", FormatAmount(1500, "BHD"))
+	assert.Equal(t, "-0.05", FormatAmount(-5, "USD"))
+	assert.Equal(t, "-92233720368547758.08", FormatAmount(math.MinInt64, "USD"))
+}
@@ -2,18 +2,240 @@ package entity
 
 import "time"
 
+type PaymentStatus string
+
+const (
+	PaymentStatusPending           PaymentStatus = "pending"
+	PaymentStatusProcessing        PaymentStatus = "processing"
+	PaymentStatusCompleted         PaymentStatus = "completed"
+	PaymentStatusFailed            PaymentStatus = "failed"
+	PaymentStatusRefunded          PaymentStatus = "refunded"
+	PaymentStatusPartiallyRefunded PaymentStatus = "partially_refunded"
+)
+
+// paymentTransitions lists the statuses a payment may move to from each status,
+// statuses without an entry are terminal.
+var paymentTransitions = map[PaymentStatus][]PaymentStatus{
+	PaymentStatusPending:           {PaymentStatusProcessing, PaymentStatusFailed},
+	PaymentStatusProcessing:        {PaymentStatusCompleted, PaymentStatusFailed},
+	PaymentStatusCompleted:         {PaymentStatusPartiallyRefunded, PaymentStatusRefunded},
+	PaymentStatusPartiallyRefunded: {PaymentStatusRefunded},
+}
+
+func (s PaymentStatus) Valid() bool {
+	switch s {
+	case PaymentStatusPending, PaymentStatusProcessing, PaymentStatusCompleted, PaymentStatusFailed,
+		PaymentStatusRefunded, PaymentStatusPartiallyRefunded:
+		return true
+	default:
+		return false
+	}
+}
+
+// NextStatuses returns the statuses reachable from s in a single transition.
+func (s PaymentStatus) NextStatuses() []PaymentStatus {
+	return paymentTransitions[s]
+}
+
+// Refundable reports whether refunds can still be created for a payment in status s.
+func (s PaymentStatus) Refundable() bool {
+	return s == PaymentStatusCompleted || s == PaymentStatusPartiallyRefunded
+}
+
+// SetByRefund reports whether s is only reached by creating refunds.
+func (s PaymentStatus) SetByRefund() bool {
+	return s == PaymentStatusRefunded || s == PaymentStatusPartiallyRefunded
+}
+
+func (s PaymentStatus) CanTransitionTo(next PaymentStatus) bool {
+	for _, allowed := range paymentTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+type ReviewOutcome string
+
+const (
+	ReviewOutcomeApproved ReviewOutcome = "approved"
+	ReviewOutcomeFlagged  ReviewOutcome = "flagged"
+)
+
+func (o ReviewOutcome) Valid() bool {
+	switch o {
+	case ReviewOutcomeApproved, ReviewOutcomeFlagged:
+		return true
+	default:
+		return false
+	}
+}
+
 type Payment struct {
-	ID        string    `json:"id"`
-	Merchant  string    `json:"merchant"`
-	Status    string    `json:"status"`
-	Amount    float64   `json:"amount"`
-	CreatedAt time.Time `json:"created_at"`
+	ID             string         `json:"id"`
+	MerchantID     string         `json:"merchant_id"`
+	Merchant       string         `json:"merchant"`
+	Status         PaymentStatus  `json:"status"`
+	Amount         int64          `json:"amount"`
+	Currency       string         `json:"currency"`
+	CreatedAt      time.Time      `json:"created_at"`
+	Review         *PaymentReview `json:"review,omitempty"`
+	RefundedAmount int64          `json:"refunded_amount"`
+	Refunds        []*Refund      `json:"refunds,omitempty"`
+}
+
+type Refund struct {
+	ID             string    `json:"id"`
+	PaymentID      string    `json:"payment_id"`
+	Amount         int64     `json:"amount"`
+	Reason         string    `json:"reason"`
+	CreatedBy      string    `json:"created_by"`
+	CreatedByEmail string    `json:"created_by_email,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type PaymentReview struct {
+	ID            string        `json:"id"`
+	PaymentID     string        `json:"payment_id"`
+	ReviewerID    string        `json:"reviewer_id"`
+	ReviewerEmail string        `json:"reviewer_email"`
+	Outcome       ReviewOutcome `json:"outcome"`
+	Note          string        `json:"note"`
+	ReviewedAt    time.Time     `json:"reviewed_at"`
+}
+
+// PaymentStatusChange is an audit record of a single status transition.
+type PaymentStatusChange struct {
+	ID             string        `json:"id"`
+	PaymentID      string        `json:"payment_id"`
+	FromStatus     PaymentStatus `json:"from_status"`
+	ToStatus       PaymentStatus `json:"to_status"`
+	ChangedBy      string        `json:"changed_by"`
+	ChangedByEmail string        `json:"changed_by_email,omitempty"`
+	Reason         string        `json:"reason"`
+	ChangedAt      time.Time     `json:"changed_at"`
+}
+
+// PaymentNote is a free text note left on a payment by a dashboard user.
+type PaymentNote struct {
+	ID          string    `json:"id"`
+	PaymentID   string    `json:"payment_id"`
+	AuthorID    string    `json:"author_id"`
+	AuthorEmail string    `json:"author_email"`
+	Body        string    `json:"body"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type PaymentEventType string
+
+const (
+	PaymentEventCreated       PaymentEventType = "created"
+	PaymentEventStatusChanged PaymentEventType = "status_changed"
+	PaymentEventReviewed      PaymentEventType = "reviewed"
+	PaymentEventRefunded      PaymentEventType = "refunded"
+	PaymentEventNoteAdded     PaymentEventType = "note_added"
+)
+
+// PaymentEvent is one entry of a payment timeline. ID is the id of the record the event
+// comes from and only the fields relevant to the event type are set.
+type PaymentEvent struct {
+	Type       PaymentEventType
+	ID         string
+	At         time.Time
+	ActorID    string
+	ActorEmail string
+	FromStatus PaymentStatus
+	ToStatus   PaymentStatus
+	Outcome    ReviewOutcome
+	Amount     int64
+	Note       string
+}
+
+// CreatePaymentInput is the client supplied part of a new payment.
+type CreatePaymentInput struct {
+	MerchantID string `json:"merchant_id"`
+	Amount     string `json:"amount"`
+	Currency   string `json:"currency"`
+}
+
+// IdempotencyKey remembers the request and response of a create call so a retry with
+// the same key replays the original response instead of creating a second payment.
+type IdempotencyKey struct {
+	Key         string
+	UserID      string
+	RequestHash string
+	PaymentID   string
+	Response    []byte
+	CreatedAt   time.Time
+}
+
+// PaymentFilter narrows the payment list, empty fields are ignored. CreatedFrom is
+// inclusive and CreatedTo exclusive, the amount bounds are inclusive minor units.
+type PaymentFilter struct {
+	Status      PaymentStatus
+	ID          string
+	MerchantID  string
+	Reviewed    *bool
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	Currency    string
+	// AmountMin and AmountMax are in the minor unit of Currency, which they require.
+	AmountMin *int64
+	AmountMax *int64
+	// Search matches payment ids and merchant names by word prefix.
+	Search string
+}
+
+// PageCursor is a position in a keyset paginated list: the sort key values of a row, the
+// row id last. Before selects the page ending before the row instead of the one after it.
+type PageCursor struct {
+	Key    []string
+	Before bool
+}
+
+// PaymentPage is one page of the payment list. NextKey and PrevKey are set when there are
+// payments after or before the page, NextCursor and PrevCursor are their signed tokens.
+type PaymentPage struct {
+	Payments   []*Payment
+	Summary    *PaymentSummary
+	NextKey    []string
+	PrevKey    []string
+	NextCursor string
+	PrevCursor string
+}
+
+// PaymentSummaryScope selects which payments the summary of a payment list covers.
+type PaymentSummaryScope string
+
+const (
+	PaymentSummaryScopeAll      PaymentSummaryScope = "all"
+	PaymentSummaryScopeFiltered PaymentSummaryScope = "filtered"
+)
+
+func (s PaymentSummaryScope) Valid() bool {
+	return s == PaymentSummaryScopeAll || s == PaymentSummaryScopeFiltered
 }
 
 type PaymentSummary struct {
+	Scope          PaymentSummaryScope
 	TotalByFiler   int
 	Total          int
 	TotalCompleted int
 	TotalFailed    int
 	TotalPending   int
+	Statuses       []*PaymentStatusSummary
+}
+
+// PaymentStatusSummary counts the payments in one status and sums their amounts, amounts
+// are only summed within a currency.
+type PaymentStatusSummary struct {
+	Status  PaymentStatus
+	Count   int
+	Amounts []CurrencyAmount
+}
+
+type CurrencyAmount struct {
+	Currency string
+	Amount   int64
 }
@@ -0,0 +1,45 @@
+package entity
+
+import "time"
+
+// RefreshToken is a long-lived opaque token exchanged for a new access token, only the
+// SHA-256 of the token is stored. Tokens issued by rotating another one share its FamilyID
+// so replaying a used token revokes the whole family.
+type RefreshToken struct {
+	ID        string
+	UserID    string
+	FamilyID  string
+	TokenHash string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UsedAt    *time.Time
+	RevokedAt *time.Time
+}
+
+// AuthTokens are issued on login and on every refresh.
+type AuthTokens struct {
+	AccessToken      string
+	AccessExpiresAt  time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
+
+// TokenClaims are the claims of a verified access token. ID is the jti claim the token is
+// revoked by.
+type TokenClaims struct {
+	ID        string
+	Subject   string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// PasswordResetToken lets the owner of an email address set a new password once before
+// ExpiresAt, only the SHA-256 of the token is stored.
+type PasswordResetToken struct {
+	ID        string
+	UserID    string
+	TokenHash string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UsedAt    *time.Time
+}
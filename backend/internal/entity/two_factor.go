@@ -0,0 +1,45 @@
+package entity
+
+import "time"
+
+// TOTP is the authenticator secret of a user. It is pending until the user confirms it with
+// a code, LastStep is the time step of the last accepted code so every code works once.
+type TOTP struct {
+	UserID    string
+	Secret    string
+	EnabledAt *time.Time
+	LastStep  int64
+}
+
+func (t *TOTP) Enabled() bool {
+	return t != nil && t.EnabledAt != nil
+}
+
+// TOTPEnrollment is a new pending secret, ProvisioningURI is the otpauth URI shown as a QR
+// code to the user.
+type TOTPEnrollment struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+// LoginChallenge is issued when the password of a user with two-factor authentication is
+// verified, its token is exchanged together with a code for AuthTokens. Only the SHA-256
+// of the token is stored.
+type LoginChallenge struct {
+	ID        string
+	UserID    string
+	TokenHash string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	Attempts  int
+	UsedAt    *time.Time
+}
+
+// LoginResult is the outcome of a password login, either Tokens or, when the user still
+// has to enter a two-factor code, a challenge token.
+type LoginResult struct {
+	User               *User
+	Tokens             *AuthTokens
+	ChallengeToken     string
+	ChallengeExpiresAt time.Time
+}
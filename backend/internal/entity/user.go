@@ -1,8 +1,88 @@
 package entity
 
+import (
+	"fmt"
+	"time"
+)
+
 type User struct {
-	ID           string `json:"id"`
-	Email        string `json:"email"`
-	PasswordHash string `json:"-"`
-	Role         string `json:"role"`
+	ID           string       `json:"id"`
+	Email        string       `json:"email"`
+	PasswordHash string       `json:"-"`
+	Role         string       `json:"role"`
+	Status       UserStatus   `json:"status"`
+	CreatedAt    time.Time    `json:"created_at"`
+	Permissions  []Permission `json:"permissions,omitempty"`
+}
+
+type UserStatus string
+
+const (
+	UserStatusActive   UserStatus = "active"
+	UserStatusDisabled UserStatus = "disabled"
+)
+
+func (s UserStatus) Valid() bool {
+	return s == UserStatusActive || s == UserStatusDisabled
+}
+
+// UserInput is the client supplied part of a new user.
+type UserInput struct {
+	Email    string
+	Password string
+	Role     string
+}
+
+// MinPasswordLength is the shortest password a user may be given.
+const MinPasswordLength = 8
+
+func ValidatePassword(password string) error {
+	if len(password) < MinPasswordLength {
+		return ErrorValidation(fmt.Sprintf("password must be at least %d characters", MinPasswordLength))
+	}
+	return nil
+}
+
+// UserFilter narrows the user list, empty fields are ignored.
+type UserFilter struct {
+	Role   string
+	Status UserStatus
+}
+
+// Permission is an action a role may be granted, roles are mapped to permissions in the
+// role_permissions table.
+type Permission string
+
+const (
+	PermissionPaymentRead         Permission = "payment:read"
+	PermissionPaymentCreate       Permission = "payment:create"
+	PermissionPaymentReview       Permission = "payment:review"
+	PermissionPaymentUpdateStatus Permission = "payment:update_status"
+	PermissionPaymentRefund       Permission = "payment:refund"
+	PermissionPaymentNote         Permission = "payment:note"
+	PermissionMerchantRead        Permission = "merchant:read"
+	PermissionMerchantManage      Permission = "merchant:manage"
+	PermissionUserManage          Permission = "user:manage"
+	PermissionAPIKeyManage        Permission = "api_key:manage"
+)
+
+func (p Permission) Valid() bool {
+	switch p {
+	case PermissionPaymentRead, PermissionPaymentCreate, PermissionPaymentReview, PermissionPaymentUpdateStatus,
+		PermissionPaymentRefund, PermissionPaymentNote, PermissionMerchantRead, PermissionMerchantManage, PermissionUserManage,
+		PermissionAPIKeyManage:
+		return true
+	default:
+		return false
+	}
+}
+
+// HasPermission reports whether the permissions of the user include p.
+func (u *User) HasPermission(p Permission) bool {
+	for _, granted := range u.Permissions {
+		if granted == p {
+			return true
+		}
+	}
+	return false
 }
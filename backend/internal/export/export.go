@@ -0,0 +1,33 @@
+package export
+
+import (
+	"io"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+)
+
+// Writer writes a table row by row, nothing is kept in memory after a row is written.
+// Values are strings or int64, anything else is written with fmt.
+type Writer interface {
+	WriteRow(values ...any) error
+	// Close flushes the remaining output, it does not close the underlying writer.
+	Close() error
+}
+
+func NewWriter(format entity.ExportFormat, w io.Writer) (Writer, error) {
+	switch format {
+	case entity.ExportFormatCSV:
+		return newCSVWriter(w), nil
+	case entity.ExportFormatXLSX:
+		return newXLSXWriter(w, "Payments")
+	default:
+		return nil, entity.ErrorValidation("unsupported export format " + string(format))
+	}
+}
+
+func ContentType(format entity.ExportFormat) string {
+	if format == entity.ExportFormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
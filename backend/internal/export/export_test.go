@@ -0,0 +1,70 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var payment = &entity.Payment{
+	ID:             "7",
+	MerchantID:     "3",
+	Merchant:       "=HYPERLINK(\"x\") & <Toko>",
+	Status:         entity.PaymentStatusPartiallyRefunded,
+	Amount:         15050,
+	RefundedAmount: 5000,
+	Currency:       "USD",
+	CreatedAt:      time.Date(2025, 1, 2, 10, 0, 0, 0, time.FixedZone("WIB", 7*60*60)),
+	Review:         &entity.PaymentReview{Outcome: entity.ReviewOutcomeApproved},
+}
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(entity.ExportFormatCSV, &buf)
+	require.NoError(t, err)
+	require.NoError(t, w.WriteRow(PaymentHeader...))
+	require.NoError(t, w.WriteRow(PaymentRow(payment)...))
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, "id,merchant_id,merchant,status,currency,amount,amount_minor,refunded_amount,reviewed,review_outcome,created_at\n"+
+		"7,3,\"'=HYPERLINK(\"\"x\"\") & <Toko>\",partially_refunded,USD,150.50,15050,50.00,true,approved,2025-01-02T03:00:00Z\n", buf.String())
+}
+
+func TestXLSXWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(entity.ExportFormatXLSX, &buf)
+	require.NoError(t, err)
+	require.NoError(t, w.WriteRow(PaymentHeader...))
+	require.NoError(t, w.WriteRow(PaymentRow(payment)...))
+	require.NoError(t, w.Close())
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	names := []string{}
+	var sheet string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			rc, err := f.Open()
+			require.NoError(t, err)
+			body, _ := io.ReadAll(rc)
+			rc.Close()
+			sheet = string(body)
+		}
+	}
+	assert.ElementsMatch(t, []string{"[Content_Types].xml", "_rels/.rels", "xl/_rels/workbook.xml.rels", "xl/workbook.xml", "xl/worksheets/sheet1.xml"}, names)
+	assert.Contains(t, sheet, `<c t="inlineStr"><is><t xml:space="preserve">=HYPERLINK(&#34;x&#34;) &amp; &lt;Toko&gt;</t></is></c>`)
+	assert.Contains(t, sheet, "<c><v>15050</v></c>")
+	assert.Contains(t, sheet, "</row></sheetData></worksheet>")
+}
+
+func TestNewWriter_UnsupportedFormat(t *testing.T) {
+	_, err := NewWriter("pdf", io.Discard)
+	assert.Error(t, err)
+}
@@ -0,0 +1,28 @@
+package export
+
+import (
+	"time"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+)
+
+// PaymentHeader names the columns of PaymentRow.
+var PaymentHeader = []any{
+	"id", "merchant_id", "merchant", "status", "currency", "amount", "amount_minor",
+	"refunded_amount", "reviewed", "review_outcome", "created_at",
+}
+
+// PaymentRow is the exported row of a payment, amounts are both formatted in the currency
+// and kept in minor units for calculations.
+func PaymentRow(p *entity.Payment) []any {
+	reviewed, outcome := "false", ""
+	if p.Review != nil {
+		reviewed, outcome = "true", string(p.Review.Outcome)
+	}
+	return []any{
+		p.ID, p.MerchantID, p.Merchant, string(p.Status), p.Currency,
+		entity.FormatAmount(p.Amount, p.Currency), p.Amount,
+		entity.FormatAmount(p.RefundedAmount, p.Currency), reviewed, outcome,
+		p.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,197 @@
+package jwtkey
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// KeyIDLayout is the layout of the kid of the keys loaded from a directory, their creation
+// time in UTC. A key signs from its creation time on.
+const KeyIDLayout = "20060102T150405Z"
+
+// minRSABits is the smallest RSA modulus accepted for RS256.
+const minRSABits = 2048
+
+// Key is a JWT signing key. HS256 keys sign and verify with the same secret, RS256 and EdDSA
+// keys are asymmetric and their public half is published in the JWKS.
+type Key struct {
+	// ID is the kid header of the tokens it signs, empty for the JWT_SECRET key.
+	ID        string
+	CreatedAt time.Time
+	method    jwt.SigningMethod
+	signKey   any
+	verifyKey any
+}
+
+func NewHMACKey(id string, secret []byte, createdAt time.Time) (*Key, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("empty secret")
+	}
+	return &Key{ID: id, CreatedAt: createdAt, method: jwt.SigningMethodHS256, signKey: secret, verifyKey: secret}, nil
+}
+
+// NewPrivateKey returns an RS256 key for an *rsa.PrivateKey and an EdDSA key for an
+// ed25519.PrivateKey.
+func NewPrivateKey(id string, private any, createdAt time.Time) (*Key, error) {
+	switch k := private.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < minRSABits {
+			return nil, fmt.Errorf("rsa key of %d bits, need at least %d", k.N.BitLen(), minRSABits)
+		}
+		return &Key{ID: id, CreatedAt: createdAt, method: jwt.SigningMethodRS256, signKey: k, verifyKey: &k.PublicKey}, nil
+	case ed25519.PrivateKey:
+		return &Key{ID: id, CreatedAt: createdAt, method: jwt.SigningMethodEdDSA, signKey: k, verifyKey: k.Public()}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", private)
+	}
+}
+
+// Algorithm returns the alg header of the tokens signed by k.
+func (k *Key) Algorithm() string {
+	return k.method.Alg()
+}
+
+// Keyring signs tokens with its newest key and verifies them with the key named by their kid.
+// A key replaced by a newer one keeps verifying for the grace period, so the tokens it signed
+// stay valid until they expire.
+type Keyring struct {
+	// keys sorted from the oldest
+	keys  []*Key
+	grace time.Duration
+}
+
+func NewKeyring(grace time.Duration, keys ...*Key) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no signing key")
+	}
+	seen := map[string]bool{}
+	for _, k := range keys {
+		if seen[k.ID] {
+			return nil, fmt.Errorf("duplicate key id %q", k.ID)
+		}
+		seen[k.ID] = true
+	}
+	sorted := append([]*Key(nil), keys...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].CreatedAt.Before(sorted[j].CreatedAt) })
+	return &Keyring{keys: sorted, grace: grace}, nil
+}
+
+// NewHMACKeyring returns a keyring of the single HS256 key secret, signing tokens without kid.
+func NewHMACKeyring(secret []byte) *Keyring {
+	key, err := NewHMACKey("", secret, time.Time{})
+	if err != nil {
+		panic(err)
+	}
+	return &Keyring{keys: []*Key{key}}
+}
+
+// Sign returns the token of claims signed by the current key.
+func (r *Keyring) Sign(claims jwt.Claims) (string, error) {
+	key := r.signingKey(time.Now())
+	token := jwt.NewWithClaims(key.method, claims)
+	if key.ID != "" {
+		token.Header["kid"] = key.ID
+	}
+	return token.SignedString(key.signKey)
+}
+
+// Keyfunc resolves the verification key of t for jwt.Parse. The key must still be in its
+// grace period and the alg of t must be the one of the key, so a public key is never used
+// as an HMAC secret.
+func (r *Keyring) Keyfunc(t *jwt.Token) (any, error) {
+	kid, _ := t.Header["kid"].(string)
+	key := r.verifyingKey(kid, time.Now())
+	if key == nil {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	if t.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %s", t.Method.Alg())
+	}
+	return key.verifyKey, nil
+}
+
+// JWKS returns the public keys other services verify our tokens with, including the keys
+// that do not sign yet. HS256 keys are never published.
+func (r *Keyring) JWKS() JWKS {
+	return r.jwks(time.Now())
+}
+
+// signingKey returns the newest key created by now, the oldest when all are newer.
+func (r *Keyring) signingKey(now time.Time) *Key {
+	current := r.keys[0]
+	for _, k := range r.keys[1:] {
+		if k.CreatedAt.After(now) {
+			break
+		}
+		current = k
+	}
+	return current
+}
+
+func (r *Keyring) verifyingKey(kid string, now time.Time) *Key {
+	for i, k := range r.keys {
+		if k.ID == kid {
+			if !r.retired(i, now) {
+				return k
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// retired reports whether the key at i was replaced by a newer key longer than the grace
+// period ago.
+func (r *Keyring) retired(i int, now time.Time) bool {
+	return i+1 < len(r.keys) && !now.Before(r.keys[i+1].CreatedAt.Add(r.grace))
+}
+
+// JWKS is a JSON Web Key Set (RFC 7517).
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK is a public key in the JSON Web Key format, n and e are set for RSA keys and crv and
+// x for Ed25519 keys (RFC 8037).
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+func (r *Keyring) jwks(now time.Time) JWKS {
+	set := JWKS{Keys: []JWK{}}
+	for i, k := range r.keys {
+		if r.retired(i, now) {
+			continue
+		}
+		jwk := JWK{Use: "sig", Algorithm: k.method.Alg(), KeyID: k.ID}
+		switch pub := k.verifyKey.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		default:
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
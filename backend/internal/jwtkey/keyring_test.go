@@ -0,0 +1,133 @@
+package jwtkey
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEd25519Key(t *testing.T, id string, createdAt time.Time) *Key {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	key, err := NewPrivateKey(id, private, createdAt)
+	require.NoError(t, err)
+	return key
+}
+
+func TestKeyring(t *testing.T) {
+	now := time.Now()
+	legacy, err := NewHMACKey("", []byte("secret"), time.Time{})
+	require.NoError(t, err)
+	old := newEd25519Key(t, "old", now.Add(-2*time.Hour))
+	current := newEd25519Key(t, "current", now.Add(-30*time.Minute))
+	next := newEd25519Key(t, "next", now.Add(time.Hour))
+	ring, err := NewKeyring(time.Hour, next, legacy, current, old)
+	require.NoError(t, err)
+
+	t.Run("signs with the newest active key", func(t *testing.T) {
+		signed, err := ring.Sign(jwt.MapClaims{"sub": "1"})
+		require.NoError(t, err)
+		tkn, err := jwt.Parse(signed, ring.Keyfunc)
+		require.NoError(t, err)
+		assert.Equal(t, "current", tkn.Header["kid"])
+		assert.Equal(t, "EdDSA", tkn.Method.Alg())
+		assert.Equal(t, next, ring.signingKey(now.Add(2*time.Hour)))
+	})
+
+	t.Run("replaced keys verify during the grace period", func(t *testing.T) {
+		assert.Equal(t, old, ring.verifyingKey("old", now))
+		assert.Nil(t, ring.verifyingKey("old", now.Add(31*time.Minute)))
+		assert.Nil(t, ring.verifyingKey("", now), "legacy key replaced long ago")
+		assert.Nil(t, ring.verifyingKey("unknown", now))
+	})
+
+	t.Run("rejects the alg of another key type", func(t *testing.T) {
+		// an HMAC token keyed with the published public key
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "1"})
+		token.Header["kid"] = "current"
+		signed, err := token.SignedString([]byte(current.verifyKey.(ed25519.PublicKey)))
+		require.NoError(t, err)
+		_, err = jwt.Parse(signed, ring.Keyfunc)
+		assert.ErrorContains(t, err, "unexpected signing method")
+	})
+
+	t.Run("jwks publishes the public keys not retired", func(t *testing.T) {
+		set := ring.jwks(now.Add(31 * time.Minute))
+		require.Len(t, set.Keys, 2)
+		assert.Equal(t, "current", set.Keys[0].KeyID)
+		assert.Equal(t, "next", set.Keys[1].KeyID)
+		assert.Equal(t, "OKP", set.Keys[1].KeyType)
+		assert.Equal(t, "Ed25519", set.Keys[1].Curve)
+		assert.Equal(t, base64.RawURLEncoding.EncodeToString(next.verifyKey.(ed25519.PublicKey)), set.Keys[1].X)
+	})
+
+	t.Run("duplicate key id", func(t *testing.T) {
+		_, err := NewKeyring(time.Hour, old, old)
+		assert.Error(t, err)
+	})
+}
+
+func TestHMACKeyring(t *testing.T) {
+	ring := NewHMACKeyring([]byte("secret"))
+	signed, err := ring.Sign(jwt.MapClaims{"sub": "1"})
+	require.NoError(t, err)
+
+	tkn, err := jwt.Parse(signed, func(*jwt.Token) (any, error) { return []byte("secret"), nil })
+	require.NoError(t, err)
+	assert.NotContains(t, tkn.Header, "kid")
+	_, err = jwt.Parse(signed, ring.Keyfunc)
+	assert.NoError(t, err)
+	assert.Empty(t, ring.JWKS().Keys)
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, minRSABits)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(edKey)
+	require.NoError(t, err)
+
+	write := func(name string, content []byte) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), content, 0o600))
+	}
+	write("20261001T000000Z.secret", []byte("rotated-secret\n"))
+	write("20261002T000000Z.pem", pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}))
+	write("20261003T000000Z.pem", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	write("README.md", []byte("ignored"))
+
+	ring, err := Load(dir, []byte("legacy"), time.Hour)
+	require.NoError(t, err)
+	require.Len(t, ring.keys, 4)
+	algs := []string{}
+	for _, k := range ring.keys {
+		algs = append(algs, k.Algorithm())
+	}
+	assert.Equal(t, []string{"HS256", "HS256", "RS256", "EdDSA"}, algs)
+	assert.Equal(t, []byte("rotated-secret"), ring.keys[1].signKey)
+	assert.Equal(t, time.Date(2026, 10, 3, 0, 0, 0, 0, time.UTC), ring.keys[3].CreatedAt)
+
+	t.Run("file name not a creation time", func(t *testing.T) {
+		write("latest.secret", []byte("secret"))
+		_, err := Load(dir, []byte("legacy"), time.Hour)
+		assert.ErrorContains(t, err, "latest.secret")
+	})
+
+	t.Run("without directory", func(t *testing.T) {
+		ring, err := Load("", []byte("legacy"), time.Hour)
+		require.NoError(t, err)
+		assert.Len(t, ring.keys, 1)
+	})
+}
@@ -0,0 +1,87 @@
+package jwtkey
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// SecretExt is the extension of the files holding an HS256 secret.
+	SecretExt = ".secret"
+	// PEMExt is the extension of the files holding a PEM encoded RSA or Ed25519 private key.
+	PEMExt = ".pem"
+)
+
+// Load returns the keyring of legacySecret, the JWT_SECRET signing tokens without kid, and of
+// the key files in dir. Files are named by their kid in KeyIDLayout, so the legacy secret is
+// only accepted for grace after the first of them. Other files in dir are ignored.
+func Load(dir string, legacySecret []byte, grace time.Duration) (*Keyring, error) {
+	legacy, err := NewHMACKey("", legacySecret, time.Time{})
+	if err != nil {
+		return nil, fmt.Errorf("JWT_SECRET: %w", err)
+	}
+	keys := []*Key{legacy}
+	if dir == "" {
+		return NewKeyring(grace, keys...)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != SecretExt && ext != PEMExt) {
+			continue
+		}
+		key, err := loadKeyFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("jwt key %s: %w", entry.Name(), err)
+		}
+		keys = append(keys, key)
+	}
+	return NewKeyring(grace, keys...)
+}
+
+func loadKeyFile(path string) (*Key, error) {
+	name := filepath.Base(path)
+	ext := filepath.Ext(name)
+	kid := strings.TrimSuffix(name, ext)
+	createdAt, err := time.Parse(KeyIDLayout, kid)
+	if err != nil {
+		return nil, fmt.Errorf("file name must be the creation time like %s", KeyIDLayout)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if ext == SecretExt {
+		return NewHMACKey(kid, bytes.TrimSpace(content), createdAt)
+	}
+
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, errors.New("no PEM block")
+	}
+	var private any
+	switch block.Type {
+	case "PRIVATE KEY":
+		private, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		private, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewPrivateKey(kid, private, createdAt)
+}
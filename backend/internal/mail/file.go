@@ -0,0 +1,46 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+)
+
+// FileMailer writes every message to its own .eml file in dir and logs where, so mails can
+// be read without a mail server during local development and tests.
+type FileMailer struct {
+	dir  string
+	from string
+	seq  atomic.Uint64
+}
+
+func NewFileMailer(dir, from string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "mail dir error")
+	}
+	return &FileMailer{dir: dir, from: from}, nil
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	now := time.Now()
+	data, err := format(m.from, msg, now)
+	if err != nil {
+		return err
+	}
+	name := filepath.Join(m.dir, fmt.Sprintf("%s-%d.eml", now.UTC().Format("20060102T150405.000000000"), m.seq.Add(1)))
+	// the body may hold a password reset link, keep it readable by the owner only
+	if err := os.WriteFile(name, data, 0o600); err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "mail file error")
+	}
+	log.Printf("mail %q to %s written to %s", msg.Subject, msg.To, name)
+	return nil
+}
@@ -0,0 +1,136 @@
+package mail
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	date := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("Headers And Body", func(t *testing.T) {
+		data, err := format("no-reply@test.com", Message{To: "cs@test.com", Subject: "Reset", Body: "line 1\nline 2"}, date)
+		require.NoError(t, err)
+		assert.Equal(t, "From: no-reply@test.com\r\nTo: cs@test.com\r\nSubject: Reset\r\n"+
+			"Date: Tue, 02 Jan 2024 03:04:05 +0000\r\nMIME-Version: 1.0\r\n"+
+			"Content-Type: text/plain; charset=utf-8\r\nContent-Transfer-Encoding: 8bit\r\n\r\n"+
+			"line 1\r\nline 2\r\n", string(data))
+	})
+
+	t.Run("Header Injection", func(t *testing.T) {
+		_, err := format("no-reply@test.com", Message{To: "cs@test.com\r\nBcc: x@test.com", Subject: "Reset"}, date)
+		assert.Error(t, err)
+		_, err = format("no-reply@test.com", Message{To: "cs@test.com", Subject: "Reset\nBcc: x@test.com"}, date)
+		assert.Error(t, err)
+	})
+}
+
+func TestFileMailer(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mails")
+	m, err := NewFileMailer(dir, "no-reply@test.com")
+	require.NoError(t, err)
+
+	require.NoError(t, m.Send(context.Background(), Message{To: "cs@test.com", Subject: "Reset", Body: "token"}))
+	require.NoError(t, m.Send(context.Background(), Message{To: "operation@test.com", Subject: "Reset", Body: "token"}))
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	data, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "To: cs@test.com\r\n")
+	assert.True(t, strings.HasSuffix(string(data), "\r\n\r\ntoken\r\n"))
+}
+
+func TestSMTPMailer(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	received := make(chan []string, 1)
+	go serveSMTP(ln, received)
+
+	m, err := NewSMTPMailer(ln.Addr().String(), "", "", "no-reply@test.com")
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, m.Send(ctx, Message{To: "cs@test.com", Subject: "Reset", Body: "token"}))
+
+	lines := <-received
+	assert.Contains(t, lines, "MAIL FROM:<no-reply@test.com>")
+	assert.Contains(t, lines, "RCPT TO:<cs@test.com>")
+	assert.Contains(t, lines, "To: cs@test.com")
+	assert.Contains(t, lines, "token")
+}
+
+func TestSMTPMailer_Timeout(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	// accept the connection but never greet
+	go func() {
+		conn, err := ln.Accept()
+		if err == nil {
+			defer conn.Close()
+			time.Sleep(5 * time.Second)
+		}
+	}()
+
+	m, err := NewSMTPMailer(ln.Addr().String(), "", "", "no-reply@test.com")
+	require.NoError(t, err)
+	m.timeout = 100 * time.Millisecond
+	start := time.Now()
+	err = m.Send(context.Background(), Message{To: "cs@test.com", Subject: "Reset", Body: "token"})
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+// serveSMTP accepts one connection, answers every command with success and sends the
+// lines it received once the client quits.
+func serveSMTP(ln net.Listener, received chan<- []string) {
+	conn, err := ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(s string) { _, _ = conn.Write([]byte(s + "\r\n")) }
+	reply("220 localhost ESMTP")
+	var lines []string
+	inData := false
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		lines = append(lines, line)
+		switch {
+		case inData:
+			if line == "." {
+				inData = false
+				reply("250 OK")
+			}
+		case strings.HasPrefix(line, "EHLO"), strings.HasPrefix(line, "HELO"):
+			reply("250 localhost")
+		case line == "DATA":
+			inData = true
+			reply("354 go ahead")
+		case line == "QUIT":
+			reply("221 bye")
+			received <- lines
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
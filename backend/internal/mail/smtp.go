@@ -0,0 +1,93 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/smtp"
+	"time"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+)
+
+// smtpTimeout is how long a delivery may take at most.
+const smtpTimeout = time.Minute
+
+// SMTPMailer delivers messages through an SMTP relay. The connection is upgraded with
+// STARTTLS when the server offers it and authenticated with PLAIN when a username is set.
+type SMTPMailer struct {
+	addr string
+	host string
+	auth smtp.Auth
+	from string
+	// timeout bounds a delivery when the context has no earlier deadline
+	timeout time.Duration
+}
+
+func NewSMTPMailer(addr, username, password, from string) (*SMTPMailer, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeValidation, "invalid smtp address")
+	}
+	m := &SMTPMailer{addr: addr, host: host, from: from, timeout: smtpTimeout}
+	if username != "" {
+		m.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return m, nil
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	data, err := format(m.from, msg, time.Now())
+	if err != nil {
+		return err
+	}
+	deadline := time.Now().Add(m.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn, err := (&net.Dialer{Deadline: deadline}).DialContext(ctx, "tcp", m.addr)
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "smtp error")
+	}
+	_ = conn.SetDeadline(deadline)
+	c, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		conn.Close()
+		return entity.WrapError(err, entity.ErrorCodeInternal, "smtp error")
+	}
+	defer c.Close()
+	if err := m.send(c, msg.To, data); err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "smtp error")
+	}
+	return nil
+}
+
+func (m *SMTPMailer) send(c *smtp.Client, to string, data []byte) error {
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: m.host}); err != nil {
+			return err
+		}
+	}
+	if m.auth != nil {
+		if err := c.Auth(m.auth); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(m.from); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
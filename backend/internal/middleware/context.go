@@ -1,26 +1,37 @@
 package middleware
 
 import (
-	"context"
 	"net/http"
 
 	"github.com/fajrinajiseno/mygolangapp/internal/config"
+	"github.com/fajrinajiseno/mygolangapp/internal/jwtkey"
 )
 
-func ContextMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if v := r.Context().Value(config.ContextUserID); v != nil {
-			next.ServeHTTP(w, r)
-			return
-		}
+// NewContextMiddleware puts the identity of the request in its context for the handlers, the
+// claims of a valid bearer token or else the API key of the X-API-Key header. Whether the
+// operation accepts it is checked by the AuthMiddleware, which also rejects requests sending
+// both.
+func NewContextMiddleware(keys *jwtkey.Keyring, apiKeys APIKeyAuthenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if v := r.Context().Value(config.ContextUserID); v != nil || hasBothCredentials(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
 
-		sub, err := GetTokenSub(r)
-		if err != nil {
-			next.ServeHTTP(w, r)
-			return
-		}
+			if claims, err := ParseToken(r, keys); err == nil {
+				next.ServeHTTP(w, r.WithContext(withTokenClaims(r.Context(), claims)))
+				return
+			}
 
-		newReq := r.WithContext(context.WithValue(r.Context(), config.ContextUserID, sub))
-		next.ServeHTTP(w, newReq)
-	})
+			if raw := r.Header.Get(APIKeyHeader); raw != "" && apiKeys != nil {
+				if key, err := apiKeys.AuthenticateAPIKey(raw); err == nil {
+					next.ServeHTTP(w, r.WithContext(withAPIKey(r.Context(), key)))
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
 }
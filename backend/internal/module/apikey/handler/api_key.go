@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/fajrinajiseno/mygolangapp/internal/module/apikey/usecase"
+	"github.com/fajrinajiseno/mygolangapp/internal/openapigen"
+	"github.com/fajrinajiseno/mygolangapp/internal/transport"
+)
+
+type APIKeyHandler struct {
+	apiKeyUC usecase.APIKeyUsecase
+}
+
+func NewAPIKeyHandler(apiKeyUC usecase.APIKeyUsecase) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyUC: apiKeyUC,
+	}
+}
+
+func (a *APIKeyHandler) GetDashboardV1ApiKeys(w http.ResponseWriter, r *http.Request, params openapigen.GetDashboardV1ApiKeysParams) {
+	limit := 10
+	offset := 0
+
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+
+	keys, total, err := a.apiKeyUC.ListAPIKeys(r.Context(), limit, offset)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	genKeys := make([]openapigen.APIKey, len(keys))
+	for i, item := range keys {
+		genKeys[i] = toGenAPIKey(item)
+	}
+	err = json.NewEncoder(w).Encode(openapigen.APIKeyListResponse{Meta: &openapigen.PaginationMeta{
+		Limit:  params.Limit,
+		Offset: params.Offset,
+		Total:  &total,
+	}, ApiKeys: &genKeys})
+	if err != nil {
+		transport.WriteAppError(w, entity.ErrorInternal("internal server error"))
+		return
+	}
+}
+
+func (a *APIKeyHandler) PostDashboardV1ApiKeys(w http.ResponseWriter, r *http.Request) {
+	var req openapigen.PostDashboardV1ApiKeysJSONRequestBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	input := entity.APIKeyInput{Name: req.Name, ExpiresAt: req.ExpiresAt}
+	for _, scope := range req.Scopes {
+		input.Scopes = append(input.Scopes, entity.Permission(scope))
+	}
+	if req.MerchantId != nil {
+		input.MerchantID = *req.MerchantId
+	}
+	key, secret, err := a.apiKeyUC.CreateAPIKey(r.Context(), input)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	genKey := toGenAPIKey(key)
+	err = json.NewEncoder(w).Encode(openapigen.APIKeyCreatedResponse{ApiKey: &genKey, Key: &secret})
+	if err != nil {
+		transport.WriteAppError(w, entity.ErrorInternal("internal server error"))
+		return
+	}
+}
+
+func (a *APIKeyHandler) DeleteDashboardV1ApiKeyId(w http.ResponseWriter, r *http.Request, id string) {
+	if err := a.apiKeyUC.RevokeAPIKey(r.Context(), id); err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func toGenAPIKey(item *entity.APIKey) openapigen.APIKey {
+	scopes := make([]string, len(item.Scopes))
+	for i, p := range item.Scopes {
+		scopes[i] = string(p)
+	}
+	k := openapigen.APIKey{
+		Id:         &item.ID,
+		Name:       &item.Name,
+		Prefix:     &item.Prefix,
+		Scopes:     &scopes,
+		CreatedBy:  &item.CreatedBy,
+		ExpiresAt:  item.ExpiresAt,
+		LastUsedAt: item.LastUsedAt,
+		CreatedAt:  &item.CreatedAt,
+		RevokedAt:  item.RevokedAt,
+	}
+	if item.MerchantID != "" {
+		k.MerchantId = &item.MerchantID
+	}
+	return k
+}
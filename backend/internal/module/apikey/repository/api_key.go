@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/database"
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+)
+
+//go:generate mockgen -source api_key.go -destination mock/api_key_mock.go -package=mock
+type APIKeyRepository interface {
+	Create(key *entity.APIKey) (*entity.APIKey, error)
+	GetByPrefix(prefix string) (*entity.APIKey, error)
+	GetAPIKeys(limit, offset int) ([]*entity.APIKey, int, error)
+	Revoke(id string) error
+	TouchLastUsed(id string, at time.Time) error
+}
+
+type APIKey struct {
+	db *sql.DB
+}
+
+func NewAPIKeyRepo(db *sql.DB) *APIKey {
+	return &APIKey{db: db}
+}
+
+const apiKeySelect = `SELECT id, name, prefix, secret_hash, scopes, merchant_id, created_by, expires_at, last_used_at, created_at, revoked_at FROM api_keys`
+
+func (r *APIKey) Create(key *entity.APIKey) (*entity.APIKey, error) {
+	created := *key
+	created.CreatedAt = time.Now().UTC()
+	var merchantID any
+	if created.MerchantID != "" {
+		merchantID = created.MerchantID
+	}
+	res, err := r.db.Exec(`INSERT INTO api_keys(name, prefix, secret_hash, scopes, merchant_id, created_by, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		created.Name, created.Prefix, created.SecretHash, joinScopes(created.Scopes), merchantID, created.CreatedBy, created.ExpiresAt, created.CreatedAt)
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	created.ID = fmt.Sprint(id)
+	return &created, nil
+}
+
+func (r *APIKey) GetByPrefix(prefix string) (*entity.APIKey, error) {
+	key, err := scanAPIKey(r.db.QueryRow(apiKeySelect+" WHERE prefix = ?", prefix))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrorNotFound("api key not found")
+		}
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return key, nil
+}
+
+// GetAPIKeys returns a page of the API keys, newest first, revoked ones included.
+func (r *APIKey) GetAPIKeys(limit, offset int) ([]*entity.APIKey, int, error) {
+	q := apiKeySelect + " ORDER BY id DESC"
+	args := []interface{}{}
+	if limit > 0 {
+		q += " LIMIT ?"
+		args = append(args, limit)
+	}
+	if offset > 0 {
+		q += " OFFSET ?"
+		args = append(args, offset)
+	}
+
+	rows, err := r.db.Query(q, args...)
+	if err != nil {
+		return nil, 0, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	defer rows.Close()
+	res := []*entity.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, 0, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		}
+		res = append(res, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(1) FROM api_keys").Scan(&total); err != nil {
+		return nil, 0, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return res, total, nil
+}
+
+// Revoke stops a key from working, a key can only be revoked once.
+func (r *APIKey) Revoke(id string) error {
+	res, err := r.db.Exec("UPDATE api_keys SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL", time.Now().UTC(), id)
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if affected > 0 {
+		return nil
+	}
+	var exists int
+	if err := r.db.QueryRow("SELECT COUNT(1) FROM api_keys WHERE id = ?", id).Scan(&exists); err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if exists == 0 {
+		return entity.ErrorNotFound("api key not found")
+	}
+	return entity.ErrorConflict("api key already revoked")
+}
+
+func (r *APIKey) TouchLastUsed(id string, at time.Time) error {
+	if _, err := r.db.Exec("UPDATE api_keys SET last_used_at = ? WHERE id = ?", at.UTC(), id); err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return nil
+}
+
+func scanAPIKey(row database.RowScanner) (*entity.APIKey, error) {
+	var k entity.APIKey
+	var scopes string
+	var merchantID sql.NullString
+	var expiresAt, lastUsedAt, revokedAt sql.NullTime
+	if err := row.Scan(&k.ID, &k.Name, &k.Prefix, &k.SecretHash, &scopes, &merchantID, &k.CreatedBy,
+		&expiresAt, &lastUsedAt, &k.CreatedAt, &revokedAt); err != nil {
+		return nil, err
+	}
+	k.Scopes = splitScopes(scopes)
+	k.MerchantID = merchantID.String
+	if expiresAt.Valid {
+		k.ExpiresAt = &expiresAt.Time
+	}
+	if lastUsedAt.Valid {
+		k.LastUsedAt = &lastUsedAt.Time
+	}
+	if revokedAt.Valid {
+		k.RevokedAt = &revokedAt.Time
+	}
+	return &k, nil
+}
+
+// scopes are stored comma separated, permissions contain no comma
+func joinScopes(scopes []entity.Permission) string {
+	names := make([]string, len(scopes))
+	for i, p := range scopes {
+		names[i] = string(p)
+	}
+	return strings.Join(names, ",")
+}
+
+func splitScopes(s string) []entity.Permission {
+	scopes := []entity.Permission{}
+	for _, name := range strings.Split(s, ",") {
+		if name != "" {
+			scopes = append(scopes, entity.Permission(name))
+		}
+	}
+	return scopes
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+var apiKeyColumns = []string{
+	"id", "name", "prefix", "secret_hash", "scopes", "merchant_id", "created_by", "expires_at", "last_used_at", "created_at", "revoked_at",
+}
+
+func newMockRepo(t *testing.T) (*APIKey, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	repo := NewAPIKeyRepo(db)
+	cleanup := func() { db.Close() }
+	return repo, mock, cleanup
+}
+
+func TestCreate_Success(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO api_keys(name, prefix, secret_hash, scopes, merchant_id, created_by, expires_at, created_at)")).
+		WithArgs("recon", "abcdef012345", "hash", "payment:read,merchant:read", "2", "1", nil, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+
+	created, err := repo.Create(&entity.APIKey{
+		Name:       "recon",
+		Prefix:     "abcdef012345",
+		SecretHash: "hash",
+		Scopes:     []entity.Permission{entity.PermissionPaymentRead, entity.PermissionMerchantRead},
+		MerchantID: "2",
+		CreatedBy:  "1",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "7", created.ID)
+	assert.False(t, created.CreatedAt.IsZero())
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCreate_TeamKey(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO api_keys(name, prefix, secret_hash, scopes, merchant_id, created_by, expires_at, created_at)")).
+		WithArgs("recon", "abcdef012345", "hash", "payment:read", nil, "1", nil, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(8, 1))
+
+	_, err := repo.Create(&entity.APIKey{
+		Name:       "recon",
+		Prefix:     "abcdef012345",
+		SecretHash: "hash",
+		Scopes:     []entity.Permission{entity.PermissionPaymentRead},
+		CreatedBy:  "1",
+	})
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetByPrefix(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(apiKeySelect + " WHERE prefix = ?")).
+		WithArgs("abcdef012345").
+		WillReturnRows(sqlmock.NewRows(apiKeyColumns).
+			AddRow("7", "recon", "abcdef012345", "hash", "payment:read,merchant:read", nil, "1", nil, now, now, nil))
+
+	key, err := repo.GetByPrefix("abcdef012345")
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.Permission{entity.PermissionPaymentRead, entity.PermissionMerchantRead}, key.Scopes)
+	assert.Empty(t, key.MerchantID)
+	assert.Nil(t, key.ExpiresAt)
+	assert.NotNil(t, key.LastUsedAt)
+	assert.Nil(t, key.RevokedAt)
+
+	mock.ExpectQuery(regexp.QuoteMeta(apiKeySelect + " WHERE prefix = ?")).
+		WithArgs("unknown").
+		WillReturnRows(sqlmock.NewRows(apiKeyColumns))
+
+	_, err = repo.GetByPrefix("unknown")
+	var appErr *entity.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, entity.ErrorCodeNotFound, appErr.Code)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetAPIKeys(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(apiKeySelect+" ORDER BY id DESC LIMIT ? OFFSET ?")).
+		WithArgs(10, 10).
+		WillReturnRows(sqlmock.NewRows(apiKeyColumns).
+			AddRow("7", "recon", "abcdef012345", "hash", "payment:read", "2", "1", now, nil, now, now))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(1) FROM api_keys")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(11))
+
+	items, total, err := repo.GetAPIKeys(10, 10)
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.Equal(t, "2", items[0].MerchantID)
+	assert.NotNil(t, items[0].ExpiresAt)
+	assert.NotNil(t, items[0].RevokedAt)
+	assert.Equal(t, 11, total)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	revoke := regexp.QuoteMeta("UPDATE api_keys SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL")
+	exists := regexp.QuoteMeta("SELECT COUNT(1) FROM api_keys WHERE id = ?")
+
+	mock.ExpectExec(revoke).WithArgs(sqlmock.AnyArg(), "7").WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.Revoke("7"))
+
+	mock.ExpectExec(revoke).WithArgs(sqlmock.AnyArg(), "7").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(exists).WithArgs("7").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	var appErr *entity.AppError
+	assert.ErrorAs(t, repo.Revoke("7"), &appErr)
+	assert.Equal(t, entity.ErrorCodeConflict, appErr.Code)
+
+	mock.ExpectExec(revoke).WithArgs(sqlmock.AnyArg(), "99").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(exists).WithArgs("99").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	assert.ErrorAs(t, repo.Revoke("99"), &appErr)
+	assert.Equal(t, entity.ErrorCodeNotFound, appErr.Code)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
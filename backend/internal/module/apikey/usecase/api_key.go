@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/authz"
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	apiKeyRepository "github.com/fajrinajiseno/mygolangapp/internal/module/apikey/repository"
+	merchantRepository "github.com/fajrinajiseno/mygolangapp/internal/module/merchant/repository"
+)
+
+//go:generate mockgen -source api_key.go -destination mock/api_key_mock.go -package=mock
+type APIKeyUsecase interface {
+	CreateAPIKey(ctx context.Context, input entity.APIKeyInput) (*entity.APIKey, string, error)
+	ListAPIKeys(ctx context.Context, limit int, offset int) ([]*entity.APIKey, int, error)
+	RevokeAPIKey(ctx context.Context, id string) error
+	AuthenticateAPIKey(key string) (*entity.APIKey, error)
+}
+
+const (
+	keyPrefix     = "dpk"
+	prefixBytes   = 6
+	secretBytes   = 32
+	maxNameLength = 100
+	// lastUsedResolution bounds how often using a key writes its last used time
+	lastUsedResolution = time.Minute
+)
+
+// unscopedPermissions cannot be granted to a key, managing users and keys needs a person.
+var unscopedPermissions = map[entity.Permission]bool{
+	entity.PermissionUserManage:   true,
+	entity.PermissionAPIKeyManage: true,
+}
+
+type APIKey struct {
+	authorizer   authz.Authorizer
+	apiKeyRepo   apiKeyRepository.APIKeyRepository
+	merchantRepo merchantRepository.MerchantRepository
+}
+
+func NewAPIKeyUsecase(kr apiKeyRepository.APIKeyRepository, mr merchantRepository.MerchantRepository, az authz.Authorizer) *APIKey {
+	return &APIKey{apiKeyRepo: kr, merchantRepo: mr, authorizer: az}
+}
+
+// CreateAPIKey creates a key and returns it with the full key, which is not stored and
+// cannot be shown again. Only permissions the creator holds can be granted.
+func (u *APIKey) CreateAPIKey(ctx context.Context, input entity.APIKeyInput) (*entity.APIKey, string, error) {
+	user, err := u.authorizer.Authorize(ctx, entity.PermissionAPIKeyManage)
+	if err != nil {
+		return nil, "", err
+	}
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, "", entity.ErrorValidation("name is required")
+	}
+	if len(name) > maxNameLength {
+		return nil, "", entity.ErrorValidation("name is too long")
+	}
+	scopes, err := checkScopes(user, input.Scopes)
+	if err != nil {
+		return nil, "", err
+	}
+	if input.MerchantID != "" {
+		if _, err := u.merchantRepo.GetMerchantByID(input.MerchantID); err != nil {
+			var appErr *entity.AppError
+			if errors.As(err, &appErr) && appErr.Code == entity.ErrorCodeNotFound {
+				return nil, "", entity.ErrorValidation("unknown merchant " + input.MerchantID)
+			}
+			return nil, "", err
+		}
+	}
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return nil, "", entity.ErrorValidation("expires_at must be in the future")
+	}
+
+	prefix, err := randomBytes(prefixBytes)
+	if err != nil {
+		return nil, "", err
+	}
+	secret, err := randomBytes(secretBytes)
+	if err != nil {
+		return nil, "", err
+	}
+	prefixStr := hex.EncodeToString(prefix)
+	secretStr := base64.RawURLEncoding.EncodeToString(secret)
+	var expiresAt *time.Time
+	if input.ExpiresAt != nil {
+		utc := input.ExpiresAt.UTC()
+		expiresAt = &utc
+	}
+	key, err := u.apiKeyRepo.Create(&entity.APIKey{
+		Name:       name,
+		Prefix:     prefixStr,
+		SecretHash: hashSecret(secretStr),
+		Scopes:     scopes,
+		MerchantID: input.MerchantID,
+		CreatedBy:  user.ID,
+		ExpiresAt:  expiresAt,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return key, keyPrefix + "_" + prefixStr + "_" + secretStr, nil
+}
+
+func (u *APIKey) ListAPIKeys(ctx context.Context, limit int, offset int) ([]*entity.APIKey, int, error) {
+	if _, err := u.authorizer.Authorize(ctx, entity.PermissionAPIKeyManage); err != nil {
+		return nil, 0, err
+	}
+	return u.apiKeyRepo.GetAPIKeys(limit, offset)
+}
+
+func (u *APIKey) RevokeAPIKey(ctx context.Context, id string) error {
+	if _, err := u.authorizer.Authorize(ctx, entity.PermissionAPIKeyManage); err != nil {
+		return err
+	}
+	return u.apiKeyRepo.Revoke(id)
+}
+
+// AuthenticateAPIKey returns the key a request presented, when it is known, not revoked
+// and not expired.
+func (u *APIKey) AuthenticateAPIKey(key string) (*entity.APIKey, error) {
+	parts := strings.SplitN(key, "_", 3)
+	if len(parts) != 3 || parts[0] != keyPrefix || parts[1] == "" || parts[2] == "" {
+		return nil, entity.ErrorUnauthorized("invalid api key")
+	}
+	stored, err := u.apiKeyRepo.GetByPrefix(parts[1])
+	if err != nil {
+		var appErr *entity.AppError
+		if errors.As(err, &appErr) && appErr.Code == entity.ErrorCodeNotFound {
+			return nil, entity.ErrorUnauthorized("invalid api key")
+		}
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(hashSecret(parts[2])), []byte(stored.SecretHash)) != 1 {
+		return nil, entity.ErrorUnauthorized("invalid api key")
+	}
+	now := time.Now()
+	if stored.RevokedAt != nil {
+		return nil, entity.ErrorUnauthorized("api key revoked")
+	}
+	if stored.ExpiresAt != nil && !now.Before(*stored.ExpiresAt) {
+		return nil, entity.ErrorUnauthorized("api key expired")
+	}
+	if stored.LastUsedAt == nil || now.Sub(*stored.LastUsedAt) >= lastUsedResolution {
+		// the request goes on without the last used time rather than failing
+		if err := u.apiKeyRepo.TouchLastUsed(stored.ID, now); err != nil {
+			log.Printf("api key %s last used: %v", stored.ID, err)
+		} else {
+			stored.LastUsedAt = &now
+		}
+	}
+	return stored, nil
+}
+
+// checkScopes validates the scopes of a new key and drops duplicates.
+func checkScopes(user *entity.User, scopes []entity.Permission) ([]entity.Permission, error) {
+	if len(scopes) == 0 {
+		return nil, entity.ErrorValidation("at least one scope is required")
+	}
+	res := make([]entity.Permission, 0, len(scopes))
+	seen := map[entity.Permission]bool{}
+	for _, p := range scopes {
+		if !p.Valid() {
+			return nil, entity.ErrorValidation("unknown scope " + string(p))
+		}
+		if unscopedPermissions[p] {
+			return nil, entity.ErrorValidation("scope " + string(p) + " cannot be granted to an api key")
+		}
+		if !user.HasPermission(p) {
+			return nil, entity.ErrorForbidden("cannot grant scope " + string(p) + " you do not have")
+		}
+		if !seen[p] {
+			seen[p] = true
+			res = append(res, p)
+		}
+	}
+	return res, nil
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "internal error")
+	}
+	return b, nil
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	azm "github.com/fajrinajiseno/mygolangapp/internal/authz/mock"
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	akm "github.com/fajrinajiseno/mygolangapp/internal/module/apikey/repository/mock"
+	mm "github.com/fajrinajiseno/mygolangapp/internal/module/merchant/repository/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var admin = &entity.User{
+	ID:          "1",
+	Email:       "admin@test.com",
+	Role:        "admin",
+	Status:      entity.UserStatusActive,
+	Permissions: []entity.Permission{entity.PermissionPaymentRead, entity.PermissionUserManage, entity.PermissionAPIKeyManage},
+}
+
+func TestAPIKey_CreateAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockKeyRepo := akm.NewMockAPIKeyRepository(ctrl)
+	mockMerchantRepo := mm.NewMockMerchantRepository(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+
+	t.Run("success", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionAPIKeyManage).Return(admin, nil)
+		mockMerchantRepo.EXPECT().GetMerchantByID("2").Return(&entity.Merchant{ID: "2"}, nil)
+		var stored *entity.APIKey
+		mockKeyRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(key *entity.APIKey) (*entity.APIKey, error) {
+			stored = key
+			return key, nil
+		})
+
+		u := NewAPIKeyUsecase(mockKeyRepo, mockMerchantRepo, mockAuthorizer)
+
+		expiresAt := time.Now().Add(time.Hour)
+		key, raw, err := u.CreateAPIKey(ctx, entity.APIKeyInput{
+			Name:       " recon ",
+			Scopes:     []entity.Permission{entity.PermissionPaymentRead, entity.PermissionPaymentRead},
+			MerchantID: "2",
+			ExpiresAt:  &expiresAt,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "recon", key.Name)
+		assert.Equal(t, []entity.Permission{entity.PermissionPaymentRead}, stored.Scopes)
+		assert.Equal(t, "1", stored.CreatedBy)
+		assert.True(t, strings.HasPrefix(raw, "dpk_"+stored.Prefix+"_"))
+		assert.Equal(t, hashSecret(strings.TrimPrefix(raw, "dpk_"+stored.Prefix+"_")), stored.SecretHash)
+	})
+
+	t.Run("scope not held by the creator", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionAPIKeyManage).Return(admin, nil)
+
+		u := NewAPIKeyUsecase(mockKeyRepo, mockMerchantRepo, mockAuthorizer)
+
+		_, _, err := u.CreateAPIKey(ctx, entity.APIKeyInput{Name: "recon", Scopes: []entity.Permission{entity.PermissionPaymentRefund}})
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeForbidden, appErr.Code)
+	})
+
+	t.Run("unscoped permission", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionAPIKeyManage).Return(admin, nil)
+
+		u := NewAPIKeyUsecase(mockKeyRepo, mockMerchantRepo, mockAuthorizer)
+
+		_, _, err := u.CreateAPIKey(ctx, entity.APIKeyInput{Name: "recon", Scopes: []entity.Permission{entity.PermissionUserManage}})
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeValidation, appErr.Code)
+	})
+
+	t.Run("unknown merchant", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionAPIKeyManage).Return(admin, nil)
+		mockMerchantRepo.EXPECT().GetMerchantByID("99").Return(nil, entity.ErrorNotFound("merchant not found"))
+
+		u := NewAPIKeyUsecase(mockKeyRepo, mockMerchantRepo, mockAuthorizer)
+
+		_, _, err := u.CreateAPIKey(ctx, entity.APIKeyInput{Name: "recon", Scopes: []entity.Permission{entity.PermissionPaymentRead}, MerchantID: "99"})
+		assert.EqualError(t, err, "unknown merchant 99")
+	})
+
+	t.Run("expiry in the past", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionAPIKeyManage).Return(admin, nil)
+
+		u := NewAPIKeyUsecase(mockKeyRepo, mockMerchantRepo, mockAuthorizer)
+
+		expiresAt := time.Now().Add(-time.Minute)
+		_, _, err := u.CreateAPIKey(ctx, entity.APIKeyInput{Name: "recon", Scopes: []entity.Permission{entity.PermissionPaymentRead}, ExpiresAt: &expiresAt})
+		assert.Error(t, err)
+	})
+
+	t.Run("missing permission", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionAPIKeyManage).Return(nil, entity.ErrorForbidden("missing permission api_key:manage"))
+
+		u := NewAPIKeyUsecase(mockKeyRepo, mockMerchantRepo, mockAuthorizer)
+
+		_, _, err := u.CreateAPIKey(ctx, entity.APIKeyInput{Name: "recon", Scopes: []entity.Permission{entity.PermissionPaymentRead}})
+		assert.EqualError(t, err, "missing permission api_key:manage")
+	})
+}
+
+func TestAPIKey_RevokeAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockKeyRepo := akm.NewMockAPIKeyRepository(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+
+	mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionAPIKeyManage).Return(admin, nil)
+	mockKeyRepo.EXPECT().Revoke("7").Return(nil)
+
+	u := NewAPIKeyUsecase(mockKeyRepo, nil, mockAuthorizer)
+	assert.NoError(t, u.RevokeAPIKey(ctx, "7"))
+}
+
+func TestAPIKey_AuthenticateAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockKeyRepo := akm.NewMockAPIKeyRepository(ctrl)
+	stored := entity.APIKey{ID: "7", Prefix: "abcdef012345", SecretHash: hashSecret("secret"), CreatedBy: "1"}
+
+	t.Run("success records last use", func(t *testing.T) {
+		key := stored
+		mockKeyRepo.EXPECT().GetByPrefix("abcdef012345").Return(&key, nil)
+		mockKeyRepo.EXPECT().TouchLastUsed("7", gomock.Any()).Return(nil)
+
+		u := NewAPIKeyUsecase(mockKeyRepo, nil, nil)
+
+		got, err := u.AuthenticateAPIKey("dpk_abcdef012345_secret")
+		assert.NoError(t, err)
+		assert.Equal(t, "7", got.ID)
+		assert.NotNil(t, got.LastUsedAt)
+	})
+
+	t.Run("recently used", func(t *testing.T) {
+		key := stored
+		lastUsed := time.Now().Add(-time.Second)
+		key.LastUsedAt = &lastUsed
+		mockKeyRepo.EXPECT().GetByPrefix("abcdef012345").Return(&key, nil)
+
+		u := NewAPIKeyUsecase(mockKeyRepo, nil, nil)
+
+		_, err := u.AuthenticateAPIKey("dpk_abcdef012345_secret")
+		assert.NoError(t, err)
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		key := stored
+		mockKeyRepo.EXPECT().GetByPrefix("abcdef012345").Return(&key, nil)
+
+		u := NewAPIKeyUsecase(mockKeyRepo, nil, nil)
+
+		_, err := u.AuthenticateAPIKey("dpk_abcdef012345_other")
+		assert.EqualError(t, err, "invalid api key")
+	})
+
+	t.Run("unknown prefix", func(t *testing.T) {
+		mockKeyRepo.EXPECT().GetByPrefix("000000000000").Return(nil, entity.ErrorNotFound("api key not found"))
+
+		u := NewAPIKeyUsecase(mockKeyRepo, nil, nil)
+
+		_, err := u.AuthenticateAPIKey("dpk_000000000000_secret")
+		assert.EqualError(t, err, "invalid api key")
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		u := NewAPIKeyUsecase(mockKeyRepo, nil, nil)
+
+		_, err := u.AuthenticateAPIKey("not-a-key")
+		assert.EqualError(t, err, "invalid api key")
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		key := stored
+		revokedAt := time.Now().Add(-time.Hour)
+		key.RevokedAt = &revokedAt
+		mockKeyRepo.EXPECT().GetByPrefix("abcdef012345").Return(&key, nil)
+
+		u := NewAPIKeyUsecase(mockKeyRepo, nil, nil)
+
+		_, err := u.AuthenticateAPIKey("dpk_abcdef012345_secret")
+		assert.EqualError(t, err, "api key revoked")
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		key := stored
+		expiresAt := time.Now().Add(-time.Second)
+		key.ExpiresAt = &expiresAt
+		mockKeyRepo.EXPECT().GetByPrefix("abcdef012345").Return(&key, nil)
+
+		u := NewAPIKeyUsecase(mockKeyRepo, nil, nil)
+
+		_, err := u.AuthenticateAPIKey("dpk_abcdef012345_secret")
+		assert.EqualError(t, err, "api key expired")
+	})
+}
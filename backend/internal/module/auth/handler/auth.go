@@ -2,9 +2,9 @@ package handler
 
 import (
 	"encoding/json"
-	"io"
 	"net/http"
 
+	"github.com/fajrinajiseno/mygolangapp/internal/config"
 	"github.com/fajrinajiseno/mygolangapp/internal/entity"
 	authUsecase "github.com/fajrinajiseno/mygolangapp/internal/module/auth/usecase"
 	paymentUsecase "github.com/fajrinajiseno/mygolangapp/internal/module/payment/usecase"
@@ -13,50 +13,183 @@ import (
 )
 
 type AuthHandler struct {
-	paymentUC paymentUsecase.PaymentUsecase
-	authUC    authUsecase.AuthUsecase
+	paymentUC   paymentUsecase.PaymentUsecase
+	authUC      authUsecase.AuthUsecase
+	resetUC     authUsecase.PasswordResetUsecase
+	twoFactorUC authUsecase.TwoFactorUsecase
 }
 
-func NewAuthHandler(paymentUC paymentUsecase.PaymentUsecase, authUC authUsecase.AuthUsecase) *AuthHandler {
+func NewAuthHandler(paymentUC paymentUsecase.PaymentUsecase, authUC authUsecase.AuthUsecase, resetUC authUsecase.PasswordResetUsecase,
+	twoFactorUC authUsecase.TwoFactorUsecase) *AuthHandler {
 	return &AuthHandler{
-		paymentUC: paymentUC,
-		authUC:    authUC,
+		paymentUC:   paymentUC,
+		authUC:      authUC,
+		resetUC:     resetUC,
+		twoFactorUC: twoFactorUC,
 	}
 }
 
 func (a *AuthHandler) PostDashboardV1AuthLogin(w http.ResponseWriter, r *http.Request) {
 	var req openapigen.PostDashboardV1AuthLoginJSONBody
-	if !decodeJSONBody(w, r, &req) {
+	if !transport.DecodeJSONBody(w, r, &req) {
 		return
 	}
-	token, user, err := a.authUC.Login(req.Email, req.Password)
+	result, err := a.authUC.Login(req.Email, req.Password, transport.ClientIP(r, config.TrustProxy))
 	if err != nil {
 		transport.WriteError(w, err)
 		return
 	}
+	if result.Tokens == nil {
+		required := true
+		writeJSON(w, openapigen.LoginResponse{
+			Email:              &result.User.Email,
+			TwoFactorRequired:  &required,
+			ChallengeToken:     &result.ChallengeToken,
+			ChallengeExpiresAt: &result.ChallengeExpiresAt,
+		})
+		return
+	}
+	writeLoginResponse(w, result.Tokens, result.User)
+}
 
-	err = json.NewEncoder(w).Encode(openapigen.LoginResponse{Email: &user.Email, Role: &user.Role, Token: &token})
+func (a *AuthHandler) PostDashboardV1AuthLoginVerify(w http.ResponseWriter, r *http.Request) {
+	var req openapigen.PostDashboardV1AuthLoginVerifyJSONBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+	tokens, user, err := a.authUC.VerifyLogin(req.ChallengeToken, req.Code, transport.ClientIP(r, config.TrustProxy))
 	if err != nil {
-		transport.WriteAppError(w, entity.ErrorInternal("internal server error"))
+		transport.WriteError(w, err)
+		return
+	}
+	writeLoginResponse(w, tokens, user)
+}
+
+func (a *AuthHandler) PostDashboardV1AuthRefresh(w http.ResponseWriter, r *http.Request) {
+	var req openapigen.PostDashboardV1AuthRefreshJSONBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+	tokens, user, err := a.authUC.Refresh(req.RefreshToken)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	writeLoginResponse(w, tokens, user)
+}
+
+func (a *AuthHandler) PostDashboardV1AuthLogout(w http.ResponseWriter, r *http.Request) {
+	var req openapigen.PostDashboardV1AuthLogoutJSONBody
+	if !transport.DecodeOptionalJSONBody(w, r, &req) {
+		return
+	}
+	refreshToken := ""
+	if req.RefreshToken != nil {
+		refreshToken = *req.RefreshToken
+	}
+	if err := a.authUC.Logout(r.Context(), refreshToken); err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *AuthHandler) PostDashboardV1AuthPasswordReset(w http.ResponseWriter, r *http.Request) {
+	var req openapigen.PostDashboardV1AuthPasswordResetJSONBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+	if err := a.resetUC.RequestPasswordReset(r.Context(), req.Email); err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *AuthHandler) PostDashboardV1AuthPasswordResetConfirm(w http.ResponseWriter, r *http.Request) {
+	var req openapigen.PostDashboardV1AuthPasswordResetConfirmJSONBody
+	if !transport.DecodeJSONBody(w, r, &req) {
 		return
 	}
+	if err := a.resetUC.ResetPassword(r.Context(), req.Token, req.Password); err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
 }
 
-func decodeJSONBody(w http.ResponseWriter, r *http.Request, dst any) bool {
-	if r.Body == nil {
-		transport.WriteAppError(w, entity.ErrorBadRequest("empty body"))
-		return false
+func (a *AuthHandler) PostDashboardV1AuthTotp(w http.ResponseWriter, r *http.Request) {
+	enrollment, err := a.twoFactorUC.SetupTOTP(r.Context())
+	if err != nil {
+		transport.WriteError(w, err)
+		return
 	}
+	writeJSON(w, openapigen.TOTPEnrollment{
+		Secret:          enrollment.Secret,
+		ProvisioningUri: enrollment.ProvisioningURI,
+	})
+}
 
-	body, err := io.ReadAll(r.Body)
+func (a *AuthHandler) PostDashboardV1AuthTotpEnable(w http.ResponseWriter, r *http.Request) {
+	var req openapigen.PostDashboardV1AuthTotpEnableJSONBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+	codes, err := a.twoFactorUC.EnableTOTP(r.Context(), req.Code)
 	if err != nil {
-		transport.WriteAppError(w, entity.ErrorBadRequest("failed to read body"))
-		return false
+		transport.WriteError(w, err)
+		return
+	}
+	writeJSON(w, openapigen.RecoveryCodes{RecoveryCodes: codes})
+}
+
+func (a *AuthHandler) PostDashboardV1AuthTotpDisable(w http.ResponseWriter, r *http.Request) {
+	var req openapigen.PostDashboardV1AuthTotpDisableJSONBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+	if err := a.twoFactorUC.DisableTOTP(r.Context(), req.Code); err != nil {
+		transport.WriteError(w, err)
+		return
 	}
+	w.WriteHeader(http.StatusNoContent)
+}
 
-	if err := json.Unmarshal(body, dst); err != nil {
-		transport.WriteAppError(w, entity.ErrorBadRequest("invalid json: "+err.Error()))
-		return false
+func (a *AuthHandler) DeleteDashboardV1UserIdTotp(w http.ResponseWriter, r *http.Request, id string) {
+	if err := a.twoFactorUC.ResetTOTP(r.Context(), id); err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *AuthHandler) DeleteDashboardV1UserIdSessions(w http.ResponseWriter, r *http.Request, id string) {
+	if err := a.authUC.RevokeSessions(r.Context(), id); err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeLoginResponse(w http.ResponseWriter, tokens *entity.AuthTokens, user *entity.User) {
+	permissions := make([]string, 0, len(user.Permissions))
+	for _, p := range user.Permissions {
+		permissions = append(permissions, string(p))
+	}
+	writeJSON(w, openapigen.LoginResponse{
+		Email:            &user.Email,
+		Role:             &user.Role,
+		Permissions:      &permissions,
+		Token:            &tokens.AccessToken,
+		ExpiresAt:        &tokens.AccessExpiresAt,
+		RefreshToken:     &tokens.RefreshToken,
+		RefreshExpiresAt: &tokens.RefreshExpiresAt,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		transport.WriteAppError(w, entity.ErrorInternal("internal server error"))
+		return
 	}
-	return true
 }
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/database"
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+)
+
+//go:generate mockgen -source lockout.go -destination mock/lockout_mock.go -package=mock
+type LockoutRepository interface {
+	GetLockout(scope entity.LockoutScope, key string) (*entity.LoginLockout, error)
+	RecordFailure(scope entity.LockoutScope, key string, now time.Time, window time.Duration) (int, error)
+	LockUntil(scope entity.LockoutScope, key string, until time.Time) error
+	ClearLockout(scope entity.LockoutScope, key string) error
+	ListLockouts(now time.Time) ([]*entity.LoginLockout, error)
+}
+
+type Lockout struct {
+	db *sql.DB
+}
+
+func NewLockoutRepo(db *sql.DB) *Lockout {
+	return &Lockout{db: db}
+}
+
+const lockoutSelect = `SELECT scope, key, failures, last_failure_at, locked_until FROM login_failures`
+
+func (r *Lockout) GetLockout(scope entity.LockoutScope, key string) (*entity.LoginLockout, error) {
+	l, err := scanLockout(r.db.QueryRow(lockoutSelect+" WHERE scope = ? AND key = ?", scope, key))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrorNotFound("lockout not found")
+		}
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return l, nil
+}
+
+// RecordFailure counts a failed login and returns the failures counted so far. The count
+// starts over when the previous failure is older than window.
+func (r *Lockout) RecordFailure(scope entity.LockoutScope, key string, now time.Time, window time.Duration) (int, error) {
+	var failures int
+	err := r.db.QueryRow(`INSERT INTO login_failures(scope, key, failures, last_failure_at) VALUES (?, ?, 1, ?)
+		ON CONFLICT(scope, key) DO UPDATE SET
+		  failures = CASE WHEN julianday(login_failures.last_failure_at) < julianday(?) THEN 1 ELSE login_failures.failures + 1 END,
+		  last_failure_at = excluded.last_failure_at
+		RETURNING failures`,
+		scope, key, now.UTC(), now.Add(-window).UTC().Format(time.RFC3339Nano)).Scan(&failures)
+	if err != nil {
+		return 0, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return failures, nil
+}
+
+func (r *Lockout) LockUntil(scope entity.LockoutScope, key string, until time.Time) error {
+	_, err := r.db.Exec("UPDATE login_failures SET locked_until = ? WHERE scope = ? AND key = ?", until.UTC(), scope, key)
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return nil
+}
+
+// ClearLockout forgets the failures of a key, it returns a not found error when there are
+// none.
+func (r *Lockout) ClearLockout(scope entity.LockoutScope, key string) error {
+	res, err := r.db.Exec("DELETE FROM login_failures WHERE scope = ? AND key = ?", scope, key)
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if affected == 0 {
+		return entity.ErrorNotFound("lockout not found")
+	}
+	return nil
+}
+
+// ListLockouts returns the keys locked at now, the longest locked first.
+func (r *Lockout) ListLockouts(now time.Time) ([]*entity.LoginLockout, error) {
+	rows, err := r.db.Query(lockoutSelect+" WHERE julianday(locked_until) > julianday(?) ORDER BY julianday(locked_until) DESC",
+		now.UTC().Format(time.RFC3339Nano))
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	defer rows.Close()
+
+	lockouts := []*entity.LoginLockout{}
+	for rows.Next() {
+		l, err := scanLockout(rows)
+		if err != nil {
+			return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		}
+		lockouts = append(lockouts, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return lockouts, nil
+}
+
+func scanLockout(row database.RowScanner) (*entity.LoginLockout, error) {
+	var l entity.LoginLockout
+	var lockedUntil sql.NullTime
+	if err := row.Scan(&l.Scope, &l.Key, &l.Failures, &l.LastFailureAt, &lockedUntil); err != nil {
+		return nil, err
+	}
+	if lockedUntil.Valid {
+		l.LockedUntil = &lockedUntil.Time
+	}
+	return &l, nil
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMockLockoutRepo(t *testing.T) (*Lockout, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	repo := NewLockoutRepo(db)
+	cleanup := func() { db.Close() }
+	return repo, mock, cleanup
+}
+
+func TestLockoutGetLockout(t *testing.T) {
+	repo, mock, cleanup := newMockLockoutRepo(t)
+	defer cleanup()
+
+	now := time.Now().UTC()
+	mock.ExpectQuery(regexp.QuoteMeta(lockoutSelect+" WHERE scope = ? AND key = ?")).
+		WithArgs(entity.LockoutScopeAccount, "cs@test.com").
+		WillReturnRows(sqlmock.NewRows([]string{"scope", "key", "failures", "last_failure_at", "locked_until"}).
+			AddRow("account", "cs@test.com", 6, now, now.Add(time.Minute)))
+	lockout, err := repo.GetLockout(entity.LockoutScopeAccount, "cs@test.com")
+	assert.NoError(t, err)
+	assert.Equal(t, 6, lockout.Failures)
+	assert.NotNil(t, lockout.LockedUntil)
+
+	mock.ExpectQuery(regexp.QuoteMeta(lockoutSelect+" WHERE scope = ? AND key = ?")).
+		WithArgs(entity.LockoutScopeIP, "10.0.0.1").
+		WillReturnRows(sqlmock.NewRows([]string{"scope"}))
+	_, err = repo.GetLockout(entity.LockoutScopeIP, "10.0.0.1")
+	assert.EqualError(t, err, "lockout not found")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLockoutRecordFailure(t *testing.T) {
+	repo, mock, cleanup := newMockLockoutRepo(t)
+	defer cleanup()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO login_failures(scope, key, failures, last_failure_at) VALUES (?, ?, 1, ?)")).
+		WithArgs(entity.LockoutScopeAccount, "cs@test.com", now.UTC(), now.Add(-time.Hour).UTC().Format(time.RFC3339Nano)).
+		WillReturnRows(sqlmock.NewRows([]string{"failures"}).AddRow(3))
+
+	failures, err := repo.RecordFailure(entity.LockoutScopeAccount, "cs@test.com", now, time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, failures)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLockoutClearLockout_NotFound(t *testing.T) {
+	repo, mock, cleanup := newMockLockoutRepo(t)
+	defer cleanup()
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM login_failures WHERE scope = ? AND key = ?")).
+		WithArgs(entity.LockoutScopeIP, "10.0.0.1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.ClearLockout(entity.LockoutScopeIP, "10.0.0.1")
+	assert.EqualError(t, err, "lockout not found")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLockoutListLockouts(t *testing.T) {
+	repo, mock, cleanup := newMockLockoutRepo(t)
+	defer cleanup()
+
+	now := time.Now().UTC()
+	mock.ExpectQuery(regexp.QuoteMeta(lockoutSelect + " WHERE julianday(locked_until) > julianday(?) ORDER BY julianday(locked_until) DESC")).
+		WithArgs(now.Format(time.RFC3339Nano)).
+		WillReturnRows(sqlmock.NewRows([]string{"scope", "key", "failures", "last_failure_at", "locked_until"}).
+			AddRow("ip", "10.0.0.1", 25, now, now.Add(time.Minute)).
+			AddRow("account", "cs@test.com", 5, now, now.Add(30*time.Second)))
+
+	lockouts, err := repo.ListLockouts(now)
+	assert.NoError(t, err)
+	assert.Len(t, lockouts, 2)
+	assert.Equal(t, entity.LockoutScopeIP, lockouts[0].Scope)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
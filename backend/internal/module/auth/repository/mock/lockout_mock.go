@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: lockout.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/fajrinajiseno/mygolangapp/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLockoutRepository is a mock of LockoutRepository interface.
+type MockLockoutRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLockoutRepositoryMockRecorder
+}
+
+// MockLockoutRepositoryMockRecorder is the mock recorder for MockLockoutRepository.
+type MockLockoutRepositoryMockRecorder struct {
+	mock *MockLockoutRepository
+}
+
+// NewMockLockoutRepository creates a new mock instance.
+func NewMockLockoutRepository(ctrl *gomock.Controller) *MockLockoutRepository {
+	mock := &MockLockoutRepository{ctrl: ctrl}
+	mock.recorder = &MockLockoutRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLockoutRepository) EXPECT() *MockLockoutRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClearLockout mocks base method.
+func (m *MockLockoutRepository) ClearLockout(scope entity.LockoutScope, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearLockout", scope, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearLockout indicates an expected call of ClearLockout.
+func (mr *MockLockoutRepositoryMockRecorder) ClearLockout(scope, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearLockout", reflect.TypeOf((*MockLockoutRepository)(nil).ClearLockout), scope, key)
+}
+
+// GetLockout mocks base method.
+func (m *MockLockoutRepository) GetLockout(scope entity.LockoutScope, key string) (*entity.LoginLockout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLockout", scope, key)
+	ret0, _ := ret[0].(*entity.LoginLockout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLockout indicates an expected call of GetLockout.
+func (mr *MockLockoutRepositoryMockRecorder) GetLockout(scope, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLockout", reflect.TypeOf((*MockLockoutRepository)(nil).GetLockout), scope, key)
+}
+
+// ListLockouts mocks base method.
+func (m *MockLockoutRepository) ListLockouts(now time.Time) ([]*entity.LoginLockout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLockouts", now)
+	ret0, _ := ret[0].([]*entity.LoginLockout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLockouts indicates an expected call of ListLockouts.
+func (mr *MockLockoutRepositoryMockRecorder) ListLockouts(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLockouts", reflect.TypeOf((*MockLockoutRepository)(nil).ListLockouts), now)
+}
+
+// LockUntil mocks base method.
+func (m *MockLockoutRepository) LockUntil(scope entity.LockoutScope, key string, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockUntil", scope, key, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockUntil indicates an expected call of LockUntil.
+func (mr *MockLockoutRepositoryMockRecorder) LockUntil(scope, key, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUntil", reflect.TypeOf((*MockLockoutRepository)(nil).LockUntil), scope, key, until)
+}
+
+// RecordFailure mocks base method.
+func (m *MockLockoutRepository) RecordFailure(scope entity.LockoutScope, key string, now time.Time, window time.Duration) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailure", scope, key, now, window)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordFailure indicates an expected call of RecordFailure.
+func (mr *MockLockoutRepositoryMockRecorder) RecordFailure(scope, key, now, window interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailure", reflect.TypeOf((*MockLockoutRepository)(nil).RecordFailure), scope, key, now, window)
+}
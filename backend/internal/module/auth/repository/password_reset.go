@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+)
+
+//go:generate mockgen -source password_reset.go -destination mock/password_reset_mock.go -package=mock
+type PasswordResetRepository interface {
+	Create(token *entity.PasswordResetToken) (*entity.PasswordResetToken, error)
+	GetByHash(hash string) (*entity.PasswordResetToken, error)
+	Consume(id, userID, passwordHash string) error
+}
+
+type PasswordReset struct {
+	db *sql.DB
+}
+
+func NewPasswordResetRepo(db *sql.DB) *PasswordReset {
+	return &PasswordReset{db: db}
+}
+
+// Create stores a new reset token and invalidates the unused tokens of the same user, so
+// only the link of the latest request works.
+func (r *PasswordReset) Create(token *entity.PasswordResetToken) (*entity.PasswordResetToken, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	defer tx.Rollback()
+
+	created := *token
+	created.CreatedAt = time.Now().UTC()
+	if _, err := tx.Exec("UPDATE password_reset_tokens SET used_at = ? WHERE user_id = ? AND used_at IS NULL",
+		created.CreatedAt, created.UserID); err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	res, err := tx.Exec("INSERT INTO password_reset_tokens(user_id, token_hash, expires_at, created_at) VALUES (?, ?, ?, ?)",
+		created.UserID, created.TokenHash, created.ExpiresAt.UTC(), created.CreatedAt)
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	tokenID, err := res.LastInsertId()
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	created.ID = fmt.Sprint(tokenID)
+	return &created, nil
+}
+
+func (r *PasswordReset) GetByHash(hash string) (*entity.PasswordResetToken, error) {
+	row := r.db.QueryRow(`SELECT id, user_id, token_hash, expires_at, created_at, used_at
+		FROM password_reset_tokens WHERE token_hash = ?`, hash)
+	var t entity.PasswordResetToken
+	var usedAt sql.NullTime
+	if err := row.Scan(&t.ID, &t.UserID, &t.TokenHash, &t.ExpiresAt, &t.CreatedAt, &usedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrorNotFound("password reset token not found")
+		}
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if usedAt.Valid {
+		t.UsedAt = &usedAt.Time
+	}
+	return &t, nil
+}
+
+// Consume marks a reset token as used and sets the new password of its user in one
+// transaction. Of two requests presenting the same token only one changes the password.
+func (r *PasswordReset) Consume(id, userID, passwordHash string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec("UPDATE password_reset_tokens SET used_at = ? WHERE id = ? AND used_at IS NULL",
+		time.Now().UTC(), id)
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if affected == 0 {
+		return entity.ErrorConflict("password reset token already used")
+	}
+	if _, err := tx.Exec("UPDATE users SET password_hash = ? WHERE id = ?", passwordHash, userID); err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if err := tx.Commit(); err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMockPasswordResetRepo(t *testing.T) (*PasswordReset, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	repo := NewPasswordResetRepo(db)
+	cleanup := func() { db.Close() }
+	return repo, mock, cleanup
+}
+
+func TestPasswordResetCreate(t *testing.T) {
+	repo, mock, cleanup := newMockPasswordResetRepo(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE password_reset_tokens SET used_at = ? WHERE user_id = ? AND used_at IS NULL")).
+		WithArgs(sqlmock.AnyArg(), "u1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO password_reset_tokens(user_id, token_hash, expires_at, created_at) VALUES (?, ?, ?, ?)")).
+		WithArgs("u1", "hash", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectCommit()
+
+	token, err := repo.Create(&entity.PasswordResetToken{UserID: "u1", TokenHash: "hash", ExpiresAt: time.Now().Add(time.Hour)})
+	assert.NoError(t, err)
+	assert.Equal(t, "7", token.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPasswordResetGetByHash(t *testing.T) {
+	repo, mock, cleanup := newMockPasswordResetRepo(t)
+	defer cleanup()
+
+	now := time.Now().UTC()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, token_hash, expires_at, created_at, used_at
+		FROM password_reset_tokens WHERE token_hash = ?`)).
+		WithArgs("hash").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "token_hash", "expires_at", "created_at", "used_at"}).
+			AddRow("1", "u1", "hash", now.Add(time.Hour), now, nil))
+
+	token, err := repo.GetByHash("hash")
+	assert.NoError(t, err)
+	assert.Equal(t, "u1", token.UserID)
+	assert.Nil(t, token.UsedAt)
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM password_reset_tokens WHERE token_hash = ?")).
+		WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	_, err = repo.GetByHash("missing")
+	assert.EqualError(t, err, "password reset token not found")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPasswordResetConsume(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo, mock, cleanup := newMockPasswordResetRepo(t)
+		defer cleanup()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE password_reset_tokens SET used_at = ? WHERE id = ? AND used_at IS NULL")).
+			WithArgs(sqlmock.AnyArg(), "1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE users SET password_hash = ? WHERE id = ?")).
+			WithArgs("new-hash", "u1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		assert.NoError(t, repo.Consume("1", "u1", "new-hash"))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("already used", func(t *testing.T) {
+		repo, mock, cleanup := newMockPasswordResetRepo(t)
+		defer cleanup()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE password_reset_tokens SET used_at = ? WHERE id = ? AND used_at IS NULL")).
+			WithArgs(sqlmock.AnyArg(), "1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repo.Consume("1", "u1", "new-hash")
+		assert.EqualError(t, err, "password reset token already used")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
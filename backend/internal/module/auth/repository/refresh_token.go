@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/database"
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+)
+
+//go:generate mockgen -source refresh_token.go -destination mock/refresh_token_mock.go -package=mock
+type RefreshTokenRepository interface {
+	Create(token *entity.RefreshToken) (*entity.RefreshToken, error)
+	GetByHash(hash string) (*entity.RefreshToken, error)
+	Rotate(usedID string, next *entity.RefreshToken) (*entity.RefreshToken, error)
+	RevokeFamily(familyID string) error
+}
+
+type RefreshToken struct {
+	db *sql.DB
+}
+
+func NewRefreshTokenRepo(db *sql.DB) *RefreshToken {
+	return &RefreshToken{db: db}
+}
+
+func (r *RefreshToken) Create(token *entity.RefreshToken) (*entity.RefreshToken, error) {
+	return insertRefreshToken(r.db, token)
+}
+
+func (r *RefreshToken) GetByHash(hash string) (*entity.RefreshToken, error) {
+	row := r.db.QueryRow(`SELECT id, user_id, family_id, token_hash, expires_at, created_at, used_at, revoked_at
+		FROM refresh_tokens WHERE token_hash = ?`, hash)
+	var t entity.RefreshToken
+	var usedAt, revokedAt sql.NullTime
+	if err := row.Scan(&t.ID, &t.UserID, &t.FamilyID, &t.TokenHash, &t.ExpiresAt, &t.CreatedAt, &usedAt, &revokedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrorNotFound("refresh token not found")
+		}
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if usedAt.Valid {
+		t.UsedAt = &usedAt.Time
+	}
+	if revokedAt.Valid {
+		t.RevokedAt = &revokedAt.Time
+	}
+	return &t, nil
+}
+
+// Rotate marks a token as used and stores the token replacing it in one transaction. The
+// used token must not have been used or revoked before, so of two requests replaying the
+// same token only one gets a new one.
+func (r *RefreshToken) Rotate(usedID string, next *entity.RefreshToken) (*entity.RefreshToken, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec("UPDATE refresh_tokens SET used_at = ? WHERE id = ? AND used_at IS NULL AND revoked_at IS NULL",
+		time.Now().UTC(), usedID)
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if affected == 0 {
+		return nil, entity.ErrorConflict("refresh token already used")
+	}
+	created, err := insertRefreshToken(tx, next)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return created, nil
+}
+
+func (r *RefreshToken) RevokeFamily(familyID string) error {
+	_, err := r.db.Exec("UPDATE refresh_tokens SET revoked_at = ? WHERE family_id = ? AND revoked_at IS NULL",
+		time.Now().UTC(), familyID)
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return nil
+}
+
+func insertRefreshToken(db database.Execer, token *entity.RefreshToken) (*entity.RefreshToken, error) {
+	created := *token
+	created.CreatedAt = time.Now().UTC()
+	res, err := db.Exec("INSERT INTO refresh_tokens(user_id, family_id, token_hash, expires_at, created_at) VALUES (?, ?, ?, ?, ?)",
+		created.UserID, created.FamilyID, created.TokenHash, created.ExpiresAt.UTC(), created.CreatedAt)
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	tokenID, err := res.LastInsertId()
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	created.ID = fmt.Sprint(tokenID)
+	return &created, nil
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMockRefreshTokenRepo(t *testing.T) (*RefreshToken, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	repo := NewRefreshTokenRepo(db)
+	cleanup := func() { db.Close() }
+	return repo, mock, cleanup
+}
+
+func TestRefreshTokenGetByHash(t *testing.T) {
+	repo, mock, cleanup := newMockRefreshTokenRepo(t)
+	defer cleanup()
+
+	now := time.Now().UTC()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, family_id, token_hash, expires_at, created_at, used_at, revoked_at
+		FROM refresh_tokens WHERE token_hash = ?`)).
+		WithArgs("hash").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "family_id", "token_hash", "expires_at", "created_at", "used_at", "revoked_at"}).
+			AddRow("1", "u1", "family", "hash", now.Add(time.Hour), now, now, nil))
+
+	token, err := repo.GetByHash("hash")
+	assert.NoError(t, err)
+	assert.Equal(t, "family", token.FamilyID)
+	assert.NotNil(t, token.UsedAt)
+	assert.Nil(t, token.RevokedAt)
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM refresh_tokens WHERE token_hash = ?")).
+		WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	_, err = repo.GetByHash("missing")
+	assert.EqualError(t, err, "refresh token not found")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRefreshTokenRotate(t *testing.T) {
+	next := &entity.RefreshToken{UserID: "u1", FamilyID: "family", TokenHash: "next", ExpiresAt: time.Now().Add(time.Hour)}
+
+	t.Run("success", func(t *testing.T) {
+		repo, mock, cleanup := newMockRefreshTokenRepo(t)
+		defer cleanup()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE refresh_tokens SET used_at = ? WHERE id = ? AND used_at IS NULL AND revoked_at IS NULL")).
+			WithArgs(sqlmock.AnyArg(), "1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO refresh_tokens(user_id, family_id, token_hash, expires_at, created_at) VALUES (?, ?, ?, ?, ?)")).
+			WithArgs("u1", "family", "next", sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectCommit()
+
+		created, err := repo.Rotate("1", next)
+		assert.NoError(t, err)
+		assert.Equal(t, "2", created.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("already used", func(t *testing.T) {
+		repo, mock, cleanup := newMockRefreshTokenRepo(t)
+		defer cleanup()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE refresh_tokens SET used_at = ?")).
+			WithArgs(sqlmock.AnyArg(), "1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		_, err := repo.Rotate("1", next)
+		var appErr *entity.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, entity.ErrorCodeConflict, appErr.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRefreshTokenRevokeFamily(t *testing.T) {
+	repo, mock, cleanup := newMockRefreshTokenRepo(t)
+	defer cleanup()
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE refresh_tokens SET revoked_at = ? WHERE family_id = ? AND revoked_at IS NULL")).
+		WithArgs(sqlmock.AnyArg(), "family").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	assert.NoError(t, repo.RevokeFamily("family"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+)
+
+//go:generate mockgen -source revocation.go -destination mock/revocation_mock.go -package=mock
+type RevocationRepository interface {
+	RevokeToken(jti, userID string, expiresAt time.Time) error
+	RevokeUser(userID string, before time.Time) error
+	ListRevokedTokens(now time.Time) (map[string]time.Time, error)
+	ListUserRevocations() (map[string]time.Time, error)
+	DeleteExpiredTokens(now time.Time) error
+}
+
+type Revocation struct {
+	db *sql.DB
+}
+
+func NewRevocationRepo(db *sql.DB) *Revocation {
+	return &Revocation{db: db}
+}
+
+func (r *Revocation) RevokeToken(jti, userID string, expiresAt time.Time) error {
+	_, err := r.db.Exec("INSERT OR IGNORE INTO revoked_tokens(jti, user_id, expires_at, revoked_at) VALUES (?, ?, ?, ?)",
+		jti, userID, expiresAt.UTC(), time.Now().UTC())
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return nil
+}
+
+// RevokeUser revokes every access token of the user issued before before, and all their
+// refresh tokens.
+func (r *Revocation) RevokeUser(userID string, before time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`INSERT INTO user_token_revocations(user_id, revoked_before) VALUES (?, ?)
+		ON CONFLICT(user_id) DO UPDATE SET revoked_before = excluded.revoked_before`, userID, before.UTC()); err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if _, err := tx.Exec("UPDATE refresh_tokens SET revoked_at = ? WHERE user_id = ? AND revoked_at IS NULL",
+		time.Now().UTC(), userID); err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if err := tx.Commit(); err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return nil
+}
+
+// ListRevokedTokens returns the expiry of the revoked tokens by jti, leaving out the ones
+// expired at now.
+func (r *Revocation) ListRevokedTokens(now time.Time) (map[string]time.Time, error) {
+	rows, err := r.db.Query("SELECT jti, expires_at FROM revoked_tokens WHERE julianday(expires_at) > julianday(?)",
+		now.UTC().Format(time.RFC3339Nano))
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return scanRevocations(rows)
+}
+
+// ListUserRevocations returns by user id the time their tokens are revoked before.
+func (r *Revocation) ListUserRevocations() (map[string]time.Time, error) {
+	rows, err := r.db.Query("SELECT user_id, revoked_before FROM user_token_revocations")
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return scanRevocations(rows)
+}
+
+func (r *Revocation) DeleteExpiredTokens(now time.Time) error {
+	_, err := r.db.Exec("DELETE FROM revoked_tokens WHERE julianday(expires_at) <= julianday(?)", now.UTC().Format(time.RFC3339Nano))
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return nil
+}
+
+func scanRevocations(rows *sql.Rows) (map[string]time.Time, error) {
+	defer rows.Close()
+	res := map[string]time.Time{}
+	for rows.Next() {
+		var key string
+		var at time.Time
+		if err := rows.Scan(&key, &at); err != nil {
+			return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		}
+		res[key] = at
+	}
+	if err := rows.Err(); err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return res, nil
+}
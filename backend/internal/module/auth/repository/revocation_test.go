@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMockRevocationRepo(t *testing.T) (*Revocation, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	repo := NewRevocationRepo(db)
+	cleanup := func() { db.Close() }
+	return repo, mock, cleanup
+}
+
+func TestRevocationRevokeUser(t *testing.T) {
+	repo, mock, cleanup := newMockRevocationRepo(t)
+	defer cleanup()
+
+	before := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO user_token_revocations(user_id, revoked_before) VALUES (?, ?)")).
+		WithArgs("2", before.UTC()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE refresh_tokens SET revoked_at = ? WHERE user_id = ? AND revoked_at IS NULL")).
+		WithArgs(sqlmock.AnyArg(), "2").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	assert.NoError(t, repo.RevokeUser("2", before))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRevocationListRevokedTokens(t *testing.T) {
+	repo, mock, cleanup := newMockRevocationRepo(t)
+	defer cleanup()
+
+	now := time.Now()
+	expiresAt := now.Add(time.Hour)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT jti, expires_at FROM revoked_tokens WHERE julianday(expires_at) > julianday(?)")).
+		WithArgs(now.UTC().Format(time.RFC3339Nano)).
+		WillReturnRows(sqlmock.NewRows([]string{"jti", "expires_at"}).AddRow("a", expiresAt))
+
+	tokens, err := repo.ListRevokedTokens(now)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]time.Time{"a": expiresAt}, tokens)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRevocationRevokeToken(t *testing.T) {
+	repo, mock, cleanup := newMockRevocationRepo(t)
+	defer cleanup()
+
+	expiresAt := time.Now().Add(time.Hour)
+	mock.ExpectExec(regexp.QuoteMeta("INSERT OR IGNORE INTO revoked_tokens(jti, user_id, expires_at, revoked_at) VALUES (?, ?, ?, ?)")).
+		WithArgs("a", "1", expiresAt.UTC(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.RevokeToken("a", "1", expiresAt))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
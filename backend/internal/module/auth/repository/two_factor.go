@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+)
+
+//go:generate mockgen -source two_factor.go -destination mock/two_factor_mock.go -package=mock
+type TwoFactorRepository interface {
+	GetTOTP(userID string) (*entity.TOTP, error)
+	SaveTOTPSecret(userID, secret string) error
+	EnableTOTP(userID string, step int64, recoveryCodeHashes []string) error
+	DisableTOTP(userID string) error
+	UseTOTPStep(userID string, step int64) error
+	UseRecoveryCode(userID, codeHash string) error
+	CreateChallenge(challenge *entity.LoginChallenge) (*entity.LoginChallenge, error)
+	GetChallengeByHash(hash string) (*entity.LoginChallenge, error)
+	AttemptChallenge(id string, maxAttempts int) error
+	UseChallenge(id string) error
+}
+
+type TwoFactor struct {
+	db *sql.DB
+}
+
+func NewTwoFactorRepo(db *sql.DB) *TwoFactor {
+	return &TwoFactor{db: db}
+}
+
+func (r *TwoFactor) GetTOTP(userID string) (*entity.TOTP, error) {
+	row := r.db.QueryRow("SELECT user_id, secret, enabled_at, last_step FROM user_totp WHERE user_id = ?", userID)
+	var t entity.TOTP
+	var enabledAt sql.NullTime
+	if err := row.Scan(&t.UserID, &t.Secret, &enabledAt, &t.LastStep); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrorNotFound("two-factor authentication not set up")
+		}
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if enabledAt.Valid {
+		t.EnabledAt = &enabledAt.Time
+	}
+	return &t, nil
+}
+
+// SaveTOTPSecret stores a pending secret, replacing the pending one of an earlier setup. The
+// secret of a user who already enabled two-factor authentication is kept.
+func (r *TwoFactor) SaveTOTPSecret(userID, secret string) error {
+	res, err := r.db.Exec(`INSERT INTO user_totp(user_id, secret, created_at) VALUES (?, ?, ?)
+		ON CONFLICT(user_id) DO UPDATE SET secret = excluded.secret, created_at = excluded.created_at, last_step = 0
+		WHERE user_totp.enabled_at IS NULL`, userID, secret, time.Now().UTC())
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if affected == 0 {
+		return entity.ErrorConflict("two-factor authentication already enabled")
+	}
+	return nil
+}
+
+// EnableTOTP enables the pending secret of a user, step is the time step of the code that
+// confirmed it, and replaces their recovery codes.
+func (r *TwoFactor) EnableTOTP(userID string, step int64, recoveryCodeHashes []string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec("UPDATE user_totp SET enabled_at = ?, last_step = ? WHERE user_id = ? AND enabled_at IS NULL",
+		time.Now().UTC(), step, userID)
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if affected == 0 {
+		return entity.ErrorConflict("two-factor authentication already enabled")
+	}
+	if _, err := tx.Exec("DELETE FROM totp_recovery_codes WHERE user_id = ?", userID); err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	for _, hash := range recoveryCodeHashes {
+		if _, err := tx.Exec("INSERT INTO totp_recovery_codes(user_id, code_hash) VALUES (?, ?)", userID, hash); err != nil {
+			return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return nil
+}
+
+// DisableTOTP removes the secret and the recovery codes of a user.
+func (r *TwoFactor) DisableTOTP(userID string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM totp_recovery_codes WHERE user_id = ?", userID); err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	res, err := tx.Exec("DELETE FROM user_totp WHERE user_id = ?", userID)
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if affected == 0 {
+		return entity.ErrorNotFound("two-factor authentication not set up")
+	}
+	if err := tx.Commit(); err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return nil
+}
+
+// UseTOTPStep records the time step of an accepted code. A step at or before the last one
+// recorded is a replayed code and returns a conflict error.
+func (r *TwoFactor) UseTOTPStep(userID string, step int64) error {
+	res, err := r.db.Exec("UPDATE user_totp SET last_step = ? WHERE user_id = ? AND enabled_at IS NOT NULL AND last_step < ?",
+		step, userID, step)
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if affected == 0 {
+		return entity.ErrorConflict("two-factor code already used")
+	}
+	return nil
+}
+
+// UseRecoveryCode marks an unused recovery code of the user as used, or returns a not found
+// error.
+func (r *TwoFactor) UseRecoveryCode(userID, codeHash string) error {
+	res, err := r.db.Exec("UPDATE totp_recovery_codes SET used_at = ? WHERE user_id = ? AND code_hash = ? AND used_at IS NULL",
+		time.Now().UTC(), userID, codeHash)
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if affected == 0 {
+		return entity.ErrorNotFound("recovery code not found")
+	}
+	return nil
+}
+
+func (r *TwoFactor) CreateChallenge(challenge *entity.LoginChallenge) (*entity.LoginChallenge, error) {
+	created := *challenge
+	created.CreatedAt = time.Now().UTC()
+	res, err := r.db.Exec("INSERT INTO login_challenges(user_id, token_hash, expires_at, created_at) VALUES (?, ?, ?, ?)",
+		created.UserID, created.TokenHash, created.ExpiresAt.UTC(), created.CreatedAt)
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	challengeID, err := res.LastInsertId()
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	created.ID = fmt.Sprint(challengeID)
+	return &created, nil
+}
+
+func (r *TwoFactor) GetChallengeByHash(hash string) (*entity.LoginChallenge, error) {
+	row := r.db.QueryRow(`SELECT id, user_id, token_hash, expires_at, created_at, attempts, used_at
+		FROM login_challenges WHERE token_hash = ?`, hash)
+	var c entity.LoginChallenge
+	var usedAt sql.NullTime
+	if err := row.Scan(&c.ID, &c.UserID, &c.TokenHash, &c.ExpiresAt, &c.CreatedAt, &c.Attempts, &usedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrorNotFound("login challenge not found")
+		}
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if usedAt.Valid {
+		c.UsedAt = &usedAt.Time
+	}
+	return &c, nil
+}
+
+// AttemptChallenge counts an attempt to answer a challenge before the code is checked, so
+// concurrent guesses cannot exceed maxAttempts. A challenge out of attempts or already used
+// returns a conflict error.
+func (r *TwoFactor) AttemptChallenge(id string, maxAttempts int) error {
+	res, err := r.db.Exec("UPDATE login_challenges SET attempts = attempts + 1 WHERE id = ? AND used_at IS NULL AND attempts < ?",
+		id, maxAttempts)
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if affected == 0 {
+		return entity.ErrorConflict("login challenge has no attempts left")
+	}
+	return nil
+}
+
+func (r *TwoFactor) UseChallenge(id string) error {
+	res, err := r.db.Exec("UPDATE login_challenges SET used_at = ? WHERE id = ? AND used_at IS NULL", time.Now().UTC(), id)
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if affected == 0 {
+		return entity.ErrorConflict("login challenge already used")
+	}
+	return nil
+}
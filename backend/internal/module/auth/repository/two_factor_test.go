@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMockTwoFactorRepo(t *testing.T) (*TwoFactor, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	repo := NewTwoFactorRepo(db)
+	cleanup := func() { db.Close() }
+	return repo, mock, cleanup
+}
+
+func TestTwoFactorGetTOTP(t *testing.T) {
+	repo, mock, cleanup := newMockTwoFactorRepo(t)
+	defer cleanup()
+
+	now := time.Now().UTC()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT user_id, secret, enabled_at, last_step FROM user_totp WHERE user_id = ?")).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "secret", "enabled_at", "last_step"}).AddRow("u1", "SECRET", now, 100))
+	totp, err := repo.GetTOTP("u1")
+	assert.NoError(t, err)
+	assert.True(t, totp.Enabled())
+	assert.Equal(t, int64(100), totp.LastStep)
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM user_totp WHERE user_id = ?")).
+		WithArgs("u2").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+	_, err = repo.GetTOTP("u2")
+	assert.EqualError(t, err, "two-factor authentication not set up")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTwoFactorSaveTOTPSecret_AlreadyEnabled(t *testing.T) {
+	repo, mock, cleanup := newMockTwoFactorRepo(t)
+	defer cleanup()
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO user_totp(user_id, secret, created_at) VALUES (?, ?, ?)")).
+		WithArgs("u1", "SECRET", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.SaveTOTPSecret("u1", "SECRET")
+	assert.EqualError(t, err, "two-factor authentication already enabled")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTwoFactorEnableTOTP(t *testing.T) {
+	repo, mock, cleanup := newMockTwoFactorRepo(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE user_totp SET enabled_at = ?, last_step = ? WHERE user_id = ? AND enabled_at IS NULL")).
+		WithArgs(sqlmock.AnyArg(), int64(100), "u1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM totp_recovery_codes WHERE user_id = ?")).
+		WithArgs("u1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	for _, hash := range []string{"h1", "h2"} {
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO totp_recovery_codes(user_id, code_hash) VALUES (?, ?)")).
+			WithArgs("u1", hash).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	mock.ExpectCommit()
+
+	assert.NoError(t, repo.EnableTOTP("u1", 100, []string{"h1", "h2"}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTwoFactorUseTOTPStep_Replay(t *testing.T) {
+	repo, mock, cleanup := newMockTwoFactorRepo(t)
+	defer cleanup()
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE user_totp SET last_step = ? WHERE user_id = ? AND enabled_at IS NOT NULL AND last_step < ?")).
+		WithArgs(int64(100), "u1", int64(100)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.UseTOTPStep("u1", 100)
+	assert.EqualError(t, err, "two-factor code already used")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTwoFactorUseRecoveryCode_NotFound(t *testing.T) {
+	repo, mock, cleanup := newMockTwoFactorRepo(t)
+	defer cleanup()
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE totp_recovery_codes SET used_at = ? WHERE user_id = ? AND code_hash = ? AND used_at IS NULL")).
+		WithArgs(sqlmock.AnyArg(), "u1", "hash").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.UseRecoveryCode("u1", "hash")
+	assert.EqualError(t, err, "recovery code not found")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTwoFactorChallenge(t *testing.T) {
+	repo, mock, cleanup := newMockTwoFactorRepo(t)
+	defer cleanup()
+
+	expiresAt := time.Now().Add(5 * time.Minute)
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO login_challenges(user_id, token_hash, expires_at, created_at) VALUES (?, ?, ?, ?)")).
+		WithArgs("u1", "hash", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	created, err := repo.CreateChallenge(&entity.LoginChallenge{UserID: "u1", TokenHash: "hash", ExpiresAt: expiresAt})
+	assert.NoError(t, err)
+	assert.Equal(t, "3", created.ID)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, token_hash, expires_at, created_at, attempts, used_at
+		FROM login_challenges WHERE token_hash = ?`)).
+		WithArgs("hash").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "token_hash", "expires_at", "created_at", "attempts", "used_at"}).
+			AddRow("3", "u1", "hash", expiresAt, time.Now(), 2, nil))
+	challenge, err := repo.GetChallengeByHash("hash")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, challenge.Attempts)
+	assert.Nil(t, challenge.UsedAt)
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE login_challenges SET attempts = attempts + 1 WHERE id = ? AND used_at IS NULL AND attempts < ?")).
+		WithArgs("3", 5).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = repo.AttemptChallenge("3", 5)
+	assert.EqualError(t, err, "login challenge has no attempts left")
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE login_challenges SET used_at = ? WHERE id = ? AND used_at IS NULL")).
+		WithArgs(sqlmock.AnyArg(), "3").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.UseChallenge("3"))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
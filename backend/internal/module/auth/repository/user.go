@@ -2,14 +2,26 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/fajrinajiseno/mygolangapp/internal/database"
 	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/mattn/go-sqlite3"
 )
 
 //go:generate mockgen -source user.go -destination mock/user_mock.go -package=mock
 type UserRepository interface {
 	GetUserByEmail(email string) (*entity.User, error)
 	GetUserById(id string) (*entity.User, error)
+	GetRolePermissions(role string) ([]entity.Permission, error)
+	GetUsers(filter entity.UserFilter, limit, offset int) ([]*entity.User, int, error)
+	CreateUser(u *entity.User) (*entity.User, error)
+	UpdateUserRole(id, role string) (*entity.User, error)
+	UpdateUserStatus(id string, status entity.UserStatus) (*entity.User, error)
+	DeleteUser(id string) error
 }
 
 type User struct {
@@ -20,26 +32,207 @@ func NewUserRepo(db *sql.DB) *User {
 	return &User{db: db}
 }
 
+const userSelect = `SELECT id, email, password_hash, role, status, created_at FROM users`
+
+// An admin is an active user whose role grants user:manage. keepsAdmin holds for a row of
+// users unless it is the only admin, role changes, disabling and deleting are conditioned
+// on it so the last admin cannot be removed, not even by two concurrent requests.
+const (
+	adminRoles = `SELECT role FROM role_permissions WHERE permission = '` + string(entity.PermissionUserManage) + `'`
+	keepsAdmin = `(status != 'active' OR role NOT IN (` + adminRoles + `)
+		OR EXISTS (SELECT 1 FROM users o WHERE o.id != users.id AND o.status = 'active' AND o.role IN (` + adminRoles + `)))`
+)
+
 func (r *User) GetUserByEmail(email string) (*entity.User, error) {
-	row := r.db.QueryRow(`SELECT id, email, password_hash, role FROM users WHERE email = ?`, email)
-	var u entity.User
-	if err := row.Scan(&u.ID, &u.Email, &u.PasswordHash, &u.Role); err != nil {
+	u, err := scanUser(r.db.QueryRow(userSelect+" WHERE email = ? COLLATE NOCASE", email))
+	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, entity.ErrorNotFound("user not found")
 		}
 		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
 	}
-	return &u, nil
+	return u, nil
 }
 
 func (r *User) GetUserById(id string) (*entity.User, error) {
-	row := r.db.QueryRow(`SELECT id, email, password_hash, role FROM users WHERE id = ?`, id)
-	var u entity.User
-	if err := row.Scan(&u.ID, &u.Email, &u.PasswordHash, &u.Role); err != nil {
+	u, err := scanUser(r.db.QueryRow(userSelect+" WHERE id = ?", id))
+	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, entity.ErrorNotFound("user not found")
 		}
 		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
 	}
+	return u, nil
+}
+
+// GetRolePermissions returns the permissions granted to a role, none for an unknown role.
+func (r *User) GetRolePermissions(role string) ([]entity.Permission, error) {
+	rows, err := r.db.Query(`SELECT permission FROM role_permissions WHERE role = ? ORDER BY permission`, role)
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	defer rows.Close()
+	permissions := []entity.Permission{}
+	for rows.Next() {
+		var p entity.Permission
+		if err := rows.Scan(&p); err != nil {
+			return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		}
+		permissions = append(permissions, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return permissions, nil
+}
+
+func (r *User) GetUsers(filter entity.UserFilter, limit, offset int) ([]*entity.User, int, error) {
+	q := userSelect
+	qt := "SELECT COUNT(1) FROM users"
+	where := []string{}
+	args := []interface{}{}
+	if filter.Role != "" {
+		where = append(where, "role = ?")
+		args = append(args, filter.Role)
+	}
+	if filter.Status != "" {
+		where = append(where, "status = ?")
+		args = append(args, string(filter.Status))
+	}
+	if len(where) > 0 {
+		q += " WHERE " + strings.Join(where, " AND ")
+		qt += " WHERE " + strings.Join(where, " AND ")
+	}
+	argsT := append([]interface{}{}, args...)
+	q += " ORDER BY id ASC"
+	if limit > 0 {
+		q += " LIMIT ?"
+		args = append(args, limit)
+	}
+	if offset > 0 {
+		q += " OFFSET ?"
+		args = append(args, offset)
+	}
+
+	rows, err := r.db.Query(q, args...)
+	if err != nil {
+		return nil, 0, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	defer rows.Close()
+	res := []*entity.User{}
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, 0, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		}
+		res = append(res, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+
+	var total int
+	if err := r.db.QueryRow(qt, argsT...).Scan(&total); err != nil {
+		return nil, 0, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return res, total, nil
+}
+
+func (r *User) CreateUser(u *entity.User) (*entity.User, error) {
+	created := *u
+	created.CreatedAt = time.Now().UTC()
+	res, err := r.db.Exec("INSERT INTO users(email, password_hash, role, status, created_at) VALUES (?, ?, ?, ?, ?)",
+		created.Email, created.PasswordHash, created.Role, string(created.Status), created.CreatedAt)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+			return nil, entity.ErrorConflict("email already registered")
+		}
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	userID, err := res.LastInsertId()
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	created.ID = fmt.Sprint(userID)
+	return &created, nil
+}
+
+func (r *User) UpdateUserRole(id, role string) (*entity.User, error) {
+	res, err := r.db.Exec("UPDATE users SET role = ? WHERE id = ? AND (? IN ("+adminRoles+") OR "+keepsAdmin+")", role, id, role)
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if err := r.checkLastAdmin(res, id); err != nil {
+		return nil, err
+	}
+	return r.GetUserById(id)
+}
+
+func (r *User) UpdateUserStatus(id string, status entity.UserStatus) (*entity.User, error) {
+	res, err := r.db.Exec("UPDATE users SET status = ? WHERE id = ? AND (? = 'active' OR "+keepsAdmin+")",
+		string(status), id, string(status))
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if err := r.checkLastAdmin(res, id); err != nil {
+		return nil, err
+	}
+	return r.GetUserById(id)
+}
+
+// DeleteUser removes a user with their sessions. Users referenced by payments, reviews,
+// notes or exports are kept by the foreign keys and a conflict error is returned, those
+// should be disabled instead.
+func (r *User) DeleteUser(id string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	defer tx.Rollback()
+
+	for _, table := range []string{"refresh_tokens", "revoked_tokens", "user_token_revocations", "password_reset_tokens",
+		"totp_recovery_codes", "user_totp", "login_challenges"} {
+		if _, err := tx.Exec("DELETE FROM "+table+" WHERE user_id = ?", id); err != nil {
+			return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		}
+	}
+	res, err := tx.Exec("DELETE FROM users WHERE id = ? AND "+keepsAdmin, id)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.Code == sqlite3.ErrConstraint {
+			return entity.ErrorConflict("user has activity, disable it instead")
+		}
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if err := r.checkLastAdmin(res, id); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return nil
+}
+
+// checkLastAdmin tells why a statement conditioned on keepsAdmin changed no row.
+func (r *User) checkLastAdmin(res sql.Result, id string) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if affected > 0 {
+		return nil
+	}
+	if _, err := r.GetUserById(id); err != nil {
+		return err
+	}
+	return entity.ErrorConflict("cannot remove the last admin")
+}
+
+func scanUser(row database.RowScanner) (*entity.User, error) {
+	var u entity.User
+	if err := row.Scan(&u.ID, &u.Email, &u.PasswordHash, &u.Role, &u.Status, &u.CreatedAt); err != nil {
+		return nil, err
+	}
 	return &u, nil
 }
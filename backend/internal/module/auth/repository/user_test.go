@@ -5,8 +5,10 @@ import (
 	"errors"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -24,10 +26,10 @@ func TestGetUserByEmail_Success(t *testing.T) {
 	repo, mock, cleanup := newMockUserRepo(t)
 	defer cleanup()
 
-	rows := sqlmock.NewRows([]string{"id", "email", "password_hash", "role"}).
-		AddRow("u1", "alice@example.com", "$2a$hash", "admin")
+	rows := sqlmock.NewRows([]string{"id", "email", "password_hash", "role", "status", "created_at"}).
+		AddRow("u1", "alice@example.com", "$2a$hash", "admin", "active", time.Now())
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, email, password_hash, role FROM users WHERE email = ?")).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, email, password_hash, role, status, created_at FROM users WHERE email = ? COLLATE NOCASE")).
 		WithArgs("alice@example.com").
 		WillReturnRows(rows)
 
@@ -47,7 +49,7 @@ func TestGetUserByEmail_NotFound(t *testing.T) {
 	repo, mock, cleanup := newMockUserRepo(t)
 	defer cleanup()
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, email, password_hash, role FROM users WHERE email = ?")).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, email, password_hash, role, status, created_at FROM users WHERE email = ? COLLATE NOCASE")).
 		WithArgs("missing@example.com").
 		WillReturnError(sql.ErrNoRows)
 
@@ -65,7 +67,7 @@ func TestGetUserByEmail_DBError(t *testing.T) {
 	repo, mock, cleanup := newMockUserRepo(t)
 	defer cleanup()
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, email, password_hash, role FROM users WHERE email = ?")).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, email, password_hash, role, status, created_at FROM users WHERE email = ? COLLATE NOCASE")).
 		WithArgs("err@example.com").
 		WillReturnError(errors.New("db fail"))
 
@@ -83,10 +85,10 @@ func TestGetUserById_Success(t *testing.T) {
 	repo, mock, cleanup := newMockUserRepo(t)
 	defer cleanup()
 
-	rows := sqlmock.NewRows([]string{"id", "email", "password_hash", "role"}).
-		AddRow("u2", "bob@example.com", "$2a$hash2", "user")
+	rows := sqlmock.NewRows([]string{"id", "email", "password_hash", "role", "status", "created_at"}).
+		AddRow("u2", "bob@example.com", "$2a$hash2", "user", "active", time.Now())
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, email, password_hash, role FROM users WHERE id = ?")).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, email, password_hash, role, status, created_at FROM users WHERE id = ?")).
 		WithArgs("u2").
 		WillReturnRows(rows)
 
@@ -105,7 +107,7 @@ func TestGetUserById_NotFound(t *testing.T) {
 	repo, mock, cleanup := newMockUserRepo(t)
 	defer cleanup()
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, email, password_hash, role FROM users WHERE id = ?")).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, email, password_hash, role, status, created_at FROM users WHERE id = ?")).
 		WithArgs("missing").
 		WillReturnError(sql.ErrNoRows)
 
@@ -123,7 +125,7 @@ func TestGetUserById_DBError(t *testing.T) {
 	repo, mock, cleanup := newMockUserRepo(t)
 	defer cleanup()
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, email, password_hash, role FROM users WHERE id = ?")).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, email, password_hash, role, status, created_at FROM users WHERE id = ?")).
 		WithArgs("errid").
 		WillReturnError(errors.New("db fail"))
 
@@ -136,3 +138,122 @@ func TestGetUserById_DBError(t *testing.T) {
 		t.Fatalf("unfulfilled expectations: %v", err)
 	}
 }
+
+func TestGetRolePermissions_Success(t *testing.T) {
+	repo, mock, cleanup := newMockUserRepo(t)
+	defer cleanup()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT permission FROM role_permissions WHERE role = ? ORDER BY permission")).
+		WithArgs("cs").
+		WillReturnRows(sqlmock.NewRows([]string{"permission"}).AddRow("payment:note").AddRow("payment:read"))
+
+	permissions, err := repo.GetRolePermissions("cs")
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.Permission{entity.PermissionPaymentNote, entity.PermissionPaymentRead}, permissions)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetRolePermissions_DBError(t *testing.T) {
+	repo, mock, cleanup := newMockUserRepo(t)
+	defer cleanup()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT permission FROM role_permissions WHERE role = ? ORDER BY permission")).
+		WithArgs("cs").
+		WillReturnError(errors.New("db fail"))
+
+	_, err := repo.GetRolePermissions("cs")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "db error")
+}
+
+func TestGetUsers(t *testing.T) {
+	repo, mock, cleanup := newMockUserRepo(t)
+	defer cleanup()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, email, password_hash, role, status, created_at FROM users WHERE role = ? AND status = ? ORDER BY id ASC LIMIT ? OFFSET ?")).
+		WithArgs("cs", "active", 10, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "password_hash", "role", "status", "created_at"}).
+			AddRow("1", "cs@test.com", "$2a$hash", "cs", "active", time.Now()))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(1) FROM users WHERE role = ? AND status = ?")).
+		WithArgs("cs", "active").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(6))
+
+	users, total, err := repo.GetUsers(entity.UserFilter{Role: "cs", Status: entity.UserStatusActive}, 10, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, total)
+	assert.Len(t, users, 1)
+	assert.Equal(t, entity.UserStatusActive, users[0].Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateUser(t *testing.T) {
+	repo, mock, cleanup := newMockUserRepo(t)
+	defer cleanup()
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO users(email, password_hash, role, status, created_at) VALUES (?, ?, ?, ?, ?)")).
+		WithArgs("new@test.com", "$2a$hash", "cs", "active", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+
+	u, err := repo.CreateUser(&entity.User{Email: "new@test.com", PasswordHash: "$2a$hash", Role: "cs", Status: entity.UserStatusActive})
+	assert.NoError(t, err)
+	assert.Equal(t, "3", u.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateUserRole_LastAdmin(t *testing.T) {
+	repo, mock, cleanup := newMockUserRepo(t)
+	defer cleanup()
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE users SET role = ? WHERE id = ? AND (? IN ("+adminRoles+") OR "+keepsAdmin+")")).
+		WithArgs("cs", "1", "cs").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, email, password_hash, role, status, created_at FROM users WHERE id = ?")).
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "password_hash", "role", "status", "created_at"}).
+			AddRow("1", "admin@test.com", "$2a$hash", "admin", "active", time.Now()))
+
+	_, err := repo.UpdateUserRole("1", "cs")
+	var appErr *entity.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, entity.ErrorCodeConflict, appErr.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateUserStatus_NotFound(t *testing.T) {
+	repo, mock, cleanup := newMockUserRepo(t)
+	defer cleanup()
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE users SET status = ? WHERE id = ? AND (? = 'active' OR "+keepsAdmin+")")).
+		WithArgs("disabled", "9", "disabled").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, email, password_hash, role, status, created_at FROM users WHERE id = ?")).
+		WithArgs("9").
+		WillReturnError(sql.ErrNoRows)
+
+	_, err := repo.UpdateUserStatus("9", entity.UserStatusDisabled)
+	assert.EqualError(t, err, "user not found")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteUser(t *testing.T) {
+	repo, mock, cleanup := newMockUserRepo(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	for _, table := range []string{"refresh_tokens", "revoked_tokens", "user_token_revocations", "password_reset_tokens",
+		"totp_recovery_codes", "user_totp", "login_challenges"} {
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM " + table + " WHERE user_id = ?")).
+			WithArgs("2").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM users WHERE id = ? AND " + keepsAdmin)).
+		WithArgs("2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, repo.DeleteUser("2"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
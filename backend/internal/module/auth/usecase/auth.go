@@ -1,9 +1,18 @@
 package usecase
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"time"
 
+	"github.com/fajrinajiseno/mygolangapp/internal/authz"
 	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/fajrinajiseno/mygolangapp/internal/jwtkey"
+	"github.com/fajrinajiseno/mygolangapp/internal/middleware"
 	"github.com/fajrinajiseno/mygolangapp/internal/module/auth/repository"
 	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
@@ -11,41 +20,366 @@ import (
 
 //go:generate mockgen -source auth.go -destination mock/auth_mock.go -package=mock
 type AuthUsecase interface {
-	Login(email string, password string) (string, *entity.User, error)
+	Login(email string, password string, ip string) (*entity.LoginResult, error)
+	VerifyLogin(challengeToken string, code string, ip string) (*entity.AuthTokens, *entity.User, error)
+	Refresh(refreshToken string) (*entity.AuthTokens, *entity.User, error)
+	Logout(ctx context.Context, refreshToken string) error
+	RevokeSessions(ctx context.Context, userID string) error
 }
 
 type Auth struct {
-	repo      repository.UserRepository
-	jwtSecret []byte
-	ttl       time.Duration
+	repo        repository.UserRepository
+	tokenRepo   repository.RefreshTokenRepository
+	twoFactor   repository.TwoFactorRepository
+	lockouts    repository.LockoutRepository
+	revocations authz.RevocationList
+	authorizer  authz.Authorizer
+	keys        *jwtkey.Keyring
+	ttl         time.Duration
+	refreshTTL  time.Duration
 }
 
-func NewAuthUsecase(repo repository.UserRepository, jwtSecret []byte, ttl time.Duration) *Auth {
-	return &Auth{repo: repo, jwtSecret: jwtSecret, ttl: ttl}
+func NewAuthUsecase(repo repository.UserRepository, tokenRepo repository.RefreshTokenRepository, twoFactor repository.TwoFactorRepository,
+	lockouts repository.LockoutRepository, revocations authz.RevocationList, az authz.Authorizer, keys *jwtkey.Keyring, ttl, refreshTTL time.Duration) *Auth {
+	return &Auth{
+		repo:        repo,
+		tokenRepo:   tokenRepo,
+		twoFactor:   twoFactor,
+		lockouts:    lockouts,
+		revocations: revocations,
+		authorizer:  az,
+		keys:        keys,
+		ttl:         ttl,
+		refreshTTL:  refreshTTL,
+	}
+}
+
+const (
+	refreshTokenBytes   = 32
+	tokenIDBytes        = 16
+	challengeTokenBytes = 32
+	challengeTTL        = 5 * time.Minute
+	// maxChallengeAttempts bounds the codes guessed per verified password
+	maxChallengeAttempts = 5
+)
+
+// Login verifies email + password and returns an access token and a refresh token starting
+// a new token family, with the user and their permissions. A user with two-factor
+// authentication gets a challenge token instead, exchanged at VerifyLogin. Failed logins
+// are counted by email and by ip, and lock them out for a while once too many.
+func (a *Auth) Login(email string, password string, ip string) (*entity.LoginResult, error) {
+	now := time.Now()
+	keys := loginKeys(email, ip)
+	if err := a.checkLockout(keys, now); err != nil {
+		return nil, err
+	}
+	user, err := a.verifyPassword(email, password)
+	if err != nil {
+		var appErr *entity.AppError
+		if errors.As(err, &appErr) && appErr.Code == entity.ErrorCodeUnauthorized {
+			if err := a.recordFailure(keys, now); err != nil {
+				return nil, err
+			}
+		}
+		return nil, err
+	}
+	if user.Status == entity.UserStatusDisabled {
+		return nil, entity.ErrorUnauthorized("user disabled")
+	}
+	if user.Permissions, err = a.repo.GetRolePermissions(user.Role); err != nil {
+		return nil, err
+	}
+
+	t, err := a.twoFactor.GetTOTP(user.ID)
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+	if t.Enabled() {
+		token, err := randomToken(challengeTokenBytes)
+		if err != nil {
+			return nil, err
+		}
+		challenge, err := a.twoFactor.CreateChallenge(&entity.LoginChallenge{
+			UserID:    user.ID,
+			TokenHash: hashToken(token),
+			ExpiresAt: time.Now().Add(challengeTTL).UTC(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &entity.LoginResult{User: user, ChallengeToken: token, ChallengeExpiresAt: challenge.ExpiresAt}, nil
+	}
+
+	if err := a.clearFailures(email); err != nil {
+		return nil, err
+	}
+	tokens, err := a.startSession(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.LoginResult{User: user, Tokens: tokens}, nil
 }
 
-// Login verifies email + password and returns a JWT.
-func (a *Auth) Login(email string, password string) (string, *entity.User, error) {
+// verifyPassword returns the user of email when password matches. An unknown email and a
+// wrong password get the same error.
+func (a *Auth) verifyPassword(email string, password string) (*entity.User, error) {
 	user, err := a.repo.GetUserByEmail(email)
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+	if user == nil || user.ID == "" {
+		_ = bcrypt.CompareHashAndPassword(dummyHash(), []byte(password))
+		return nil, entity.ErrorUnauthorized("invalid credentials")
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil {
+		return nil, entity.ErrorUnauthorized("invalid credentials")
+	}
+	return user, nil
+}
+
+// VerifyLogin completes the login of a user with two-factor authentication with the
+// challenge token returned by Login and a TOTP or recovery code. A challenge is single use
+// and allows maxChallengeAttempts codes, then the password has to be entered again. Wrong
+// codes count as failed logins.
+func (a *Auth) VerifyLogin(challengeToken string, code string, ip string) (*entity.AuthTokens, *entity.User, error) {
+	now := time.Now()
+	challenge, err := a.twoFactor.GetChallengeByHash(hashToken(challengeToken))
 	if err != nil {
-		return "", nil, err
+		if isNotFound(err) {
+			return nil, nil, entity.ErrorUnauthorized("invalid challenge token")
+		}
+		return nil, nil, err
+	}
+	if challenge.UsedAt != nil {
+		return nil, nil, entity.ErrorUnauthorized("challenge token already used")
 	}
-	if user.ID == "" {
-		return "", nil, entity.ErrorNotFound("user not found")
+	if !now.Before(challenge.ExpiresAt) {
+		return nil, nil, entity.ErrorUnauthorized("challenge token expired")
 	}
-	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
-		return "", nil, entity.WrapError(err, entity.ErrorCodeUnauthorized, "invalid credentials")
+	if err := a.twoFactor.AttemptChallenge(challenge.ID, maxChallengeAttempts); err != nil {
+		var appErr *entity.AppError
+		if errors.As(err, &appErr) && appErr.Code == entity.ErrorCodeConflict {
+			return nil, nil, entity.ErrorUnauthorized("too many attempts, log in again")
+		}
+		return nil, nil, err
 	}
 
+	user, err := a.repo.GetUserById(challenge.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user.Status == entity.UserStatusDisabled {
+		return nil, nil, entity.ErrorUnauthorized("user disabled")
+	}
+	keys := loginKeys(user.Email, ip)
+	if err := a.checkLockout(keys, now); err != nil {
+		return nil, nil, err
+	}
+	t, err := a.twoFactor.GetTOTP(user.ID)
+	if err != nil && !isNotFound(err) {
+		return nil, nil, err
+	}
+	if !t.Enabled() {
+		// turned off since the password was verified
+		return nil, nil, entity.ErrorUnauthorized("log in again")
+	}
+	if err := verifySecondFactor(a.twoFactor, t, code); err != nil {
+		var appErr *entity.AppError
+		if errors.As(err, &appErr) && appErr.Code == entity.ErrorCodeValidation {
+			if err := a.recordFailure(keys, now); err != nil {
+				return nil, nil, err
+			}
+			return nil, nil, entity.ErrorUnauthorized(appErr.Message)
+		}
+		return nil, nil, err
+	}
+	if err := a.twoFactor.UseChallenge(challenge.ID); err != nil {
+		var appErr *entity.AppError
+		if errors.As(err, &appErr) && appErr.Code == entity.ErrorCodeConflict {
+			return nil, nil, entity.ErrorUnauthorized("challenge token already used")
+		}
+		return nil, nil, err
+	}
+	if user.Permissions, err = a.repo.GetRolePermissions(user.Role); err != nil {
+		return nil, nil, err
+	}
+	if err := a.clearFailures(user.Email); err != nil {
+		return nil, nil, err
+	}
+
+	tokens, err := a.startSession(user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tokens, user, nil
+}
+
+// Refresh exchanges a refresh token for new tokens. Every refresh token is single use, it is
+// replaced by a new one of the same family. Presenting a token that was already replaced
+// means it leaked, so the whole family is revoked and the user has to log in again.
+func (a *Auth) Refresh(refreshToken string) (*entity.AuthTokens, *entity.User, error) {
+	stored, err := a.tokenRepo.GetByHash(hashToken(refreshToken))
+	if err != nil {
+		var appErr *entity.AppError
+		if errors.As(err, &appErr) && appErr.Code == entity.ErrorCodeNotFound {
+			return nil, nil, entity.ErrorUnauthorized("invalid refresh token")
+		}
+		return nil, nil, err
+	}
+	if stored.RevokedAt != nil {
+		return nil, nil, entity.ErrorUnauthorized("refresh token revoked")
+	}
+	if stored.UsedAt != nil {
+		return nil, nil, a.revokeReused(stored.FamilyID)
+	}
+	if !time.Now().Before(stored.ExpiresAt) {
+		return nil, nil, entity.ErrorUnauthorized("refresh token expired")
+	}
+
+	user, err := a.repo.GetUserById(stored.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user.Status == entity.UserStatusDisabled {
+		return nil, nil, entity.ErrorUnauthorized("user disabled")
+	}
+	if user.Permissions, err = a.repo.GetRolePermissions(user.Role); err != nil {
+		return nil, nil, err
+	}
+
+	rotated, next, err := a.newRefreshToken(user.ID, stored.FamilyID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := a.tokenRepo.Rotate(stored.ID, next); err != nil {
+		var appErr *entity.AppError
+		if errors.As(err, &appErr) && appErr.Code == entity.ErrorCodeConflict {
+			// another request rotated the token first
+			return nil, nil, a.revokeReused(stored.FamilyID)
+		}
+		return nil, nil, err
+	}
+	tokens, err := a.issue(user.ID, rotated, next.ExpiresAt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tokens, user, nil
+}
+
+// Logout revokes the access token of the request. The family of refreshToken is revoked
+// too when it belongs to the same user, an unknown refresh token is ignored.
+func (a *Auth) Logout(ctx context.Context, refreshToken string) error {
+	claims := middleware.GetTokenClaims(ctx)
+	if claims == nil {
+		return entity.ErrorUnauthorized("missing token")
+	}
+	if err := a.revocations.RevokeToken(claims); err != nil {
+		return err
+	}
+	if refreshToken == "" {
+		return nil
+	}
+	stored, err := a.tokenRepo.GetByHash(hashToken(refreshToken))
+	if err != nil {
+		var appErr *entity.AppError
+		if errors.As(err, &appErr) && appErr.Code == entity.ErrorCodeNotFound {
+			return nil
+		}
+		return err
+	}
+	if stored.UserID != claims.Subject {
+		return nil
+	}
+	return a.tokenRepo.RevokeFamily(stored.FamilyID)
+}
+
+// RevokeSessions revokes every access and refresh token of a user, requires user:manage.
+func (a *Auth) RevokeSessions(ctx context.Context, userID string) error {
+	if _, err := a.authorizer.Authorize(ctx, entity.PermissionUserManage); err != nil {
+		return err
+	}
+	if _, err := a.repo.GetUserById(userID); err != nil {
+		return err
+	}
+	return a.revocations.RevokeUser(userID)
+}
+
+// startSession issues the tokens of a new login, starting a new refresh token family.
+func (a *Auth) startSession(userID string) (*entity.AuthTokens, error) {
+	familyID, err := randomToken(tokenIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, next, err := a.newRefreshToken(userID, familyID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := a.tokenRepo.Create(next); err != nil {
+		return nil, err
+	}
+	return a.issue(userID, refreshToken, next.ExpiresAt)
+}
+
+func (a *Auth) revokeReused(familyID string) error {
+	if err := a.tokenRepo.RevokeFamily(familyID); err != nil {
+		return err
+	}
+	return entity.ErrorUnauthorized("refresh token reused")
+}
+
+func (a *Auth) newRefreshToken(userID, familyID string) (string, *entity.RefreshToken, error) {
+	token, err := randomToken(refreshTokenBytes)
+	if err != nil {
+		return "", nil, err
+	}
+	return token, &entity.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(a.refreshTTL).UTC(),
+	}, nil
+}
+
+func (a *Auth) issue(userID, refreshToken string, refreshExpiresAt time.Time) (*entity.AuthTokens, error) {
+	jti, err := randomToken(tokenIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	expiresAt := now.Add(a.ttl)
 	claims := jwt.MapClaims{
-		"sub": user.ID,
-		"exp": time.Now().Add(a.ttl).Unix(),
-		"iat": time.Now().Unix(),
+		"jti": jti,
+		"sub": userID,
+		"exp": expiresAt.Unix(),
+		// in microseconds so revoking the sessions of a user spares a login right after
+		"iat": float64(now.UnixMicro()) / 1e6,
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signed, err := token.SignedString(a.jwtSecret)
+	signed, err := a.keys.Sign(claims)
 	if err != nil {
-		return "", nil, entity.WrapError(err, entity.ErrorCodeUnauthorized, "invalid credentials")
+		return nil, entity.WrapError(err, entity.ErrorCodeUnauthorized, "invalid credentials")
 	}
-	return signed, user, nil
+	return &entity.AuthTokens{
+		AccessToken:      signed,
+		AccessExpiresAt:  expiresAt.UTC(),
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: refreshExpiresAt,
+	}, nil
+}
+
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", entity.WrapError(err, entity.ErrorCodeInternal, "internal error")
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func isNotFound(err error) bool {
+	var appErr *entity.AppError
+	return errors.As(err, &appErr) && appErr.Code == entity.ErrorCodeNotFound
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
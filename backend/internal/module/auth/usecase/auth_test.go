@@ -1,12 +1,20 @@
 package usecase
 
 import (
+	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	azm "github.com/fajrinajiseno/mygolangapp/internal/authz/mock"
+	"github.com/fajrinajiseno/mygolangapp/internal/config"
 	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/fajrinajiseno/mygolangapp/internal/jwtkey"
+	"github.com/fajrinajiseno/mygolangapp/internal/middleware"
 	"github.com/fajrinajiseno/mygolangapp/internal/module/auth/repository/mock"
+	"github.com/fajrinajiseno/mygolangapp/internal/totp"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -29,19 +37,48 @@ func TestAuth_Login(t *testing.T) {
 	}
 
 	mockRepo := mock.NewMockUserRepository(ctrl)
+	mockTokenRepo := mock.NewMockRefreshTokenRepository(ctrl)
+	mockTwoFactor := mock.NewMockTwoFactorRepository(ctrl)
+	mockLockouts := mock.NewMockLockoutRepository(ctrl)
+	noLockout := entity.ErrorNotFound("lockout not found")
 
 	t.Run("success", func(t *testing.T) {
+		mockLockouts.EXPECT().GetLockout(entity.LockoutScopeAccount, "alice@example.com").Return(nil, noLockout)
 		mockRepo.EXPECT().
 			GetUserByEmail("alice@example.com").
 			Return(user, nil)
+		mockRepo.EXPECT().
+			GetRolePermissions("user").
+			Return([]entity.Permission{entity.PermissionPaymentRead}, nil)
+		mockTwoFactor.EXPECT().
+			GetTOTP("u1").
+			Return(nil, entity.ErrorNotFound("two-factor authentication not set up"))
+		var stored *entity.RefreshToken
+		mockTokenRepo.EXPECT().
+			Create(gomock.Any()).
+			DoAndReturn(func(token *entity.RefreshToken) (*entity.RefreshToken, error) {
+				stored = token
+				return token, nil
+			})
+		mockLockouts.EXPECT().ClearLockout(entity.LockoutScopeAccount, "alice@example.com").Return(noLockout)
 
 		secret := []byte("test-secret")
-		u := NewAuthUsecase(mockRepo, secret, time.Hour)
+		u := NewAuthUsecase(mockRepo, mockTokenRepo, mockTwoFactor, mockLockouts, nil, nil, jwtkey.NewHMACKeyring(secret), time.Hour, 24*time.Hour)
 
-		tokenStr, gotUser, err := u.Login("alice@example.com", password)
+		loginAt := time.Now().Truncate(time.Microsecond)
+		result, err := u.Login("alice@example.com", password, "")
 		assert.NoError(t, err)
+		tokens, gotUser := result.Tokens, result.User
+		tokenStr := tokens.AccessToken
 		assert.NotEmpty(t, tokenStr)
+		assert.NotEmpty(t, tokens.RefreshToken)
+		assert.Equal(t, "u1", stored.UserID)
+		assert.NotEmpty(t, stored.FamilyID)
+		assert.Equal(t, hashToken(tokens.RefreshToken), stored.TokenHash)
+		assert.Equal(t, stored.ExpiresAt, tokens.RefreshExpiresAt)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), tokens.AccessExpiresAt, time.Minute)
 		assert.Equal(t, user, gotUser)
+		assert.Equal(t, []entity.Permission{entity.PermissionPaymentRead}, gotUser.Permissions)
 
 		// validate token can be parsed and subject matches
 		parsed, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
@@ -57,46 +94,468 @@ func TestAuth_Login(t *testing.T) {
 		claims, ok := parsed.Claims.(jwt.MapClaims)
 		assert.True(t, ok)
 		assert.Equal(t, "u1", claims["sub"])
+		assert.NotEmpty(t, claims["jti"])
+
+		// iat keeps the microseconds a revocation in the same second is compared with
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+tokenStr)
+		tokenClaims, err := middleware.ParseToken(req, jwtkey.NewHMACKeyring(secret))
+		assert.NoError(t, err)
+		assert.False(t, tokenClaims.IssuedAt.Before(loginAt))
 	})
 
 	t.Run("Wrong Password", func(t *testing.T) {
+		mockLockouts.EXPECT().GetLockout(entity.LockoutScopeAccount, "alice@example.com").Return(nil, noLockout)
 		mockRepo.EXPECT().
 			GetUserByEmail("alice@example.com").
 			Return(user, nil)
+		mockLockouts.EXPECT().RecordFailure(entity.LockoutScopeAccount, "alice@example.com", gomock.Any(), failureWindow).Return(1, nil)
 
 		secret := []byte("test-secret")
-		u := NewAuthUsecase(mockRepo, secret, time.Hour)
+		u := NewAuthUsecase(mockRepo, mockTokenRepo, nil, mockLockouts, nil, nil, jwtkey.NewHMACKeyring(secret), time.Hour, 24*time.Hour)
 
 		// wrong password
-		_, _, err = u.Login("alice@example.com", "wrong-password")
+		_, err = u.Login("alice@example.com", "wrong-password", "")
 		assert.Error(t, err)
 		// wrapped error message contains "invalid credentials" according to usecase
 		assert.Contains(t, err.Error(), "invalid credentials")
 	})
 
+	t.Run("Disabled User", func(t *testing.T) {
+		disabled := *user
+		disabled.Status = entity.UserStatusDisabled
+		mockLockouts.EXPECT().GetLockout(entity.LockoutScopeAccount, "alice@example.com").Return(nil, noLockout)
+		mockRepo.EXPECT().
+			GetUserByEmail("alice@example.com").
+			Return(&disabled, nil)
+
+		secret := []byte("test-secret")
+		u := NewAuthUsecase(mockRepo, mockTokenRepo, nil, mockLockouts, nil, nil, jwtkey.NewHMACKeyring(secret), time.Hour, 24*time.Hour)
+
+		_, err := u.Login("alice@example.com", password, "")
+		assert.EqualError(t, err, "user disabled")
+	})
+
+	t.Run("Two Factor Challenge", func(t *testing.T) {
+		enabledAt := time.Now()
+		mockLockouts.EXPECT().GetLockout(entity.LockoutScopeAccount, "alice@example.com").Return(nil, noLockout)
+		mockRepo.EXPECT().
+			GetUserByEmail("alice@example.com").
+			Return(user, nil)
+		mockRepo.EXPECT().
+			GetRolePermissions("user").
+			Return([]entity.Permission{entity.PermissionPaymentRead}, nil)
+		mockTwoFactor.EXPECT().
+			GetTOTP("u1").
+			Return(&entity.TOTP{UserID: "u1", Secret: "SECRET", EnabledAt: &enabledAt}, nil)
+		var stored *entity.LoginChallenge
+		mockTwoFactor.EXPECT().
+			CreateChallenge(gomock.Any()).
+			DoAndReturn(func(challenge *entity.LoginChallenge) (*entity.LoginChallenge, error) {
+				stored = challenge
+				return challenge, nil
+			})
+
+		u := NewAuthUsecase(mockRepo, mockTokenRepo, mockTwoFactor, mockLockouts, nil, nil, jwtkey.NewHMACKeyring([]byte("test-secret")), time.Hour, 24*time.Hour)
+
+		result, err := u.Login("alice@example.com", password, "")
+		assert.NoError(t, err)
+		assert.Nil(t, result.Tokens)
+		assert.NotEmpty(t, result.ChallengeToken)
+		assert.Equal(t, hashToken(result.ChallengeToken), stored.TokenHash)
+		assert.WithinDuration(t, time.Now().Add(challengeTTL), result.ChallengeExpiresAt, time.Minute)
+	})
+
 	t.Run("Repo Error", func(t *testing.T) {
+		mockLockouts.EXPECT().GetLockout(entity.LockoutScopeAccount, "alice@example.com").Return(nil, noLockout)
 		mockRepo.EXPECT().
 			GetUserByEmail("alice@example.com").
 			Return(nil, errors.New("db fail"))
 
 		secret := []byte("test-secret")
-		u := NewAuthUsecase(mockRepo, secret, time.Hour)
+		u := NewAuthUsecase(mockRepo, mockTokenRepo, nil, mockLockouts, nil, nil, jwtkey.NewHMACKeyring(secret), time.Hour, 24*time.Hour)
 
-		_, _, err := u.Login("alice@example.com", "pw")
+		_, err := u.Login("alice@example.com", "pw", "")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "db fail")
 	})
 
 	t.Run("User Not Found EmptyID", func(t *testing.T) {
+		mockLockouts.EXPECT().GetLockout(entity.LockoutScopeAccount, "noone@example.com").Return(nil, noLockout)
 		mockRepo.EXPECT().
 			GetUserByEmail("noone@example.com").
 			Return(&entity.User{}, nil)
+		mockLockouts.EXPECT().RecordFailure(entity.LockoutScopeAccount, "noone@example.com", gomock.Any(), failureWindow).Return(1, nil)
 
 		secret := []byte("test-secret")
-		u := NewAuthUsecase(mockRepo, secret, time.Hour)
+		u := NewAuthUsecase(mockRepo, mockTokenRepo, nil, mockLockouts, nil, nil, jwtkey.NewHMACKeyring(secret), time.Hour, 24*time.Hour)
 
-		_, _, err := u.Login("noone@example.com", "pw")
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "user not found")
+		_, err := u.Login("noone@example.com", "pw", "")
+		// same error as a wrong password so emails cannot be enumerated
+		assert.EqualError(t, err, "invalid credentials")
+	})
+
+	t.Run("Unknown Email", func(t *testing.T) {
+		mockLockouts.EXPECT().GetLockout(entity.LockoutScopeAccount, "noone@example.com").Return(nil, noLockout)
+		mockRepo.EXPECT().
+			GetUserByEmail("noone@example.com").
+			Return(nil, entity.ErrorNotFound("user not found"))
+		mockLockouts.EXPECT().RecordFailure(entity.LockoutScopeAccount, "noone@example.com", gomock.Any(), failureWindow).Return(1, nil)
+
+		u := NewAuthUsecase(mockRepo, mockTokenRepo, nil, mockLockouts, nil, nil, jwtkey.NewHMACKeyring([]byte("test-secret")), time.Hour, 24*time.Hour)
+
+		_, err := u.Login("noone@example.com", "pw", "")
+		assert.EqualError(t, err, "invalid credentials")
+	})
+
+	t.Run("Failure Locks Account", func(t *testing.T) {
+		mockLockouts.EXPECT().GetLockout(entity.LockoutScopeAccount, "alice@example.com").Return(nil, noLockout)
+		mockLockouts.EXPECT().GetLockout(entity.LockoutScopeIP, "10.0.0.1").Return(nil, noLockout)
+		mockRepo.EXPECT().
+			GetUserByEmail(" Alice@example.com").
+			Return(user, nil)
+		mockLockouts.EXPECT().RecordFailure(entity.LockoutScopeAccount, "alice@example.com", gomock.Any(), failureWindow).Return(6, nil)
+		mockLockouts.EXPECT().RecordFailure(entity.LockoutScopeIP, "10.0.0.1", gomock.Any(), failureWindow).Return(6, nil)
+		var until time.Time
+		mockLockouts.EXPECT().
+			LockUntil(entity.LockoutScopeAccount, "alice@example.com", gomock.Any()).
+			DoAndReturn(func(scope entity.LockoutScope, key string, t time.Time) error {
+				until = t
+				return nil
+			})
+
+		u := NewAuthUsecase(mockRepo, mockTokenRepo, nil, mockLockouts, nil, nil, jwtkey.NewHMACKeyring([]byte("test-secret")), time.Hour, 24*time.Hour)
+
+		_, err := u.Login(" Alice@example.com", "wrong-password", "10.0.0.1")
+		assert.EqualError(t, err, "invalid credentials")
+		assert.WithinDuration(t, time.Now().Add(time.Minute), until, 5*time.Second)
+	})
+
+	t.Run("Locked Out", func(t *testing.T) {
+		lockedUntil := time.Now().Add(2 * time.Minute)
+		mockLockouts.EXPECT().GetLockout(entity.LockoutScopeAccount, "alice@example.com").Return(nil, noLockout)
+		mockLockouts.EXPECT().
+			GetLockout(entity.LockoutScopeIP, "10.0.0.1").
+			Return(&entity.LoginLockout{Scope: entity.LockoutScopeIP, Key: "10.0.0.1", Failures: 21, LockedUntil: &lockedUntil}, nil)
+
+		u := NewAuthUsecase(mockRepo, mockTokenRepo, nil, mockLockouts, nil, nil, jwtkey.NewHMACKeyring([]byte("test-secret")), time.Hour, 24*time.Hour)
+
+		// the password is not even checked
+		_, err := u.Login("alice@example.com", password, "10.0.0.1")
+		var appErr *entity.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, entity.ErrorCodeTooManyRequests, appErr.Code)
+		retry, ok := appErr.Details.(entity.RetryAfter)
+		assert.True(t, ok)
+		assert.InDelta(t, 120, retry.Seconds, 2)
+	})
+}
+
+func TestLockoutPolicy_Delay(t *testing.T) {
+	p := lockoutPolicy{threshold: 5, base: 30 * time.Second, max: 15 * time.Minute}
+	assert.Equal(t, time.Duration(0), p.delay(4))
+	assert.Equal(t, 30*time.Second, p.delay(5))
+	assert.Equal(t, time.Minute, p.delay(6))
+	assert.Equal(t, 8*time.Minute, p.delay(9))
+	assert.Equal(t, 15*time.Minute, p.delay(10))
+	assert.Equal(t, 15*time.Minute, p.delay(100))
+}
+
+func TestAuth_Refresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &entity.User{ID: "u1", Email: "alice@example.com", Role: "user"}
+	secret := []byte("test-secret")
+	refreshToken := "refresh-token"
+	valid := func() *entity.RefreshToken {
+		return &entity.RefreshToken{
+			ID:        "7",
+			UserID:    "u1",
+			FamilyID:  "family",
+			TokenHash: hashToken(refreshToken),
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := mock.NewMockUserRepository(ctrl)
+		mockTokenRepo := mock.NewMockRefreshTokenRepository(ctrl)
+		mockTokenRepo.EXPECT().GetByHash(hashToken(refreshToken)).Return(valid(), nil)
+		mockRepo.EXPECT().GetUserById("u1").Return(user, nil)
+		mockRepo.EXPECT().GetRolePermissions("user").Return([]entity.Permission{entity.PermissionPaymentRead}, nil)
+		var next *entity.RefreshToken
+		mockTokenRepo.EXPECT().
+			Rotate("7", gomock.Any()).
+			DoAndReturn(func(_ string, token *entity.RefreshToken) (*entity.RefreshToken, error) {
+				next = token
+				return token, nil
+			})
+
+		u := NewAuthUsecase(mockRepo, mockTokenRepo, nil, nil, nil, nil, jwtkey.NewHMACKeyring(secret), time.Hour, 24*time.Hour)
+		tokens, gotUser, err := u.Refresh(refreshToken)
+		assert.NoError(t, err)
+		assert.Equal(t, user, gotUser)
+		assert.NotEmpty(t, tokens.AccessToken)
+		assert.NotEqual(t, refreshToken, tokens.RefreshToken)
+		assert.Equal(t, "family", next.FamilyID)
+		assert.Equal(t, hashToken(tokens.RefreshToken), next.TokenHash)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		mockTokenRepo := mock.NewMockRefreshTokenRepository(ctrl)
+		mockTokenRepo.EXPECT().GetByHash(hashToken("nope")).Return(nil, entity.ErrorNotFound("refresh token not found"))
+
+		u := NewAuthUsecase(mock.NewMockUserRepository(ctrl), mockTokenRepo, nil, nil, nil, nil, jwtkey.NewHMACKeyring(secret), time.Hour, 24*time.Hour)
+		_, _, err := u.Refresh("nope")
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeUnauthorized, appErr.Code)
+	})
+
+	t.Run("reused token revokes family", func(t *testing.T) {
+		used := valid()
+		usedAt := time.Now().Add(-time.Minute)
+		used.UsedAt = &usedAt
+		mockTokenRepo := mock.NewMockRefreshTokenRepository(ctrl)
+		mockTokenRepo.EXPECT().GetByHash(hashToken(refreshToken)).Return(used, nil)
+		mockTokenRepo.EXPECT().RevokeFamily("family").Return(nil)
+
+		u := NewAuthUsecase(mock.NewMockUserRepository(ctrl), mockTokenRepo, nil, nil, nil, nil, jwtkey.NewHMACKeyring(secret), time.Hour, 24*time.Hour)
+		_, _, err := u.Refresh(refreshToken)
+		assert.EqualError(t, err, "refresh token reused")
+	})
+
+	t.Run("concurrent rotation revokes family", func(t *testing.T) {
+		mockRepo := mock.NewMockUserRepository(ctrl)
+		mockTokenRepo := mock.NewMockRefreshTokenRepository(ctrl)
+		mockTokenRepo.EXPECT().GetByHash(hashToken(refreshToken)).Return(valid(), nil)
+		mockRepo.EXPECT().GetUserById("u1").Return(user, nil)
+		mockRepo.EXPECT().GetRolePermissions("user").Return(nil, nil)
+		mockTokenRepo.EXPECT().Rotate("7", gomock.Any()).Return(nil, entity.ErrorConflict("refresh token already used"))
+		mockTokenRepo.EXPECT().RevokeFamily("family").Return(nil)
+
+		u := NewAuthUsecase(mockRepo, mockTokenRepo, nil, nil, nil, nil, jwtkey.NewHMACKeyring(secret), time.Hour, 24*time.Hour)
+		_, _, err := u.Refresh(refreshToken)
+		assert.EqualError(t, err, "refresh token reused")
+	})
+
+	t.Run("revoked token", func(t *testing.T) {
+		revoked := valid()
+		revokedAt := time.Now()
+		revoked.RevokedAt = &revokedAt
+		mockTokenRepo := mock.NewMockRefreshTokenRepository(ctrl)
+		mockTokenRepo.EXPECT().GetByHash(hashToken(refreshToken)).Return(revoked, nil)
+
+		u := NewAuthUsecase(mock.NewMockUserRepository(ctrl), mockTokenRepo, nil, nil, nil, nil, jwtkey.NewHMACKeyring(secret), time.Hour, 24*time.Hour)
+		_, _, err := u.Refresh(refreshToken)
+		assert.EqualError(t, err, "refresh token revoked")
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		expired := valid()
+		expired.ExpiresAt = time.Now().Add(-time.Second)
+		mockTokenRepo := mock.NewMockRefreshTokenRepository(ctrl)
+		mockTokenRepo.EXPECT().GetByHash(hashToken(refreshToken)).Return(expired, nil)
+
+		u := NewAuthUsecase(mock.NewMockUserRepository(ctrl), mockTokenRepo, nil, nil, nil, nil, jwtkey.NewHMACKeyring(secret), time.Hour, 24*time.Hour)
+		_, _, err := u.Refresh(refreshToken)
+		assert.EqualError(t, err, "refresh token expired")
+	})
+}
+
+func TestAuth_Logout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secret := []byte("test-secret")
+	claims := &entity.TokenClaims{ID: "jti-1", Subject: "u1", ExpiresAt: time.Now().Add(time.Hour)}
+	ctx := context.WithValue(context.Background(), config.ContextTokenClaims, claims)
+
+	t.Run("revokes access token and refresh family", func(t *testing.T) {
+		mockTokenRepo := mock.NewMockRefreshTokenRepository(ctrl)
+		mockRevocations := azm.NewMockRevocationList(ctrl)
+		mockRevocations.EXPECT().RevokeToken(claims).Return(nil)
+		mockTokenRepo.EXPECT().GetByHash(hashToken("refresh")).Return(&entity.RefreshToken{ID: "1", UserID: "u1", FamilyID: "family"}, nil)
+		mockTokenRepo.EXPECT().RevokeFamily("family").Return(nil)
+
+		u := NewAuthUsecase(mock.NewMockUserRepository(ctrl), mockTokenRepo, nil, nil, mockRevocations, nil, jwtkey.NewHMACKeyring(secret), time.Hour, 24*time.Hour)
+		assert.NoError(t, u.Logout(ctx, "refresh"))
+	})
+
+	t.Run("ignores refresh token of another user", func(t *testing.T) {
+		mockTokenRepo := mock.NewMockRefreshTokenRepository(ctrl)
+		mockRevocations := azm.NewMockRevocationList(ctrl)
+		mockRevocations.EXPECT().RevokeToken(claims).Return(nil)
+		mockTokenRepo.EXPECT().GetByHash(hashToken("refresh")).Return(&entity.RefreshToken{ID: "1", UserID: "u2", FamilyID: "family"}, nil)
+
+		u := NewAuthUsecase(mock.NewMockUserRepository(ctrl), mockTokenRepo, nil, nil, mockRevocations, nil, jwtkey.NewHMACKeyring(secret), time.Hour, 24*time.Hour)
+		assert.NoError(t, u.Logout(ctx, "refresh"))
+	})
+
+	t.Run("without token", func(t *testing.T) {
+		u := NewAuthUsecase(mock.NewMockUserRepository(ctrl), mock.NewMockRefreshTokenRepository(ctrl), nil, nil, azm.NewMockRevocationList(ctrl), nil, jwtkey.NewHMACKeyring(secret), time.Hour, 24*time.Hour)
+		assert.EqualError(t, u.Logout(context.Background(), ""), "missing token")
+	})
+}
+
+func TestAuth_RevokeSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secret := []byte("test-secret")
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := mock.NewMockUserRepository(ctrl)
+		mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+		mockRevocations := azm.NewMockRevocationList(ctrl)
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionUserManage).Return(&entity.User{ID: "1"}, nil)
+		mockRepo.EXPECT().GetUserById("2").Return(&entity.User{ID: "2"}, nil)
+		mockRevocations.EXPECT().RevokeUser("2").Return(nil)
+
+		u := NewAuthUsecase(mockRepo, mock.NewMockRefreshTokenRepository(ctrl), nil, nil, mockRevocations, mockAuthorizer, jwtkey.NewHMACKeyring(secret), time.Hour, 24*time.Hour)
+		assert.NoError(t, u.RevokeSessions(ctx, "2"))
+	})
+
+	t.Run("missing permission", func(t *testing.T) {
+		mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionUserManage).Return(nil, entity.ErrorForbidden("missing permission user:manage"))
+
+		u := NewAuthUsecase(mock.NewMockUserRepository(ctrl), mock.NewMockRefreshTokenRepository(ctrl), nil, nil, azm.NewMockRevocationList(ctrl), mockAuthorizer, jwtkey.NewHMACKeyring(secret), time.Hour, 24*time.Hour)
+		assert.EqualError(t, u.RevokeSessions(ctx, "2"), "missing permission user:manage")
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		mockRepo := mock.NewMockUserRepository(ctrl)
+		mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionUserManage).Return(&entity.User{ID: "1"}, nil)
+		mockRepo.EXPECT().GetUserById("9").Return(nil, entity.ErrorNotFound("user not found"))
+
+		u := NewAuthUsecase(mockRepo, mock.NewMockRefreshTokenRepository(ctrl), nil, nil, azm.NewMockRevocationList(ctrl), mockAuthorizer, jwtkey.NewHMACKeyring(secret), time.Hour, 24*time.Hour)
+		assert.EqualError(t, u.RevokeSessions(ctx, "9"), "user not found")
+	})
+}
+
+func TestAuth_VerifyLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secret := []byte("test-secret")
+	totpSecret, err := totp.GenerateSecret()
+	assert.NoError(t, err)
+	enabledAt := time.Now()
+	userTOTP := &entity.TOTP{UserID: "u1", Secret: totpSecret, EnabledAt: &enabledAt}
+	user := &entity.User{ID: "u1", Email: "alice@example.com", Role: "user", Status: entity.UserStatusActive}
+	challenge := &entity.LoginChallenge{ID: "c1", UserID: "u1", TokenHash: hashToken("challenge"), ExpiresAt: time.Now().Add(time.Minute)}
+	noLockout := entity.ErrorNotFound("lockout not found")
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := mock.NewMockUserRepository(ctrl)
+		mockTokenRepo := mock.NewMockRefreshTokenRepository(ctrl)
+		mockTwoFactor := mock.NewMockTwoFactorRepository(ctrl)
+		mockLockouts := mock.NewMockLockoutRepository(ctrl)
+
+		code, err := totp.Code(totpSecret, totp.Step(time.Now()))
+		assert.NoError(t, err)
+		mockTwoFactor.EXPECT().GetChallengeByHash(hashToken("challenge")).Return(challenge, nil)
+		mockTwoFactor.EXPECT().AttemptChallenge("c1", maxChallengeAttempts).Return(nil)
+		mockRepo.EXPECT().GetUserById("u1").Return(user, nil)
+		mockLockouts.EXPECT().GetLockout(entity.LockoutScopeAccount, "alice@example.com").Return(nil, noLockout)
+		mockTwoFactor.EXPECT().GetTOTP("u1").Return(userTOTP, nil)
+		mockTwoFactor.EXPECT().UseTOTPStep("u1", gomock.Any()).Return(nil)
+		mockTwoFactor.EXPECT().UseChallenge("c1").Return(nil)
+		mockRepo.EXPECT().GetRolePermissions("user").Return([]entity.Permission{entity.PermissionPaymentRead}, nil)
+		mockLockouts.EXPECT().ClearLockout(entity.LockoutScopeAccount, "alice@example.com").Return(nil)
+		mockTokenRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(token *entity.RefreshToken) (*entity.RefreshToken, error) {
+			return token, nil
+		})
+
+		u := NewAuthUsecase(mockRepo, mockTokenRepo, mockTwoFactor, mockLockouts, nil, nil, jwtkey.NewHMACKeyring(secret), time.Hour, 24*time.Hour)
+		tokens, gotUser, err := u.VerifyLogin("challenge", code, "")
+		assert.NoError(t, err)
+		assert.NotEmpty(t, tokens.AccessToken)
+		assert.NotEmpty(t, tokens.RefreshToken)
+		assert.Equal(t, "u1", gotUser.ID)
+	})
+
+	t.Run("Recovery Code", func(t *testing.T) {
+		mockRepo := mock.NewMockUserRepository(ctrl)
+		mockTokenRepo := mock.NewMockRefreshTokenRepository(ctrl)
+		mockTwoFactor := mock.NewMockTwoFactorRepository(ctrl)
+		mockLockouts := mock.NewMockLockoutRepository(ctrl)
+
+		mockTwoFactor.EXPECT().GetChallengeByHash(hashToken("challenge")).Return(challenge, nil)
+		mockTwoFactor.EXPECT().AttemptChallenge("c1", maxChallengeAttempts).Return(nil)
+		mockRepo.EXPECT().GetUserById("u1").Return(user, nil)
+		mockLockouts.EXPECT().GetLockout(entity.LockoutScopeAccount, "alice@example.com").Return(nil, noLockout)
+		mockTwoFactor.EXPECT().GetTOTP("u1").Return(userTOTP, nil)
+		mockTwoFactor.EXPECT().UseRecoveryCode("u1", hashToken("abcdefghijklmnop")).Return(nil)
+		mockTwoFactor.EXPECT().UseChallenge("c1").Return(nil)
+		mockRepo.EXPECT().GetRolePermissions("user").Return(nil, nil)
+		mockLockouts.EXPECT().ClearLockout(entity.LockoutScopeAccount, "alice@example.com").Return(nil)
+		mockTokenRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(token *entity.RefreshToken) (*entity.RefreshToken, error) {
+			return token, nil
+		})
+
+		u := NewAuthUsecase(mockRepo, mockTokenRepo, mockTwoFactor, mockLockouts, nil, nil, jwtkey.NewHMACKeyring(secret), time.Hour, 24*time.Hour)
+		_, _, err := u.VerifyLogin("challenge", "ABCDEFGH-ijklmnop", "")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Wrong Code", func(t *testing.T) {
+		mockRepo := mock.NewMockUserRepository(ctrl)
+		mockTwoFactor := mock.NewMockTwoFactorRepository(ctrl)
+		mockLockouts := mock.NewMockLockoutRepository(ctrl)
+
+		mockTwoFactor.EXPECT().GetChallengeByHash(hashToken("challenge")).Return(challenge, nil)
+		mockTwoFactor.EXPECT().AttemptChallenge("c1", maxChallengeAttempts).Return(nil)
+		mockRepo.EXPECT().GetUserById("u1").Return(user, nil)
+		mockLockouts.EXPECT().GetLockout(entity.LockoutScopeAccount, "alice@example.com").Return(nil, noLockout)
+		mockLockouts.EXPECT().GetLockout(entity.LockoutScopeIP, "10.0.0.1").Return(nil, noLockout)
+		mockTwoFactor.EXPECT().GetTOTP("u1").Return(userTOTP, nil)
+
+		// the code of a period well outside the accepted skew
+		code, err := totp.Code(totpSecret, totp.Step(time.Now())+10)
+		assert.NoError(t, err)
+		mockLockouts.EXPECT().RecordFailure(entity.LockoutScopeAccount, "alice@example.com", gomock.Any(), failureWindow).Return(1, nil)
+		mockLockouts.EXPECT().RecordFailure(entity.LockoutScopeIP, "10.0.0.1", gomock.Any(), failureWindow).Return(1, nil)
+
+		u := NewAuthUsecase(mockRepo, mock.NewMockRefreshTokenRepository(ctrl), mockTwoFactor, mockLockouts, nil, nil, jwtkey.NewHMACKeyring(secret), time.Hour, 24*time.Hour)
+		_, _, err = u.VerifyLogin("challenge", code, "10.0.0.1")
+		assert.EqualError(t, err, "invalid two-factor code")
+		var appErr *entity.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, entity.ErrorCodeUnauthorized, appErr.Code)
+	})
+
+	t.Run("Too Many Attempts", func(t *testing.T) {
+		mockTwoFactor := mock.NewMockTwoFactorRepository(ctrl)
+		mockTwoFactor.EXPECT().GetChallengeByHash(hashToken("challenge")).Return(challenge, nil)
+		mockTwoFactor.EXPECT().AttemptChallenge("c1", maxChallengeAttempts).Return(entity.ErrorConflict("login challenge has no attempts left"))
+
+		u := NewAuthUsecase(mock.NewMockUserRepository(ctrl), mock.NewMockRefreshTokenRepository(ctrl), mockTwoFactor, nil, nil, nil, jwtkey.NewHMACKeyring(secret), time.Hour, 24*time.Hour)
+		_, _, err := u.VerifyLogin("challenge", "123456", "")
+		assert.EqualError(t, err, "too many attempts, log in again")
+	})
+
+	t.Run("Expired Challenge", func(t *testing.T) {
+		mockTwoFactor := mock.NewMockTwoFactorRepository(ctrl)
+		expired := *challenge
+		expired.ExpiresAt = time.Now().Add(-time.Second)
+		mockTwoFactor.EXPECT().GetChallengeByHash(hashToken("challenge")).Return(&expired, nil)
+
+		u := NewAuthUsecase(mock.NewMockUserRepository(ctrl), mock.NewMockRefreshTokenRepository(ctrl), mockTwoFactor, nil, nil, nil, jwtkey.NewHMACKeyring(secret), time.Hour, 24*time.Hour)
+		_, _, err := u.VerifyLogin("challenge", "123456", "")
+		assert.EqualError(t, err, "challenge token expired")
+	})
+
+	t.Run("Unknown Challenge", func(t *testing.T) {
+		mockTwoFactor := mock.NewMockTwoFactorRepository(ctrl)
+		mockTwoFactor.EXPECT().GetChallengeByHash(hashToken("other")).Return(nil, entity.ErrorNotFound("login challenge not found"))
+
+		u := NewAuthUsecase(mock.NewMockUserRepository(ctrl), mock.NewMockRefreshTokenRepository(ctrl), mockTwoFactor, nil, nil, nil, jwtkey.NewHMACKeyring(secret), time.Hour, 24*time.Hour)
+		_, _, err := u.VerifyLogin("other", "123456", "")
+		assert.EqualError(t, err, "invalid challenge token")
 	})
 }
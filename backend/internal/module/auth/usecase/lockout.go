@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// lockoutPolicy locks a key for base once it reaches threshold failed logins, each further
+// failure doubles the lock up to max.
+type lockoutPolicy struct {
+	threshold int
+	base      time.Duration
+	max       time.Duration
+}
+
+func (p lockoutPolicy) delay(failures int) time.Duration {
+	if failures < p.threshold {
+		return 0
+	}
+	shift := failures - p.threshold
+	if shift >= 32 {
+		return p.max
+	}
+	return min(p.base<<shift, p.max)
+}
+
+// An IP is allowed more failures than an account since many users can share one.
+var lockoutPolicies = map[entity.LockoutScope]lockoutPolicy{
+	entity.LockoutScopeAccount: {threshold: 5, base: 30 * time.Second, max: 15 * time.Minute},
+	entity.LockoutScopeIP:      {threshold: 20, base: 30 * time.Second, max: 15 * time.Minute},
+}
+
+// failureWindow is how long failed logins are remembered after the last one.
+const failureWindow = 24 * time.Hour
+
+// dummyHash is compared against when the email is unknown, so the response takes as long
+// as for a wrong password and does not tell whether the account exists.
+var dummyHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+	return hash
+})
+
+type loginKey struct {
+	scope entity.LockoutScope
+	key   string
+}
+
+// loginKeys returns the keys failed logins are counted by. Emails are counted whether or
+// not they are registered, so a lockout does not reveal an account either.
+func loginKeys(email, ip string) []loginKey {
+	keys := []loginKey{{entity.LockoutScopeAccount, normalizeEmail(email)}}
+	if ip != "" {
+		keys = append(keys, loginKey{entity.LockoutScopeIP, ip})
+	}
+	return keys
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// checkLockout refuses a login while any of keys is locked.
+func (a *Auth) checkLockout(keys []loginKey, now time.Time) error {
+	var wait time.Duration
+	for _, k := range keys {
+		lockout, err := a.lockouts.GetLockout(k.scope, k.key)
+		if err != nil {
+			if isNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if lockout.LockedUntil != nil && now.Before(*lockout.LockedUntil) {
+			wait = max(wait, lockout.LockedUntil.Sub(now))
+		}
+	}
+	if wait > 0 {
+		return entity.ErrorTooManyRequests("too many failed logins, try again later", wait)
+	}
+	return nil
+}
+
+// recordFailure counts a failed login against keys and locks the ones over their policy.
+func (a *Auth) recordFailure(keys []loginKey, now time.Time) error {
+	for _, k := range keys {
+		failures, err := a.lockouts.RecordFailure(k.scope, k.key, now, failureWindow)
+		if err != nil {
+			return err
+		}
+		if d := lockoutPolicies[k.scope].delay(failures); d > 0 {
+			if err := a.lockouts.LockUntil(k.scope, k.key, now.Add(d)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// clearFailures forgets the failed logins of an account after a complete login. The IP
+// keeps its count, a valid account must not let an IP guess others' passwords.
+func (a *Auth) clearFailures(email string) error {
+	err := a.lockouts.ClearLockout(entity.LockoutScopeAccount, normalizeEmail(email))
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -5,6 +5,7 @@
 package mock
 
 import (
+	context "context"
 	reflect "reflect"
 
 	entity "github.com/fajrinajiseno/mygolangapp/internal/entity"
@@ -35,17 +36,76 @@ func (m *MockAuthUsecase) EXPECT() *MockAuthUsecaseMockRecorder {
 }
 
 // Login mocks base method.
-func (m *MockAuthUsecase) Login(email, password string) (string, *entity.User, error) {
+func (m *MockAuthUsecase) Login(email, password, ip string) (*entity.LoginResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Login", email, password)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "Login", email, password, ip)
+	ret0, _ := ret[0].(*entity.LoginResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockAuthUsecaseMockRecorder) Login(email, password, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockAuthUsecase)(nil).Login), email, password, ip)
+}
+
+// Logout mocks base method.
+func (m *MockAuthUsecase) Logout(ctx context.Context, refreshToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockAuthUsecaseMockRecorder) Logout(ctx, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthUsecase)(nil).Logout), ctx, refreshToken)
+}
+
+// Refresh mocks base method.
+func (m *MockAuthUsecase) Refresh(refreshToken string) (*entity.AuthTokens, *entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", refreshToken)
+	ret0, _ := ret[0].(*entity.AuthTokens)
 	ret1, _ := ret[1].(*entity.User)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// Login indicates an expected call of Login.
-func (mr *MockAuthUsecaseMockRecorder) Login(email, password interface{}) *gomock.Call {
+// Refresh indicates an expected call of Refresh.
+func (mr *MockAuthUsecaseMockRecorder) Refresh(refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockAuthUsecase)(nil).Refresh), refreshToken)
+}
+
+// RevokeSessions mocks base method.
+func (m *MockAuthUsecase) RevokeSessions(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSessions", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSessions indicates an expected call of RevokeSessions.
+func (mr *MockAuthUsecaseMockRecorder) RevokeSessions(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessions", reflect.TypeOf((*MockAuthUsecase)(nil).RevokeSessions), ctx, userID)
+}
+
+// VerifyLogin mocks base method.
+func (m *MockAuthUsecase) VerifyLogin(challengeToken, code, ip string) (*entity.AuthTokens, *entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyLogin", challengeToken, code, ip)
+	ret0, _ := ret[0].(*entity.AuthTokens)
+	ret1, _ := ret[1].(*entity.User)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// VerifyLogin indicates an expected call of VerifyLogin.
+func (mr *MockAuthUsecaseMockRecorder) VerifyLogin(challengeToken, code, ip interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockAuthUsecase)(nil).Login), email, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyLogin", reflect.TypeOf((*MockAuthUsecase)(nil).VerifyLogin), challengeToken, code, ip)
 }
@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: two_factor.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/fajrinajiseno/mygolangapp/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTwoFactorUsecase is a mock of TwoFactorUsecase interface.
+type MockTwoFactorUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockTwoFactorUsecaseMockRecorder
+}
+
+// MockTwoFactorUsecaseMockRecorder is the mock recorder for MockTwoFactorUsecase.
+type MockTwoFactorUsecaseMockRecorder struct {
+	mock *MockTwoFactorUsecase
+}
+
+// NewMockTwoFactorUsecase creates a new mock instance.
+func NewMockTwoFactorUsecase(ctrl *gomock.Controller) *MockTwoFactorUsecase {
+	mock := &MockTwoFactorUsecase{ctrl: ctrl}
+	mock.recorder = &MockTwoFactorUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTwoFactorUsecase) EXPECT() *MockTwoFactorUsecaseMockRecorder {
+	return m.recorder
+}
+
+// DisableTOTP mocks base method.
+func (m *MockTwoFactorUsecase) DisableTOTP(ctx context.Context, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTOTP", ctx, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTOTP indicates an expected call of DisableTOTP.
+func (mr *MockTwoFactorUsecaseMockRecorder) DisableTOTP(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTOTP", reflect.TypeOf((*MockTwoFactorUsecase)(nil).DisableTOTP), ctx, code)
+}
+
+// EnableTOTP mocks base method.
+func (m *MockTwoFactorUsecase) EnableTOTP(ctx context.Context, code string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableTOTP", ctx, code)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableTOTP indicates an expected call of EnableTOTP.
+func (mr *MockTwoFactorUsecaseMockRecorder) EnableTOTP(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTOTP", reflect.TypeOf((*MockTwoFactorUsecase)(nil).EnableTOTP), ctx, code)
+}
+
+// ResetTOTP mocks base method.
+func (m *MockTwoFactorUsecase) ResetTOTP(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetTOTP", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetTOTP indicates an expected call of ResetTOTP.
+func (mr *MockTwoFactorUsecaseMockRecorder) ResetTOTP(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetTOTP", reflect.TypeOf((*MockTwoFactorUsecase)(nil).ResetTOTP), ctx, userID)
+}
+
+// SetupTOTP mocks base method.
+func (m *MockTwoFactorUsecase) SetupTOTP(ctx context.Context) (*entity.TOTPEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetupTOTP", ctx)
+	ret0, _ := ret[0].(*entity.TOTPEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetupTOTP indicates an expected call of SetupTOTP.
+func (mr *MockTwoFactorUsecaseMockRecorder) SetupTOTP(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetupTOTP", reflect.TypeOf((*MockTwoFactorUsecase)(nil).SetupTOTP), ctx)
+}
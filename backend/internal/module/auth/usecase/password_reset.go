@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/authz"
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/fajrinajiseno/mygolangapp/internal/mail"
+	"github.com/fajrinajiseno/mygolangapp/internal/module/auth/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+//go:generate mockgen -source password_reset.go -destination mock/password_reset_mock.go -package=mock
+type PasswordResetUsecase interface {
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token string, password string) error
+}
+
+type PasswordReset struct {
+	repo        repository.UserRepository
+	resetRepo   repository.PasswordResetRepository
+	revocations authz.RevocationList
+	mailer      mail.Mailer
+	ttl         time.Duration
+	resetURL    string
+	// pending counts the links still being issued in the background
+	pending sync.WaitGroup
+}
+
+// NewPasswordResetUsecase mails links to resetURL with the reset token in the token query
+// parameter, the page is expected to post it to the confirm endpoint.
+func NewPasswordResetUsecase(repo repository.UserRepository, resetRepo repository.PasswordResetRepository,
+	revocations authz.RevocationList, mailer mail.Mailer, ttl time.Duration, resetURL string) *PasswordReset {
+	return &PasswordReset{
+		repo:        repo,
+		resetRepo:   resetRepo,
+		revocations: revocations,
+		mailer:      mailer,
+		ttl:         ttl,
+		resetURL:    resetURL,
+	}
+}
+
+const passwordResetTokenBytes = 32
+
+// passwordResetSendTimeout bounds the background issuing of a link, so a stuck mail server
+// cannot hold up the shutdown waiting for it.
+const passwordResetSendTimeout = 30 * time.Second
+
+// RequestPasswordReset mails a reset link to an active user. The outcome does not depend on
+// whether the email is registered, so the endpoint cannot be used to discover accounts: the
+// link is stored and mailed in the background, keeping a registered email as fast as an
+// unknown one, and a failure is only logged.
+func (p *PasswordReset) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := p.repo.GetUserByEmail(strings.TrimSpace(email))
+	if err != nil {
+		var appErr *entity.AppError
+		if errors.As(err, &appErr) && appErr.Code == entity.ErrorCodeNotFound {
+			return nil
+		}
+		return err
+	}
+	if user.Status == entity.UserStatusDisabled {
+		return nil
+	}
+
+	p.pending.Add(1)
+	go func() {
+		defer p.pending.Done()
+		// the request is answered before the mail is sent
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), passwordResetSendTimeout)
+		defer cancel()
+		if err := p.sendResetLink(ctx, user); err != nil {
+			log.Printf("password reset for user %s: %v", user.ID, err)
+		}
+	}()
+	return nil
+}
+
+// Wait blocks until the reset links requested so far were mailed or failed.
+func (p *PasswordReset) Wait() {
+	p.pending.Wait()
+}
+
+func (p *PasswordReset) sendResetLink(ctx context.Context, user *entity.User) error {
+	token, err := randomToken(passwordResetTokenBytes)
+	if err != nil {
+		return err
+	}
+	link, err := url.Parse(p.resetURL)
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "invalid password reset url")
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	stored, err := p.resetRepo.Create(&entity.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(p.ttl).UTC(),
+	})
+	if err != nil {
+		return err
+	}
+	msg := mail.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("A password reset was requested for your account.\n\n"+
+			"Open the link below before %s to choose a new password:\n%s\n\n"+
+			"If you did not request it you can ignore this email.\n",
+			stored.ExpiresAt.Format(time.RFC1123), link.String()),
+	}
+	return p.mailer.Send(ctx, msg)
+}
+
+// ResetPassword sets a new password with a reset token. Every token is single use and the
+// sessions of the user are revoked, so whoever knew the old password is logged out.
+func (p *PasswordReset) ResetPassword(ctx context.Context, token string, password string) error {
+	if err := entity.ValidatePassword(password); err != nil {
+		return err
+	}
+	stored, err := p.resetRepo.GetByHash(hashToken(token))
+	if err != nil {
+		var appErr *entity.AppError
+		if errors.As(err, &appErr) && appErr.Code == entity.ErrorCodeNotFound {
+			return entity.ErrorUnauthorized("invalid password reset token")
+		}
+		return err
+	}
+	if stored.UsedAt != nil {
+		return entity.ErrorUnauthorized("password reset token already used")
+	}
+	if !time.Now().Before(stored.ExpiresAt) {
+		return entity.ErrorUnauthorized("password reset token expired")
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "internal error")
+	}
+	if err := p.resetRepo.Consume(stored.ID, stored.UserID, string(hash)); err != nil {
+		var appErr *entity.AppError
+		if errors.As(err, &appErr) && appErr.Code == entity.ErrorCodeConflict {
+			return entity.ErrorUnauthorized("password reset token already used")
+		}
+		return err
+	}
+	return p.revocations.RevokeUser(stored.UserID)
+}
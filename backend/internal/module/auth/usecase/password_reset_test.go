@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	azm "github.com/fajrinajiseno/mygolangapp/internal/authz/mock"
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/fajrinajiseno/mygolangapp/internal/mail"
+	mailm "github.com/fajrinajiseno/mygolangapp/internal/mail/mock"
+	"github.com/fajrinajiseno/mygolangapp/internal/module/auth/repository/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const resetURL = "http://localhost:3000/reset-password"
+
+func TestPasswordReset_RequestPasswordReset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	user := &entity.User{ID: "u1", Email: "alice@example.com", Status: entity.UserStatusActive}
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := mock.NewMockUserRepository(ctrl)
+		mockResetRepo := mock.NewMockPasswordResetRepository(ctrl)
+		mockMailer := mailm.NewMockMailer(ctrl)
+
+		mockRepo.EXPECT().GetUserByEmail("alice@example.com").Return(user, nil)
+		var stored *entity.PasswordResetToken
+		mockResetRepo.EXPECT().
+			Create(gomock.Any()).
+			DoAndReturn(func(token *entity.PasswordResetToken) (*entity.PasswordResetToken, error) {
+				stored = token
+				return token, nil
+			})
+		var sent mail.Message
+		mockMailer.EXPECT().
+			Send(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, msg mail.Message) error {
+				sent = msg
+				return nil
+			})
+
+		u := NewPasswordResetUsecase(mockRepo, mockResetRepo, nil, mockMailer, 30*time.Minute, resetURL)
+		err := u.RequestPasswordReset(ctx, " alice@example.com ")
+		assert.NoError(t, err)
+		u.Wait()
+
+		assert.Equal(t, "alice@example.com", sent.To)
+		start := strings.Index(sent.Body, resetURL)
+		assert.GreaterOrEqual(t, start, 0)
+		link, err := url.Parse(strings.Fields(sent.Body[start:])[0])
+		assert.NoError(t, err)
+		token := link.Query().Get("token")
+		assert.NotEmpty(t, token)
+		assert.Equal(t, "u1", stored.UserID)
+		assert.Equal(t, hashToken(token), stored.TokenHash)
+		assert.WithinDuration(t, time.Now().Add(30*time.Minute), stored.ExpiresAt, time.Minute)
+	})
+
+	t.Run("Unknown Email", func(t *testing.T) {
+		mockRepo := mock.NewMockUserRepository(ctrl)
+		mockRepo.EXPECT().GetUserByEmail("bob@example.com").Return(nil, entity.ErrorNotFound("user not found"))
+
+		u := NewPasswordResetUsecase(mockRepo, mock.NewMockPasswordResetRepository(ctrl), nil, mailm.NewMockMailer(ctrl), 30*time.Minute, resetURL)
+		assert.NoError(t, u.RequestPasswordReset(ctx, "bob@example.com"))
+	})
+
+	t.Run("Disabled User", func(t *testing.T) {
+		mockRepo := mock.NewMockUserRepository(ctrl)
+		disabled := *user
+		disabled.Status = entity.UserStatusDisabled
+		mockRepo.EXPECT().GetUserByEmail("alice@example.com").Return(&disabled, nil)
+
+		u := NewPasswordResetUsecase(mockRepo, mock.NewMockPasswordResetRepository(ctrl), nil, mailm.NewMockMailer(ctrl), 30*time.Minute, resetURL)
+		assert.NoError(t, u.RequestPasswordReset(ctx, "alice@example.com"))
+	})
+
+	t.Run("Mail Error", func(t *testing.T) {
+		mockRepo := mock.NewMockUserRepository(ctrl)
+		mockResetRepo := mock.NewMockPasswordResetRepository(ctrl)
+		mockMailer := mailm.NewMockMailer(ctrl)
+
+		mockRepo.EXPECT().GetUserByEmail("alice@example.com").Return(user, nil)
+		mockResetRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(token *entity.PasswordResetToken) (*entity.PasswordResetToken, error) {
+			return token, nil
+		})
+		mockMailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(errors.New("connection refused"))
+
+		u := NewPasswordResetUsecase(mockRepo, mockResetRepo, nil, mockMailer, 30*time.Minute, resetURL)
+		assert.NoError(t, u.RequestPasswordReset(ctx, "alice@example.com"))
+		u.Wait()
+	})
+
+	t.Run("Registered Email Answers Before Mailing", func(t *testing.T) {
+		mockRepo := mock.NewMockUserRepository(ctrl)
+		mockResetRepo := mock.NewMockPasswordResetRepository(ctrl)
+		mockMailer := mailm.NewMockMailer(ctrl)
+
+		mockRepo.EXPECT().GetUserByEmail("alice@example.com").Return(user, nil)
+		mockRepo.EXPECT().GetUserByEmail("bob@example.com").Return(nil, entity.ErrorNotFound("user not found"))
+		release := make(chan struct{})
+		mockResetRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(token *entity.PasswordResetToken) (*entity.PasswordResetToken, error) {
+			<-release
+			return token, nil
+		})
+		// a slow SMTP server
+		mockMailer.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, mail.Message) error {
+			<-release
+			return errors.New("connection refused")
+		})
+
+		u := NewPasswordResetUsecase(mockRepo, mockResetRepo, nil, mockMailer, 30*time.Minute, resetURL)
+		for _, email := range []string{"alice@example.com", "bob@example.com"} {
+			done := make(chan error, 1)
+			go func() { done <- u.RequestPasswordReset(ctx, email) }()
+			select {
+			case err := <-done:
+				assert.NoError(t, err, email)
+			case <-time.After(time.Second):
+				t.Fatalf("request for %s waited for the mail", email)
+			}
+		}
+		close(release)
+		u.Wait()
+	})
+}
+
+func TestPasswordReset_ResetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	valid := &entity.PasswordResetToken{ID: "1", UserID: "u1", TokenHash: hashToken("token"), ExpiresAt: time.Now().Add(time.Hour)}
+
+	t.Run("success", func(t *testing.T) {
+		mockResetRepo := mock.NewMockPasswordResetRepository(ctrl)
+		mockRevocations := azm.NewMockRevocationList(ctrl)
+
+		mockResetRepo.EXPECT().GetByHash(hashToken("token")).Return(valid, nil)
+		var newHash string
+		mockResetRepo.EXPECT().
+			Consume("1", "u1", gomock.Any()).
+			DoAndReturn(func(_, _, passwordHash string) error {
+				newHash = passwordHash
+				return nil
+			})
+		mockRevocations.EXPECT().RevokeUser("u1").Return(nil)
+
+		u := NewPasswordResetUsecase(mock.NewMockUserRepository(ctrl), mockResetRepo, mockRevocations, mailm.NewMockMailer(ctrl), time.Hour, resetURL)
+		assert.NoError(t, u.ResetPassword(ctx, "token", "new-password"))
+		assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(newHash), []byte("new-password")))
+	})
+
+	t.Run("Short Password", func(t *testing.T) {
+		u := NewPasswordResetUsecase(mock.NewMockUserRepository(ctrl), mock.NewMockPasswordResetRepository(ctrl), nil, mailm.NewMockMailer(ctrl), time.Hour, resetURL)
+		err := u.ResetPassword(ctx, "token", "short")
+		assert.EqualError(t, err, "password must be at least 8 characters")
+	})
+
+	t.Run("Unknown Token", func(t *testing.T) {
+		mockResetRepo := mock.NewMockPasswordResetRepository(ctrl)
+		mockResetRepo.EXPECT().GetByHash(hashToken("other")).Return(nil, entity.ErrorNotFound("password reset token not found"))
+
+		u := NewPasswordResetUsecase(mock.NewMockUserRepository(ctrl), mockResetRepo, nil, mailm.NewMockMailer(ctrl), time.Hour, resetURL)
+		err := u.ResetPassword(ctx, "other", "new-password")
+		assert.EqualError(t, err, "invalid password reset token")
+	})
+
+	t.Run("Expired Token", func(t *testing.T) {
+		mockResetRepo := mock.NewMockPasswordResetRepository(ctrl)
+		expired := *valid
+		expired.ExpiresAt = time.Now().Add(-time.Minute)
+		mockResetRepo.EXPECT().GetByHash(hashToken("token")).Return(&expired, nil)
+
+		u := NewPasswordResetUsecase(mock.NewMockUserRepository(ctrl), mockResetRepo, nil, mailm.NewMockMailer(ctrl), time.Hour, resetURL)
+		err := u.ResetPassword(ctx, "token", "new-password")
+		assert.EqualError(t, err, "password reset token expired")
+	})
+
+	t.Run("Used Concurrently", func(t *testing.T) {
+		mockResetRepo := mock.NewMockPasswordResetRepository(ctrl)
+		mockResetRepo.EXPECT().GetByHash(hashToken("token")).Return(valid, nil)
+		mockResetRepo.EXPECT().Consume("1", "u1", gomock.Any()).Return(entity.ErrorConflict("password reset token already used"))
+
+		u := NewPasswordResetUsecase(mock.NewMockUserRepository(ctrl), mockResetRepo, nil, mailm.NewMockMailer(ctrl), time.Hour, resetURL)
+		err := u.ResetPassword(ctx, "token", "new-password")
+		assert.EqualError(t, err, "password reset token already used")
+	})
+}
@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/authz"
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/fajrinajiseno/mygolangapp/internal/module/auth/repository"
+	"github.com/fajrinajiseno/mygolangapp/internal/totp"
+)
+
+//go:generate mockgen -source two_factor.go -destination mock/two_factor_mock.go -package=mock
+type TwoFactorUsecase interface {
+	SetupTOTP(ctx context.Context) (*entity.TOTPEnrollment, error)
+	EnableTOTP(ctx context.Context, code string) ([]string, error)
+	DisableTOTP(ctx context.Context, code string) error
+	ResetTOTP(ctx context.Context, userID string) error
+}
+
+type TwoFactor struct {
+	repo       repository.TwoFactorRepository
+	authorizer authz.Authorizer
+	issuer     string
+}
+
+// NewTwoFactorUsecase names the accounts added to authenticator apps after issuer.
+func NewTwoFactorUsecase(repo repository.TwoFactorRepository, az authz.Authorizer, issuer string) *TwoFactor {
+	return &TwoFactor{repo: repo, authorizer: az, issuer: issuer}
+}
+
+const (
+	// totpSkew is how many periods a code may be early or late, for clock drift
+	totpSkew          = 1
+	recoveryCodeCount = 10
+	recoveryCodeBytes = 10
+)
+
+// SetupTOTP starts the enrollment of the signed in user with a new secret. The secret stays
+// pending, and a repeated setup replaces it, until it is confirmed with EnableTOTP.
+func (f *TwoFactor) SetupTOTP(ctx context.Context) (*entity.TOTPEnrollment, error) {
+	user, err := f.authorizer.Authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := f.repo.SaveTOTPSecret(user.ID, secret); err != nil {
+		return nil, err
+	}
+	return &entity.TOTPEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(f.issuer, user.Email, secret),
+	}, nil
+}
+
+// EnableTOTP enables the pending secret of the signed in user once code proves their app
+// generates the same codes, and returns recovery codes. The recovery codes are only stored
+// hashed, this is the only time they can be shown.
+func (f *TwoFactor) EnableTOTP(ctx context.Context, code string) ([]string, error) {
+	user, err := f.authorizer.Authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t, err := f.repo.GetTOTP(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if t.Enabled() {
+		return nil, entity.ErrorConflict("two-factor authentication already enabled")
+	}
+	step, ok := totp.Verify(t.Secret, strings.TrimSpace(code), time.Now(), totpSkew)
+	if !ok {
+		return nil, entity.ErrorValidation("invalid two-factor code")
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		if codes[i], err = newRecoveryCode(); err != nil {
+			return nil, err
+		}
+		hashes[i] = hashToken(normalizeRecoveryCode(codes[i]))
+	}
+	if err := f.repo.EnableTOTP(user.ID, step, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// DisableTOTP turns two-factor authentication of the signed in user off, code is a current
+// code or a recovery code.
+func (f *TwoFactor) DisableTOTP(ctx context.Context, code string) error {
+	user, err := f.authorizer.Authorize(ctx)
+	if err != nil {
+		return err
+	}
+	t, err := f.repo.GetTOTP(user.ID)
+	if err != nil {
+		return err
+	}
+	if !t.Enabled() {
+		return entity.ErrorNotFound("two-factor authentication not enabled")
+	}
+	if err := verifySecondFactor(f.repo, t, code); err != nil {
+		return err
+	}
+	return f.repo.DisableTOTP(user.ID)
+}
+
+// ResetTOTP turns two-factor authentication of a user who lost their device and recovery
+// codes off, requires user:manage.
+func (f *TwoFactor) ResetTOTP(ctx context.Context, userID string) error {
+	if _, err := f.authorizer.Authorize(ctx, entity.PermissionUserManage); err != nil {
+		return err
+	}
+	return f.repo.DisableTOTP(userID)
+}
+
+// verifySecondFactor accepts a TOTP code newer than the last one accepted or an unused
+// recovery code, and uses it up.
+func verifySecondFactor(repo repository.TwoFactorRepository, t *entity.TOTP, code string) error {
+	code = strings.TrimSpace(code)
+	if step, ok := totp.Verify(t.Secret, code, time.Now(), totpSkew); ok {
+		if err := repo.UseTOTPStep(t.UserID, step); err != nil {
+			var appErr *entity.AppError
+			if errors.As(err, &appErr) && appErr.Code == entity.ErrorCodeConflict {
+				return entity.ErrorValidation("two-factor code already used")
+			}
+			return err
+		}
+		return nil
+	}
+	if len(code) == totp.Digits {
+		return entity.ErrorValidation("invalid two-factor code")
+	}
+	if err := repo.UseRecoveryCode(t.UserID, hashToken(normalizeRecoveryCode(code))); err != nil {
+		var appErr *entity.AppError
+		if errors.As(err, &appErr) && appErr.Code == entity.ErrorCodeNotFound {
+			return entity.ErrorValidation("invalid two-factor code")
+		}
+		return err
+	}
+	return nil
+}
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// newRecoveryCode returns 16 base32 characters in two groups, like abcdefgh-ijklmnop.
+func newRecoveryCode() (string, error) {
+	b := make([]byte, recoveryCodeBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", entity.WrapError(err, entity.ErrorCodeInternal, "internal error")
+	}
+	code := strings.ToLower(recoveryCodeEncoding.EncodeToString(b))
+	return code[:8] + "-" + code[8:], nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
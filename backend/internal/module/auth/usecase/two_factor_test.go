@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	azm "github.com/fajrinajiseno/mygolangapp/internal/authz/mock"
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/fajrinajiseno/mygolangapp/internal/module/auth/repository/mock"
+	"github.com/fajrinajiseno/mygolangapp/internal/totp"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTwoFactor_SetupTOTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	user := &entity.User{ID: "u1", Email: "alice@example.com"}
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := mock.NewMockTwoFactorRepository(ctrl)
+		mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+		mockAuthorizer.EXPECT().Authorize(ctx).Return(user, nil)
+		var saved string
+		mockRepo.EXPECT().SaveTOTPSecret("u1", gomock.Any()).DoAndReturn(func(_, secret string) error {
+			saved = secret
+			return nil
+		})
+
+		f := NewTwoFactorUsecase(mockRepo, mockAuthorizer, "Payment Dashboard")
+		enrollment, err := f.SetupTOTP(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, saved, enrollment.Secret)
+		assert.Equal(t, totp.ProvisioningURI("Payment Dashboard", "alice@example.com", saved), enrollment.ProvisioningURI)
+	})
+
+	t.Run("Already Enabled", func(t *testing.T) {
+		mockRepo := mock.NewMockTwoFactorRepository(ctrl)
+		mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+		mockAuthorizer.EXPECT().Authorize(ctx).Return(user, nil)
+		mockRepo.EXPECT().SaveTOTPSecret("u1", gomock.Any()).Return(entity.ErrorConflict("two-factor authentication already enabled"))
+
+		f := NewTwoFactorUsecase(mockRepo, mockAuthorizer, "Payment Dashboard")
+		_, err := f.SetupTOTP(ctx)
+		assert.EqualError(t, err, "two-factor authentication already enabled")
+	})
+}
+
+func TestTwoFactor_EnableTOTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	user := &entity.User{ID: "u1", Email: "alice@example.com"}
+	secret, err := totp.GenerateSecret()
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := mock.NewMockTwoFactorRepository(ctrl)
+		mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+		mockAuthorizer.EXPECT().Authorize(ctx).Return(user, nil)
+		mockRepo.EXPECT().GetTOTP("u1").Return(&entity.TOTP{UserID: "u1", Secret: secret}, nil)
+		step := totp.Step(time.Now())
+		var hashes []string
+		mockRepo.EXPECT().EnableTOTP("u1", step, gomock.Any()).DoAndReturn(func(_ string, _ int64, h []string) error {
+			hashes = h
+			return nil
+		})
+
+		code, err := totp.Code(secret, step)
+		assert.NoError(t, err)
+		f := NewTwoFactorUsecase(mockRepo, mockAuthorizer, "Payment Dashboard")
+		codes, err := f.EnableTOTP(ctx, code)
+		assert.NoError(t, err)
+		assert.Len(t, codes, recoveryCodeCount)
+		assert.Len(t, hashes, recoveryCodeCount)
+		assert.Len(t, codes[0], 17)
+		assert.Equal(t, hashToken(strings.ReplaceAll(codes[0], "-", "")), hashes[0])
+	})
+
+	t.Run("Invalid Code", func(t *testing.T) {
+		mockRepo := mock.NewMockTwoFactorRepository(ctrl)
+		mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+		mockAuthorizer.EXPECT().Authorize(ctx).Return(user, nil)
+		mockRepo.EXPECT().GetTOTP("u1").Return(&entity.TOTP{UserID: "u1", Secret: secret}, nil)
+
+		code, err := totp.Code(secret, totp.Step(time.Now())+10)
+		assert.NoError(t, err)
+		f := NewTwoFactorUsecase(mockRepo, mockAuthorizer, "Payment Dashboard")
+		_, err = f.EnableTOTP(ctx, code)
+		assert.EqualError(t, err, "invalid two-factor code")
+	})
+
+	t.Run("Not Set Up", func(t *testing.T) {
+		mockRepo := mock.NewMockTwoFactorRepository(ctrl)
+		mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+		mockAuthorizer.EXPECT().Authorize(ctx).Return(user, nil)
+		mockRepo.EXPECT().GetTOTP("u1").Return(nil, entity.ErrorNotFound("two-factor authentication not set up"))
+
+		f := NewTwoFactorUsecase(mockRepo, mockAuthorizer, "Payment Dashboard")
+		_, err := f.EnableTOTP(ctx, "123456")
+		assert.EqualError(t, err, "two-factor authentication not set up")
+	})
+}
+
+func TestTwoFactor_DisableTOTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	user := &entity.User{ID: "u1", Email: "alice@example.com"}
+	secret, err := totp.GenerateSecret()
+	assert.NoError(t, err)
+	enabledAt := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := mock.NewMockTwoFactorRepository(ctrl)
+		mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+		mockAuthorizer.EXPECT().Authorize(ctx).Return(user, nil)
+		mockRepo.EXPECT().GetTOTP("u1").Return(&entity.TOTP{UserID: "u1", Secret: secret, EnabledAt: &enabledAt}, nil)
+		step := totp.Step(time.Now())
+		mockRepo.EXPECT().UseTOTPStep("u1", step).Return(nil)
+		mockRepo.EXPECT().DisableTOTP("u1").Return(nil)
+
+		code, err := totp.Code(secret, step)
+		assert.NoError(t, err)
+		f := NewTwoFactorUsecase(mockRepo, mockAuthorizer, "Payment Dashboard")
+		assert.NoError(t, f.DisableTOTP(ctx, code))
+	})
+
+	t.Run("Replayed Code", func(t *testing.T) {
+		mockRepo := mock.NewMockTwoFactorRepository(ctrl)
+		mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+		mockAuthorizer.EXPECT().Authorize(ctx).Return(user, nil)
+		mockRepo.EXPECT().GetTOTP("u1").Return(&entity.TOTP{UserID: "u1", Secret: secret, EnabledAt: &enabledAt}, nil)
+		step := totp.Step(time.Now())
+		mockRepo.EXPECT().UseTOTPStep("u1", step).Return(entity.ErrorConflict("two-factor code already used"))
+
+		code, err := totp.Code(secret, step)
+		assert.NoError(t, err)
+		f := NewTwoFactorUsecase(mockRepo, mockAuthorizer, "Payment Dashboard")
+		assert.EqualError(t, f.DisableTOTP(ctx, code), "two-factor code already used")
+	})
+
+	t.Run("Unknown Recovery Code", func(t *testing.T) {
+		mockRepo := mock.NewMockTwoFactorRepository(ctrl)
+		mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+		mockAuthorizer.EXPECT().Authorize(ctx).Return(user, nil)
+		mockRepo.EXPECT().GetTOTP("u1").Return(&entity.TOTP{UserID: "u1", Secret: secret, EnabledAt: &enabledAt}, nil)
+		mockRepo.EXPECT().UseRecoveryCode("u1", hashToken("abcdefghijklmnop")).Return(entity.ErrorNotFound("recovery code not found"))
+
+		f := NewTwoFactorUsecase(mockRepo, mockAuthorizer, "Payment Dashboard")
+		assert.EqualError(t, f.DisableTOTP(ctx, "abcdefgh-ijklmnop"), "invalid two-factor code")
+	})
+}
+
+func TestTwoFactor_ResetTOTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := mock.NewMockTwoFactorRepository(ctrl)
+		mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionUserManage).Return(&entity.User{ID: "admin"}, nil)
+		mockRepo.EXPECT().DisableTOTP("u1").Return(nil)
+
+		f := NewTwoFactorUsecase(mockRepo, mockAuthorizer, "Payment Dashboard")
+		assert.NoError(t, f.ResetTOTP(ctx, "u1"))
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionUserManage).Return(nil, entity.ErrorForbidden("missing permission user:manage"))
+
+		f := NewTwoFactorUsecase(mock.NewMockTwoFactorRepository(ctrl), mockAuthorizer, "Payment Dashboard")
+		assert.EqualError(t, f.ResetTOTP(ctx, "u1"), "missing permission user:manage")
+	})
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/fajrinajiseno/mygolangapp/internal/export"
+	"github.com/fajrinajiseno/mygolangapp/internal/module/export/usecase"
+	"github.com/fajrinajiseno/mygolangapp/internal/openapigen"
+	"github.com/fajrinajiseno/mygolangapp/internal/transport"
+)
+
+type ExportHandler struct {
+	exportUC usecase.ExportUsecase
+}
+
+func NewExportHandler(exportUC usecase.ExportUsecase) *ExportHandler {
+	return &ExportHandler{
+		exportUC: exportUC,
+	}
+}
+
+func (a *ExportHandler) PostDashboardV1Exports(w http.ResponseWriter, r *http.Request) {
+	var req openapigen.PostDashboardV1ExportsJSONRequestBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	job, err := a.exportUC.CreateExport(r.Context(), toExportJobInput(req))
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	genJob := toGenExportJob(job)
+	err = json.NewEncoder(w).Encode(openapigen.ExportJobResponse{Export: &genJob})
+	if err != nil {
+		transport.WriteAppError(w, entity.ErrorInternal("internal server error"))
+		return
+	}
+}
+
+func (a *ExportHandler) GetDashboardV1ExportsId(w http.ResponseWriter, r *http.Request, id string) {
+	job, err := a.exportUC.GetExport(r.Context(), id)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	genJob := toGenExportJob(job)
+	err = json.NewEncoder(w).Encode(openapigen.ExportJobResponse{Export: &genJob})
+	if err != nil {
+		transport.WriteAppError(w, entity.ErrorInternal("internal server error"))
+		return
+	}
+}
+
+// GetDashboardV1ExportsIdDownload serves the export file. The server write timeout is
+// lifted for this response since large files can take longer to send.
+func (a *ExportHandler) GetDashboardV1ExportsIdDownload(w http.ResponseWriter, r *http.Request, id string) {
+	job, f, err := a.exportUC.OpenExportFile(r.Context(), id)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	defer f.Close()
+
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+	filename := fmt.Sprintf("payments-%s.%s", job.CreatedAt.UTC().Format("20060102-150405"), job.Format)
+	w.Header().Set("Content-Type", export.ContentType(job.Format))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	modTime := job.CreatedAt
+	if job.CompletedAt != nil {
+		modTime = *job.CompletedAt
+	}
+	http.ServeContent(w, r, filename, modTime, f)
+}
+
+// toExportJobInput reads the export filters like the payment list does, a search is sorted
+// by relevance unless a sort is given.
+func toExportJobInput(req openapigen.ExportJobInput) entity.ExportJobInput {
+	input := entity.ExportJobInput{Sort: "-created_at"}
+	if req.Format != nil {
+		input.Format = entity.ExportFormat(*req.Format)
+	}
+	if req.Q != nil && strings.TrimSpace(*req.Q) != "" {
+		input.Filter.Search = strings.TrimSpace(*req.Q)
+		input.Sort = "relevance"
+	}
+	if req.Sort != nil {
+		input.Sort = *req.Sort
+	}
+	if req.Status != nil {
+		input.Filter.Status = entity.PaymentStatus(*req.Status)
+	}
+	if req.Id != nil {
+		input.Filter.ID = *req.Id
+	}
+	if req.MerchantId != nil {
+		input.Filter.MerchantID = *req.MerchantId
+	}
+	input.Filter.Reviewed = req.Reviewed
+	input.Filter.CreatedFrom = req.CreatedFrom
+	input.Filter.CreatedTo = req.CreatedTo
+	if req.Currency != nil {
+		input.Filter.Currency = *req.Currency
+	}
+	input.Filter.AmountMin = req.AmountMin
+	input.Filter.AmountMax = req.AmountMax
+	return input
+}
+
+func toGenExportJob(job *entity.ExportJob) openapigen.ExportJob {
+	status := openapigen.ExportJobStatus(job.Status)
+	format := openapigen.ExportJobFormat(job.Format)
+	progress := 0
+	if job.Status == entity.ExportStatusCompleted || job.Status == entity.ExportStatusExpired {
+		progress = 100
+	} else if job.TotalRows > 0 {
+		progress = min(job.RowsWritten*100/job.TotalRows, 99)
+	}
+	genJob := openapigen.ExportJob{
+		Id:          &job.ID,
+		Status:      &status,
+		Format:      &format,
+		TotalRows:   &job.TotalRows,
+		RowsWritten: &job.RowsWritten,
+		Progress:    &progress,
+		CreatedAt:   &job.CreatedAt,
+		CompletedAt: job.CompletedAt,
+		ExpiresAt:   job.ExpiresAt,
+	}
+	if job.Error != "" {
+		genJob.Error = &job.Error
+	}
+	if job.Status == entity.ExportStatusCompleted {
+		url := "/dashboard/v1/exports/" + job.ID + "/download"
+		genJob.DownloadUrl = &url
+	}
+	return genJob
+}
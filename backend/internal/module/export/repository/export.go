@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/database"
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+)
+
+//go:generate mockgen -source export.go -destination mock/export_mock.go -package=mock
+type ExportRepository interface {
+	Create(job *entity.ExportJob) (*entity.ExportJob, error)
+	GetByID(id string) (*entity.ExportJob, error)
+	ClaimNext() (*entity.ExportJob, error)
+	UpdateProgress(id string, rowsWritten int) error
+	Complete(id, filePath string, rowsWritten int, expiresAt time.Time) error
+	Fail(id, message string) error
+	ResetRunning() error
+	ListExpired(now time.Time) ([]*entity.ExportJob, error)
+	MarkExpired(id string) error
+}
+
+type Export struct {
+	db *sql.DB
+}
+
+func NewExportRepo(db *sql.DB) *Export {
+	return &Export{db: db}
+}
+
+const exportSelect = `SELECT id, user_id, format, filter, sort, status, total_rows, rows_written, error, file_path,
+	created_at, started_at, completed_at, expires_at FROM export_jobs`
+
+func (r *Export) Create(job *entity.ExportJob) (*entity.ExportJob, error) {
+	created := *job
+	created.Status = entity.ExportStatusPending
+	created.CreatedAt = time.Now().UTC()
+	filter, err := json.Marshal(created.Filter)
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "internal error")
+	}
+	res, err := r.db.Exec("INSERT INTO export_jobs(user_id, format, filter, sort, status, total_rows, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		created.UserID, string(created.Format), string(filter), created.Sort, string(created.Status), created.TotalRows, created.CreatedAt)
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	jobID, err := res.LastInsertId()
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	created.ID = fmt.Sprint(jobID)
+	return &created, nil
+}
+
+func (r *Export) GetByID(id string) (*entity.ExportJob, error) {
+	job, err := scanExportJob(r.db.QueryRow(exportSelect+" WHERE id = ?", id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrorNotFound("export not found")
+		}
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return job, nil
+}
+
+// ClaimNext marks the oldest pending job as running and returns it, nil when no job is
+// pending. The claim is a single statement so a job is never picked up twice.
+func (r *Export) ClaimNext() (*entity.ExportJob, error) {
+	var id string
+	err := r.db.QueryRow(`UPDATE export_jobs SET status = ?, started_at = ?
+		WHERE id = (SELECT id FROM export_jobs WHERE status = ? ORDER BY id LIMIT 1) RETURNING id`,
+		string(entity.ExportStatusRunning), time.Now().UTC(), string(entity.ExportStatusPending)).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return r.GetByID(id)
+}
+
+func (r *Export) UpdateProgress(id string, rowsWritten int) error {
+	if _, err := r.db.Exec("UPDATE export_jobs SET rows_written = ? WHERE id = ?", rowsWritten, id); err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return nil
+}
+
+func (r *Export) Complete(id, filePath string, rowsWritten int, expiresAt time.Time) error {
+	_, err := r.db.Exec("UPDATE export_jobs SET status = ?, file_path = ?, rows_written = ?, completed_at = ?, expires_at = ? WHERE id = ?",
+		string(entity.ExportStatusCompleted), filePath, rowsWritten, time.Now().UTC(), expiresAt.UTC(), id)
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return nil
+}
+
+func (r *Export) Fail(id, message string) error {
+	_, err := r.db.Exec("UPDATE export_jobs SET status = ?, error = ?, completed_at = ? WHERE id = ?",
+		string(entity.ExportStatusFailed), message, time.Now().UTC(), id)
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return nil
+}
+
+// ResetRunning puts jobs that were interrupted by a restart back in the queue.
+func (r *Export) ResetRunning() error {
+	_, err := r.db.Exec("UPDATE export_jobs SET status = ?, rows_written = 0, started_at = NULL WHERE status = ?",
+		string(entity.ExportStatusPending), string(entity.ExportStatusRunning))
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return nil
+}
+
+// ListExpired returns the completed jobs whose file expired at now.
+func (r *Export) ListExpired(now time.Time) ([]*entity.ExportJob, error) {
+	rows, err := r.db.Query(exportSelect+" WHERE status = ? AND julianday(expires_at) <= julianday(?) ORDER BY id",
+		string(entity.ExportStatusCompleted), now.UTC().Format(time.RFC3339Nano))
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	defer rows.Close()
+	res := []*entity.ExportJob{}
+	for rows.Next() {
+		job, err := scanExportJob(rows)
+		if err != nil {
+			return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		}
+		res = append(res, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return res, nil
+}
+
+func (r *Export) MarkExpired(id string) error {
+	if _, err := r.db.Exec("UPDATE export_jobs SET status = ?, file_path = '' WHERE id = ?", string(entity.ExportStatusExpired), id); err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return nil
+}
+
+func scanExportJob(row database.RowScanner) (*entity.ExportJob, error) {
+	var job entity.ExportJob
+	var filter string
+	var startedAt, completedAt, expiresAt sql.NullTime
+	if err := row.Scan(&job.ID, &job.UserID, &job.Format, &filter, &job.Sort, &job.Status, &job.TotalRows, &job.RowsWritten,
+		&job.Error, &job.FilePath, &job.CreatedAt, &startedAt, &completedAt, &expiresAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(filter), &job.Filter); err != nil {
+		return nil, err
+	}
+	if startedAt.Valid {
+		job.StartedAt = &startedAt.Time
+	}
+	if completedAt.Valid {
+		job.CompletedAt = &completedAt.Time
+	}
+	if expiresAt.Valid {
+		job.ExpiresAt = &expiresAt.Time
+	}
+	return &job, nil
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+var exportColumns = []string{
+	"id", "user_id", "format", "filter", "sort", "status", "total_rows", "rows_written", "error", "file_path",
+	"created_at", "started_at", "completed_at", "expires_at",
+}
+
+func newMockRepo(t *testing.T) (*Export, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	repo := NewExportRepo(db)
+	cleanup := func() { db.Close() }
+	return repo, mock, cleanup
+}
+
+func TestCreate(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO export_jobs(user_id, format, filter, sort, status, total_rows, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)")).
+		WithArgs("1", "xlsx", sqlmock.AnyArg(), "-amount", "pending", 40, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+
+	job, err := repo.Create(&entity.ExportJob{
+		UserID:    "1",
+		Format:    entity.ExportFormatXLSX,
+		Filter:    entity.PaymentFilter{Status: entity.PaymentStatusCompleted},
+		Sort:      "-amount",
+		TotalRows: 40,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "3", job.ID)
+	assert.Equal(t, entity.ExportStatusPending, job.Status)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetByID(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		now := time.Now()
+		mock.ExpectQuery(regexp.QuoteMeta(exportSelect + " WHERE id = ?")).
+			WithArgs("3").
+			WillReturnRows(sqlmock.NewRows(exportColumns).
+				AddRow("3", "1", "csv", `{"Status":"completed","MerchantID":"2"}`, "-amount", "completed", 40, 40, "", "exports/export-3.csv",
+					now, now, now, now.Add(time.Hour)))
+
+		job, err := repo.GetByID("3")
+		assert.NoError(t, err)
+		assert.Equal(t, entity.PaymentFilter{Status: entity.PaymentStatusCompleted, MerchantID: "2"}, job.Filter)
+		assert.Equal(t, entity.ExportStatusCompleted, job.Status)
+		assert.NotNil(t, job.ExpiresAt)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		mock.ExpectQuery(regexp.QuoteMeta(exportSelect + " WHERE id = ?")).
+			WithArgs("9").
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := repo.GetByID("9")
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeNotFound, appErr.Code)
+	})
+}
+
+func TestClaimNext(t *testing.T) {
+	claim := "UPDATE export_jobs SET status = ?, started_at = ?"
+
+	t.Run("claims oldest pending", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		mock.ExpectQuery(regexp.QuoteMeta(claim)).
+			WithArgs("running", sqlmock.AnyArg(), "pending").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("4"))
+		mock.ExpectQuery(regexp.QuoteMeta(exportSelect + " WHERE id = ?")).
+			WithArgs("4").
+			WillReturnRows(sqlmock.NewRows(exportColumns).
+				AddRow("4", "1", "csv", `{}`, "", "running", 2, 0, "", "", time.Now(), time.Now(), nil, nil))
+
+		job, err := repo.ClaimNext()
+		assert.NoError(t, err)
+		assert.Equal(t, "4", job.ID)
+		assert.Nil(t, job.CompletedAt)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("nothing pending", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		mock.ExpectQuery(regexp.QuoteMeta(claim)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		job, err := repo.ClaimNext()
+		assert.NoError(t, err)
+		assert.Nil(t, job)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		mock.ExpectQuery(regexp.QuoteMeta(claim)).
+			WillReturnError(errors.New("db fail"))
+
+		_, err := repo.ClaimNext()
+		assert.Error(t, err)
+	})
+}
+
+func TestComplete(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	expiresAt := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE export_jobs SET status = ?, file_path = ?, rows_written = ?, completed_at = ?, expires_at = ? WHERE id = ?")).
+		WithArgs("completed", "exports/export-4.csv", 12, sqlmock.AnyArg(), expiresAt, "4").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.Complete("4", "exports/export-4.csv", 12, expiresAt))
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestListExpired(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	now := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(exportSelect+" WHERE status = ? AND julianday(expires_at) <= julianday(?) ORDER BY id")).
+		WithArgs("completed", "2025-01-02T00:00:00Z").
+		WillReturnRows(sqlmock.NewRows(exportColumns).
+			AddRow("1", "1", "csv", `{}`, "", "completed", 2, 2, "", "exports/export-1.csv", now, now, now, now))
+
+	jobs, err := repo.ListExpired(now)
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, "exports/export-1.csv", jobs[0].FilePath)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,244 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/authz"
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/fajrinajiseno/mygolangapp/internal/export"
+	"github.com/fajrinajiseno/mygolangapp/internal/middleware"
+	exportRepository "github.com/fajrinajiseno/mygolangapp/internal/module/export/repository"
+	paymentUsecase "github.com/fajrinajiseno/mygolangapp/internal/module/payment/usecase"
+)
+
+const (
+	// progressEvery is how many rows are written between progress updates.
+	progressEvery = 1000
+	// pollInterval is how often the worker looks for jobs and expired files without being woken.
+	pollInterval = time.Minute
+)
+
+//go:generate mockgen -source export.go -destination mock/export_mock.go -package=mock
+type ExportUsecase interface {
+	CreateExport(ctx context.Context, input entity.ExportJobInput) (*entity.ExportJob, error)
+	GetExport(ctx context.Context, id string) (*entity.ExportJob, error)
+	OpenExportFile(ctx context.Context, id string) (*entity.ExportJob, *os.File, error)
+}
+
+type Export struct {
+	authorizer authz.Authorizer
+	exportRepo exportRepository.ExportRepository
+	paymentUC  paymentUsecase.PaymentUsecase
+	dir        string
+	ttl        time.Duration
+	wake       chan struct{}
+}
+
+// NewExportUsecase writes export files to dir and keeps them for ttl after they are done.
+func NewExportUsecase(er exportRepository.ExportRepository, az authz.Authorizer, pu paymentUsecase.PaymentUsecase, dir string, ttl time.Duration) *Export {
+	return &Export{exportRepo: er, authorizer: az, paymentUC: pu, dir: dir, ttl: ttl, wake: make(chan struct{}, 1)}
+}
+
+// CreateExport queues an export of the payments matching the filter. The filter and sort
+// are checked by counting the matching payments, which is also the total of the progress.
+func (u *Export) CreateExport(ctx context.Context, input entity.ExportJobInput) (*entity.ExportJob, error) {
+	user, err := u.authorizer.Authorize(ctx, entity.PermissionPaymentRead)
+	if err != nil {
+		return nil, err
+	}
+	if input.Format == "" {
+		input.Format = entity.ExportFormatCSV
+	}
+	if !input.Format.Valid() {
+		return nil, entity.ErrorValidation("unsupported export format " + string(input.Format))
+	}
+	page, err := u.paymentUC.ListPayment(ctx, input.Filter, input.Sort, 1, 0, "", entity.PaymentSummaryScopeFiltered)
+	if err != nil {
+		return nil, err
+	}
+	job, err := u.exportRepo.Create(&entity.ExportJob{
+		UserID:    user.ID,
+		Format:    input.Format,
+		Filter:    input.Filter,
+		Sort:      input.Sort,
+		TotalRows: page.Summary.TotalByFiler,
+	})
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case u.wake <- struct{}{}:
+	default:
+	}
+	return job, nil
+}
+
+// GetExport returns an export of the current user, exports of other users are not found.
+func (u *Export) GetExport(ctx context.Context, id string) (*entity.ExportJob, error) {
+	user, err := u.authorizer.Authorize(ctx, entity.PermissionPaymentRead)
+	if err != nil {
+		return nil, err
+	}
+	job, err := u.exportRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if job.UserID != user.ID {
+		return nil, entity.ErrorNotFound("export not found")
+	}
+	return job, nil
+}
+
+// OpenExportFile opens the file of a completed export, the caller closes it.
+func (u *Export) OpenExportFile(ctx context.Context, id string) (*entity.ExportJob, *os.File, error) {
+	job, err := u.GetExport(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch job.Status {
+	case entity.ExportStatusCompleted:
+	case entity.ExportStatusExpired:
+		return nil, nil, entity.ErrorNotFound("export has expired")
+	case entity.ExportStatusFailed:
+		return nil, nil, entity.ErrorConflict("export failed: " + job.Error)
+	default:
+		return nil, nil, entity.ErrorConflict("export is not finished yet")
+	}
+	f, err := os.Open(job.FilePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil, entity.ErrorNotFound("export has expired")
+		}
+		return nil, nil, entity.WrapError(err, entity.ErrorCodeInternal, "internal error")
+	}
+	return job, f, nil
+}
+
+// Run processes queued exports one at a time and removes expired files until ctx is done.
+// Jobs interrupted by a restart are run again from the start.
+func (u *Export) Run(ctx context.Context) {
+	if err := os.MkdirAll(u.dir, 0o750); err != nil {
+		log.Printf("export worker stopped: %v", err)
+		return
+	}
+	if err := u.exportRepo.ResetRunning(); err != nil {
+		log.Printf("export worker: %v", err)
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		u.runPending(ctx)
+		u.removeExpired()
+		select {
+		case <-ctx.Done():
+			return
+		case <-u.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+func (u *Export) runPending(ctx context.Context) {
+	for ctx.Err() == nil {
+		job, err := u.exportRepo.ClaimNext()
+		if err != nil {
+			log.Printf("export worker: %v", err)
+			return
+		}
+		if job == nil {
+			return
+		}
+		path, rows, err := u.writeFile(ctx, job)
+		if err != nil {
+			if ctx.Err() != nil {
+				// shutting down, the job is picked up again on the next start
+				return
+			}
+			log.Printf("export %s failed: %v", job.ID, err)
+			message := "internal error"
+			var appErr *entity.AppError
+			if errors.As(err, &appErr) && appErr.Code == entity.ErrorCodeValidation {
+				message = appErr.Message
+			}
+			if err := u.exportRepo.Fail(job.ID, message); err != nil {
+				log.Printf("export worker: %v", err)
+			}
+			continue
+		}
+		if err := u.exportRepo.Complete(job.ID, path, rows, time.Now().Add(u.ttl)); err != nil {
+			log.Printf("export worker: %v", err)
+		}
+	}
+}
+
+// writeFile writes the payments of the job to a temporary file that is renamed once it is
+// complete, so a half written file is never served.
+func (u *Export) writeFile(ctx context.Context, job *entity.ExportJob) (string, int, error) {
+	path := filepath.Join(u.dir, fmt.Sprintf("export-%s.%s", job.ID, job.Format))
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return "", 0, err
+	}
+	defer os.Remove(tmp)
+	defer f.Close()
+
+	writer, err := export.NewWriter(job.Format, f)
+	if err != nil {
+		return "", 0, err
+	}
+	if err := writer.WriteRow(export.PaymentHeader...); err != nil {
+		return "", 0, err
+	}
+	rows := 0
+	// the payments are read as the user who asked for the export
+	err = u.paymentUC.ExportPayments(middleware.WithUserID(ctx, job.UserID), job.Filter, job.Sort, func(p *entity.Payment) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := writer.WriteRow(export.PaymentRow(p)...); err != nil {
+			return err
+		}
+		rows++
+		if rows%progressEvery == 0 {
+			return u.exportRepo.UpdateProgress(job.ID, rows)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	if err := writer.Close(); err != nil {
+		return "", 0, err
+	}
+	if err := f.Close(); err != nil {
+		return "", 0, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return "", 0, err
+	}
+	return path, rows, nil
+}
+
+func (u *Export) removeExpired() {
+	jobs, err := u.exportRepo.ListExpired(time.Now())
+	if err != nil {
+		log.Printf("export worker: %v", err)
+		return
+	}
+	for _, job := range jobs {
+		if err := os.Remove(job.FilePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Printf("export %s: %v", job.ID, err)
+			continue
+		}
+		if err := u.exportRepo.MarkExpired(job.ID); err != nil {
+			log.Printf("export worker: %v", err)
+		}
+	}
+}
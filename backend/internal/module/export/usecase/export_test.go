@@ -0,0 +1,211 @@
+package usecase
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	azm "github.com/fajrinajiseno/mygolangapp/internal/authz/mock"
+	"github.com/fajrinajiseno/mygolangapp/internal/config"
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/fajrinajiseno/mygolangapp/internal/middleware"
+	em "github.com/fajrinajiseno/mygolangapp/internal/module/export/repository/mock"
+	pum "github.com/fajrinajiseno/mygolangapp/internal/module/payment/usecase/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExport_CreateExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockExportRepo := em.NewMockExportRepository(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+	mockPaymentUC := pum.NewMockPaymentUsecase(ctrl)
+	ctx := context.WithValue(context.Background(), config.ContextUserID, "1")
+	filter := entity.PaymentFilter{Status: entity.PaymentStatusCompleted}
+
+	t.Run("success", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentRead).Return(&entity.User{ID: "1"}, nil)
+		mockPaymentUC.EXPECT().
+			ListPayment(ctx, filter, "-amount", 1, 0, "", entity.PaymentSummaryScopeFiltered).
+			Return(&entity.PaymentPage{Summary: &entity.PaymentSummary{TotalByFiler: 40}}, nil)
+		mockExportRepo.EXPECT().
+			Create(&entity.ExportJob{UserID: "1", Format: entity.ExportFormatCSV, Filter: filter, Sort: "-amount", TotalRows: 40}).
+			Return(&entity.ExportJob{ID: "3", Status: entity.ExportStatusPending}, nil)
+
+		u := NewExportUsecase(mockExportRepo, mockAuthorizer, mockPaymentUC, t.TempDir(), time.Hour)
+
+		job, err := u.CreateExport(ctx, entity.ExportJobInput{Filter: filter, Sort: "-amount"})
+		assert.NoError(t, err)
+		assert.Equal(t, "3", job.ID)
+		assert.Len(t, u.wake, 1)
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentRead).Return(&entity.User{ID: "1"}, nil)
+		mockPaymentUC.EXPECT().
+			ListPayment(ctx, filter, "fee", 1, 0, "", entity.PaymentSummaryScopeFiltered).
+			Return(nil, entity.ErrorValidation(`unknown sort field "fee"`))
+
+		u := NewExportUsecase(mockExportRepo, mockAuthorizer, mockPaymentUC, t.TempDir(), time.Hour)
+
+		_, err := u.CreateExport(ctx, entity.ExportJobInput{Filter: filter, Sort: "fee"})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentRead).Return(&entity.User{ID: "1"}, nil)
+		u := NewExportUsecase(mockExportRepo, mockAuthorizer, mockPaymentUC, t.TempDir(), time.Hour)
+
+		_, err := u.CreateExport(ctx, entity.ExportJobInput{Format: "pdf"})
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeValidation, appErr.Code)
+	})
+
+	t.Run("missing permission", func(t *testing.T) {
+		mockAuthorizer.EXPECT().
+			Authorize(ctx, entity.PermissionPaymentRead).
+			Return(nil, entity.ErrorForbidden("missing permission payment:read"))
+
+		u := NewExportUsecase(mockExportRepo, mockAuthorizer, mockPaymentUC, t.TempDir(), time.Hour)
+
+		_, err := u.CreateExport(ctx, entity.ExportJobInput{})
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeForbidden, appErr.Code)
+	})
+}
+
+func TestExport_OpenExportFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockExportRepo := em.NewMockExportRepository(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+	mockPaymentUC := pum.NewMockPaymentUsecase(ctrl)
+	ctx := context.WithValue(context.Background(), config.ContextUserID, "1")
+	dir := t.TempDir()
+	path := filepath.Join(dir, "export-1.csv")
+	require.NoError(t, os.WriteFile(path, []byte("id\n"), 0o600))
+
+	t.Run("completed", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentRead).Return(&entity.User{ID: "1"}, nil)
+		mockExportRepo.EXPECT().GetByID("1").
+			Return(&entity.ExportJob{ID: "1", UserID: "1", Status: entity.ExportStatusCompleted, FilePath: path}, nil)
+
+		u := NewExportUsecase(mockExportRepo, mockAuthorizer, mockPaymentUC, dir, time.Hour)
+
+		_, f, err := u.OpenExportFile(ctx, "1")
+		assert.NoError(t, err)
+		f.Close()
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentRead).Return(&entity.User{ID: "1"}, nil)
+		mockExportRepo.EXPECT().GetByID("1").
+			Return(&entity.ExportJob{ID: "1", UserID: "2", Status: entity.ExportStatusCompleted, FilePath: path}, nil)
+
+		u := NewExportUsecase(mockExportRepo, mockAuthorizer, mockPaymentUC, dir, time.Hour)
+
+		_, _, err := u.OpenExportFile(ctx, "1")
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeNotFound, appErr.Code)
+	})
+
+	t.Run("still running", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentRead).Return(&entity.User{ID: "1"}, nil)
+		mockExportRepo.EXPECT().GetByID("1").
+			Return(&entity.ExportJob{ID: "1", UserID: "1", Status: entity.ExportStatusRunning}, nil)
+
+		u := NewExportUsecase(mockExportRepo, mockAuthorizer, mockPaymentUC, dir, time.Hour)
+
+		_, _, err := u.OpenExportFile(ctx, "1")
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeConflict, appErr.Code)
+	})
+}
+
+func TestExport_RunPending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockExportRepo := em.NewMockExportRepository(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+	mockPaymentUC := pum.NewMockPaymentUsecase(ctrl)
+
+	t.Run("writes the file", func(t *testing.T) {
+		dir := t.TempDir()
+		job := &entity.ExportJob{ID: "1", UserID: "4", Format: entity.ExportFormatCSV, Sort: "id"}
+		gomock.InOrder(
+			mockExportRepo.EXPECT().ClaimNext().Return(job, nil),
+			mockPaymentUC.EXPECT().
+				ExportPayments(gomock.Any(), entity.PaymentFilter{}, "id", gomock.Any()).
+				DoAndReturn(func(ctx context.Context, _ entity.PaymentFilter, _ string, fn func(p *entity.Payment) error) error {
+					// read as the user who asked for the export
+					assert.Equal(t, "4", middleware.GetUserID(ctx))
+					return fn(&entity.Payment{ID: "7", Merchant: "merchant 7", Amount: 100, Currency: "USD", Status: entity.PaymentStatusCompleted})
+				}),
+			mockExportRepo.EXPECT().Complete("1", filepath.Join(dir, "export-1.csv"), 1, gomock.Any()).Return(nil),
+			mockExportRepo.EXPECT().ClaimNext().Return(nil, nil),
+		)
+
+		u := NewExportUsecase(mockExportRepo, mockAuthorizer, mockPaymentUC, dir, time.Hour)
+		u.runPending(context.Background())
+
+		body, err := os.ReadFile(filepath.Join(dir, "export-1.csv"))
+		require.NoError(t, err)
+		assert.Contains(t, string(body), "7,,merchant 7,completed,USD,1.00,100,")
+		_, err = os.Stat(filepath.Join(dir, "export-1.csv.tmp"))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("failed job", func(t *testing.T) {
+		dir := t.TempDir()
+		job := &entity.ExportJob{ID: "2", Format: entity.ExportFormatCSV, Sort: "fee"}
+		gomock.InOrder(
+			mockExportRepo.EXPECT().ClaimNext().Return(job, nil),
+			mockPaymentUC.EXPECT().
+				ExportPayments(gomock.Any(), entity.PaymentFilter{}, "fee", gomock.Any()).
+				Return(entity.ErrorValidation(`unknown sort field "fee"`)),
+			mockExportRepo.EXPECT().Fail("2", `unknown sort field "fee"`).Return(nil),
+			mockExportRepo.EXPECT().ClaimNext().Return(nil, nil),
+		)
+
+		u := NewExportUsecase(mockExportRepo, mockAuthorizer, mockPaymentUC, dir, time.Hour)
+		u.runPending(context.Background())
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
+
+func TestExport_RemoveExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockExportRepo := em.NewMockExportRepository(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+	mockPaymentUC := pum.NewMockPaymentUsecase(ctrl)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "export-1.csv")
+	require.NoError(t, os.WriteFile(path, []byte("id\n"), 0o600))
+
+	mockExportRepo.EXPECT().ListExpired(gomock.Any()).
+		Return([]*entity.ExportJob{{ID: "1", FilePath: path}, {ID: "2", FilePath: filepath.Join(dir, "gone.csv")}}, nil)
+	mockExportRepo.EXPECT().MarkExpired("1").Return(nil)
+	mockExportRepo.EXPECT().MarkExpired("2").Return(nil)
+
+	u := NewExportUsecase(mockExportRepo, mockAuthorizer, mockPaymentUC, dir, time.Hour)
+	u.removeExpired()
+
+	_, err := os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: export.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	os "os"
+	reflect "reflect"
+
+	entity "github.com/fajrinajiseno/mygolangapp/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockExportUsecase is a mock of ExportUsecase interface.
+type MockExportUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportUsecaseMockRecorder
+}
+
+// MockExportUsecaseMockRecorder is the mock recorder for MockExportUsecase.
+type MockExportUsecaseMockRecorder struct {
+	mock *MockExportUsecase
+}
+
+// NewMockExportUsecase creates a new mock instance.
+func NewMockExportUsecase(ctrl *gomock.Controller) *MockExportUsecase {
+	mock := &MockExportUsecase{ctrl: ctrl}
+	mock.recorder = &MockExportUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportUsecase) EXPECT() *MockExportUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateExport mocks base method.
+func (m *MockExportUsecase) CreateExport(ctx context.Context, input entity.ExportJobInput) (*entity.ExportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExport", ctx, input)
+	ret0, _ := ret[0].(*entity.ExportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExport indicates an expected call of CreateExport.
+func (mr *MockExportUsecaseMockRecorder) CreateExport(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExport", reflect.TypeOf((*MockExportUsecase)(nil).CreateExport), ctx, input)
+}
+
+// GetExport mocks base method.
+func (m *MockExportUsecase) GetExport(ctx context.Context, id string) (*entity.ExportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExport", ctx, id)
+	ret0, _ := ret[0].(*entity.ExportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExport indicates an expected call of GetExport.
+func (mr *MockExportUsecaseMockRecorder) GetExport(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExport", reflect.TypeOf((*MockExportUsecase)(nil).GetExport), ctx, id)
+}
+
+// OpenExportFile mocks base method.
+func (m *MockExportUsecase) OpenExportFile(ctx context.Context, id string) (*entity.ExportJob, *os.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenExportFile", ctx, id)
+	ret0, _ := ret[0].(*entity.ExportJob)
+	ret1, _ := ret[1].(*os.File)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OpenExportFile indicates an expected call of OpenExportFile.
+func (mr *MockExportUsecaseMockRecorder) OpenExportFile(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenExportFile", reflect.TypeOf((*MockExportUsecase)(nil).OpenExportFile), ctx, id)
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/fajrinajiseno/mygolangapp/internal/module/merchant/usecase"
+	"github.com/fajrinajiseno/mygolangapp/internal/openapigen"
+	"github.com/fajrinajiseno/mygolangapp/internal/transport"
+)
+
+type MerchantHandler struct {
+	merchantUC usecase.MerchantUsecase
+}
+
+func NewMerchantHandler(merchantUC usecase.MerchantUsecase) *MerchantHandler {
+	return &MerchantHandler{
+		merchantUC: merchantUC,
+	}
+}
+
+func (a *MerchantHandler) GetDashboardV1Merchants(w http.ResponseWriter, r *http.Request, params openapigen.GetDashboardV1MerchantsParams) {
+	limit := 10
+	offset := 0
+	filter := entity.MerchantFilter{}
+
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+
+	if params.Status != nil {
+		filter.Status = entity.MerchantStatus(*params.Status)
+	}
+
+	merchants, total, err := a.merchantUC.ListMerchants(r.Context(), filter, limit, offset)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	genMerchants := make([]openapigen.Merchant, len(merchants))
+	for i, item := range merchants {
+		genMerchants[i] = toGenMerchant(item)
+	}
+	err = json.NewEncoder(w).Encode(openapigen.MerchantListResponse{Meta: &openapigen.PaginationMeta{
+		Limit:  params.Limit,
+		Offset: params.Offset,
+		Total:  &total,
+	}, Merchants: &genMerchants})
+	if err != nil {
+		transport.WriteAppError(w, entity.ErrorInternal("internal server error"))
+		return
+	}
+}
+
+func (a *MerchantHandler) PostDashboardV1Merchants(w http.ResponseWriter, r *http.Request) {
+	var req openapigen.PostDashboardV1MerchantsJSONRequestBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	merchant, err := a.merchantUC.CreateMerchant(r.Context(), toMerchantInput(req))
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	genMerchant := toGenMerchant(merchant)
+	err = json.NewEncoder(w).Encode(openapigen.MerchantResponse{Merchant: &genMerchant})
+	if err != nil {
+		transport.WriteAppError(w, entity.ErrorInternal("internal server error"))
+		return
+	}
+}
+
+func (a *MerchantHandler) GetDashboardV1MerchantId(w http.ResponseWriter, r *http.Request, id string) {
+	merchant, err := a.merchantUC.GetMerchant(r.Context(), id)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	genMerchant := toGenMerchant(merchant)
+	err = json.NewEncoder(w).Encode(openapigen.MerchantResponse{Merchant: &genMerchant})
+	if err != nil {
+		transport.WriteAppError(w, entity.ErrorInternal("internal server error"))
+		return
+	}
+}
+
+func (a *MerchantHandler) PutDashboardV1MerchantId(w http.ResponseWriter, r *http.Request, id string) {
+	var req openapigen.PutDashboardV1MerchantIdJSONRequestBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	merchant, err := a.merchantUC.UpdateMerchant(r.Context(), id, toMerchantInput(req))
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	genMerchant := toGenMerchant(merchant)
+	err = json.NewEncoder(w).Encode(openapigen.MerchantResponse{Merchant: &genMerchant})
+	if err != nil {
+		transport.WriteAppError(w, entity.ErrorInternal("internal server error"))
+		return
+	}
+}
+
+func (a *MerchantHandler) DeleteDashboardV1MerchantId(w http.ResponseWriter, r *http.Request, id string) {
+	if err := a.merchantUC.DeleteMerchant(r.Context(), id); err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func toMerchantInput(req openapigen.MerchantInput) entity.MerchantInput {
+	input := entity.MerchantInput{
+		LegalName:          req.LegalName,
+		DisplayName:        req.DisplayName,
+		SettlementCurrency: req.SettlementCurrency,
+		ContactEmail:       req.ContactEmail,
+	}
+	if req.Status != nil {
+		input.Status = entity.MerchantStatus(*req.Status)
+	}
+	return input
+}
+
+func toGenMerchant(item *entity.Merchant) openapigen.Merchant {
+	status := openapigen.MerchantStatus(item.Status)
+	return openapigen.Merchant{
+		Id:                 &item.ID,
+		LegalName:          &item.LegalName,
+		DisplayName:        &item.DisplayName,
+		Status:             &status,
+		SettlementCurrency: &item.SettlementCurrency,
+		ContactEmail:       &item.ContactEmail,
+		CreatedAt:          &item.CreatedAt,
+	}
+}
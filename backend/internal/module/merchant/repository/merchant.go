@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/database"
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/mattn/go-sqlite3"
+)
+
+//go:generate mockgen -source merchant.go -destination mock/merchant_mock.go -package=mock
+type MerchantRepository interface {
+	GetMerchants(filter entity.MerchantFilter, limit, offset int) ([]*entity.Merchant, int, error)
+	GetMerchantByID(id string) (*entity.Merchant, error)
+	Create(m *entity.Merchant) (*entity.Merchant, error)
+	Update(m *entity.Merchant) (*entity.Merchant, error)
+	Delete(id string) error
+}
+
+type Merchant struct {
+	db *sql.DB
+}
+
+func NewMerchantRepo(db *sql.DB) *Merchant {
+	return &Merchant{db: db}
+}
+
+const merchantSelect = `SELECT id, legal_name, display_name, status, settlement_currency, contact_email, created_at FROM merchants`
+
+func (r *Merchant) GetMerchants(filter entity.MerchantFilter, limit, offset int) ([]*entity.Merchant, int, error) {
+	q := merchantSelect
+	qt := "SELECT COUNT(1) FROM merchants"
+	where := []string{}
+	args := []interface{}{}
+	if filter.Status != "" {
+		where = append(where, "status = ?")
+		args = append(args, filter.Status)
+	}
+	if len(where) > 0 {
+		q += " WHERE " + strings.Join(where, " AND ")
+		qt += " WHERE " + strings.Join(where, " AND ")
+	}
+	argsT := append([]interface{}{}, args...)
+	q += " ORDER BY id ASC"
+	if limit > 0 {
+		q += " LIMIT ?"
+		args = append(args, limit)
+	}
+	if offset > 0 {
+		q += " OFFSET ?"
+		args = append(args, offset)
+	}
+
+	rows, err := r.db.Query(q, args...)
+	if err != nil {
+		return nil, 0, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	defer rows.Close()
+	res := []*entity.Merchant{}
+	for rows.Next() {
+		m, err := scanMerchant(rows)
+		if err != nil {
+			return nil, 0, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		}
+		res = append(res, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+
+	var total int
+	if err := r.db.QueryRow(qt, argsT...).Scan(&total); err != nil {
+		return nil, 0, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return res, total, nil
+}
+
+func (r *Merchant) GetMerchantByID(id string) (*entity.Merchant, error) {
+	m, err := scanMerchant(r.db.QueryRow(merchantSelect+" WHERE id = ?", id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrorNotFound("merchant not found")
+		}
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return m, nil
+}
+
+func (r *Merchant) Create(m *entity.Merchant) (*entity.Merchant, error) {
+	created := *m
+	created.CreatedAt = time.Now().UTC()
+	res, err := r.db.Exec("INSERT INTO merchants(legal_name, display_name, status, settlement_currency, contact_email, created_at) VALUES (?, ?, ?, ?, ?, ?)",
+		created.LegalName, created.DisplayName, string(created.Status), created.SettlementCurrency, created.ContactEmail, created.CreatedAt)
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	merchantID, err := res.LastInsertId()
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	created.ID = fmt.Sprint(merchantID)
+	return &created, nil
+}
+
+func (r *Merchant) Update(m *entity.Merchant) (*entity.Merchant, error) {
+	res, err := r.db.Exec("UPDATE merchants SET legal_name = ?, display_name = ?, status = ?, settlement_currency = ?, contact_email = ? WHERE id = ?",
+		m.LegalName, m.DisplayName, string(m.Status), m.SettlementCurrency, m.ContactEmail, m.ID)
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if affected == 0 {
+		return nil, entity.ErrorNotFound("merchant not found")
+	}
+	return r.GetMerchantByID(m.ID)
+}
+
+// Delete removes a merchant. Merchants that still have payments are kept by the foreign
+// key and a conflict error is returned, those should be deactivated instead.
+func (r *Merchant) Delete(id string) error {
+	res, err := r.db.Exec("DELETE FROM merchants WHERE id = ?", id)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.Code == sqlite3.ErrConstraint {
+			return entity.ErrorConflict("merchant has payments, deactivate it instead")
+		}
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if affected == 0 {
+		return entity.ErrorNotFound("merchant not found")
+	}
+	return nil
+}
+
+func scanMerchant(row database.RowScanner) (*entity.Merchant, error) {
+	var m entity.Merchant
+	if err := row.Scan(&m.ID, &m.LegalName, &m.DisplayName, &m.Status, &m.SettlementCurrency, &m.ContactEmail, &m.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
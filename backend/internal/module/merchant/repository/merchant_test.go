@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+var merchantColumns = []string{
+	"id", "legal_name", "display_name", "status", "settlement_currency", "contact_email", "created_at",
+}
+
+func newMockRepo(t *testing.T) (*Merchant, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	repo := NewMerchantRepo(db)
+	cleanup := func() { db.Close() }
+	return repo, mock, cleanup
+}
+
+func TestGetMerchants_Success(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectQuery(regexp.QuoteMeta(merchantSelect+" WHERE status = ? ORDER BY id ASC LIMIT ? OFFSET ?")).
+		WithArgs("active", 10, 5).
+		WillReturnRows(sqlmock.NewRows(merchantColumns).
+			AddRow("1", "PT Merchant 1", "merchant 1", "active", "IDR", "finance@merchant1.test", time.Now()))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(1) FROM merchants WHERE status = ?")).
+		WithArgs("active").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(6))
+
+	items, total, err := repo.GetMerchants(entity.MerchantFilter{Status: entity.MerchantStatusActive}, 10, 5)
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.Equal(t, "merchant 1", items[0].DisplayName)
+	assert.Equal(t, 6, total)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetMerchants_QueryError(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectQuery(regexp.QuoteMeta(merchantSelect + " ORDER BY id ASC")).
+		WillReturnError(errors.New("db fail"))
+
+	_, _, err := repo.GetMerchants(entity.MerchantFilter{}, 0, 0)
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetMerchantByID_NotFound(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectQuery(regexp.QuoteMeta(merchantSelect + " WHERE id = ?")).
+		WithArgs("99").
+		WillReturnRows(sqlmock.NewRows(merchantColumns))
+
+	_, err := repo.GetMerchantByID("99")
+	var appErr *entity.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, entity.ErrorCodeNotFound, appErr.Code)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCreateMerchant_Success(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO merchants(legal_name, display_name, status, settlement_currency, contact_email, created_at) VALUES (?, ?, ?, ?, ?, ?)")).
+		WithArgs("PT Merchant 13", "merchant 13", "active", "USD", "finance@merchant13.test", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(13, 1))
+
+	m, err := repo.Create(&entity.Merchant{
+		LegalName:          "PT Merchant 13",
+		DisplayName:        "merchant 13",
+		Status:             entity.MerchantStatusActive,
+		SettlementCurrency: "USD",
+		ContactEmail:       "finance@merchant13.test",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "13", m.ID)
+	assert.False(t, m.CreatedAt.IsZero())
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUpdateMerchant_NotFound(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE merchants SET legal_name = ?, display_name = ?, status = ?, settlement_currency = ?, contact_email = ? WHERE id = ?")).
+		WithArgs("PT Merchant 1", "merchant 1", "inactive", "IDR", "finance@merchant1.test", "99").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	_, err := repo.Update(&entity.Merchant{
+		ID:                 "99",
+		LegalName:          "PT Merchant 1",
+		DisplayName:        "merchant 1",
+		Status:             entity.MerchantStatusInactive,
+		SettlementCurrency: "IDR",
+		ContactEmail:       "finance@merchant1.test",
+	})
+	var appErr *entity.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, entity.ErrorCodeNotFound, appErr.Code)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestDeleteMerchant_WithPayments(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM merchants WHERE id = ?")).
+		WithArgs("1").
+		WillReturnError(sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintForeignKey})
+
+	err := repo.Delete("1")
+	var appErr *entity.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, entity.ErrorCodeConflict, appErr.Code)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestDeleteMerchant_Success(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM merchants WHERE id = ?")).
+		WithArgs("13").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.Delete("13"))
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
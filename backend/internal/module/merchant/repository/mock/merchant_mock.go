@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: merchant.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	entity "github.com/fajrinajiseno/mygolangapp/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMerchantRepository is a mock of MerchantRepository interface.
+type MockMerchantRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMerchantRepositoryMockRecorder
+}
+
+// MockMerchantRepositoryMockRecorder is the mock recorder for MockMerchantRepository.
+type MockMerchantRepositoryMockRecorder struct {
+	mock *MockMerchantRepository
+}
+
+// NewMockMerchantRepository creates a new mock instance.
+func NewMockMerchantRepository(ctrl *gomock.Controller) *MockMerchantRepository {
+	mock := &MockMerchantRepository{ctrl: ctrl}
+	mock.recorder = &MockMerchantRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMerchantRepository) EXPECT() *MockMerchantRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m_2 *MockMerchantRepository) Create(m *entity.Merchant) (*entity.Merchant, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Create", m)
+	ret0, _ := ret[0].(*entity.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMerchantRepositoryMockRecorder) Create(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMerchantRepository)(nil).Create), m)
+}
+
+// Delete mocks base method.
+func (m *MockMerchantRepository) Delete(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMerchantRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMerchantRepository)(nil).Delete), id)
+}
+
+// GetMerchantByID mocks base method.
+func (m *MockMerchantRepository) GetMerchantByID(id string) (*entity.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerchantByID", id)
+	ret0, _ := ret[0].(*entity.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerchantByID indicates an expected call of GetMerchantByID.
+func (mr *MockMerchantRepositoryMockRecorder) GetMerchantByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchantByID", reflect.TypeOf((*MockMerchantRepository)(nil).GetMerchantByID), id)
+}
+
+// GetMerchants mocks base method.
+func (m *MockMerchantRepository) GetMerchants(filter entity.MerchantFilter, limit, offset int) ([]*entity.Merchant, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerchants", filter, limit, offset)
+	ret0, _ := ret[0].([]*entity.Merchant)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMerchants indicates an expected call of GetMerchants.
+func (mr *MockMerchantRepositoryMockRecorder) GetMerchants(filter, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchants", reflect.TypeOf((*MockMerchantRepository)(nil).GetMerchants), filter, limit, offset)
+}
+
+// Update mocks base method.
+func (m_2 *MockMerchantRepository) Update(m *entity.Merchant) (*entity.Merchant, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Update", m)
+	ret0, _ := ret[0].(*entity.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockMerchantRepositoryMockRecorder) Update(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMerchantRepository)(nil).Update), m)
+}
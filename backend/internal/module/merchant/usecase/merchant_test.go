@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	azm "github.com/fajrinajiseno/mygolangapp/internal/authz/mock"
+	"github.com/fajrinajiseno/mygolangapp/internal/config"
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	mm "github.com/fajrinajiseno/mygolangapp/internal/module/merchant/repository/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerchant_ListMerchants(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMerchantRepo := mm.NewMockMerchantRepository(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+	ctx := context.WithValue(context.Background(), config.ContextUserID, "1")
+	cs := &entity.User{ID: "1", Role: "cs"}
+
+	t.Run("success", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionMerchantRead).Return(cs, nil)
+		expected := []*entity.Merchant{{ID: "1", DisplayName: "merchant 1", Status: entity.MerchantStatusActive}}
+		mockMerchantRepo.EXPECT().
+			GetMerchants(entity.MerchantFilter{Status: entity.MerchantStatusActive}, 10, 0).
+			Return(expected, 1, nil)
+
+		u := NewMerchantUsecase(mockMerchantRepo, mockAuthorizer)
+
+		items, total, err := u.ListMerchants(ctx, entity.MerchantFilter{Status: entity.MerchantStatusActive}, 10, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, items)
+		assert.Equal(t, 1, total)
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionMerchantRead).Return(cs, nil)
+		u := NewMerchantUsecase(mockMerchantRepo, mockAuthorizer)
+
+		_, _, err := u.ListMerchants(ctx, entity.MerchantFilter{Status: "closed"}, 10, 0)
+		assert.Error(t, err)
+	})
+
+	t.Run("without merchant read", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionMerchantRead).
+			Return(nil, entity.ErrorForbidden("missing permission merchant:read"))
+		u := NewMerchantUsecase(mockMerchantRepo, mockAuthorizer)
+
+		_, _, err := u.ListMerchants(ctx, entity.MerchantFilter{}, 10, 0)
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeForbidden, appErr.Code)
+	})
+}
+
+func TestMerchant_CreateMerchant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMerchantRepo := mm.NewMockMerchantRepository(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+	operation := &entity.User{ID: "u1", Email: "alice@example.com", Role: "operation"}
+	ctx := context.WithValue(context.Background(), config.ContextUserID, "1")
+	input := entity.MerchantInput{
+		LegalName:          " PT Merchant 13 ",
+		DisplayName:        "merchant 13",
+		SettlementCurrency: "usd",
+		ContactEmail:       "finance@merchant13.test",
+	}
+
+	t.Run("defaults to active", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionMerchantManage).Return(operation, nil)
+		mockMerchantRepo.EXPECT().
+			Create(&entity.Merchant{
+				LegalName:          "PT Merchant 13",
+				DisplayName:        "merchant 13",
+				Status:             entity.MerchantStatusActive,
+				SettlementCurrency: "USD",
+				ContactEmail:       "finance@merchant13.test",
+			}).
+			Return(&entity.Merchant{ID: "13"}, nil)
+
+		u := NewMerchantUsecase(mockMerchantRepo, mockAuthorizer)
+
+		m, err := u.CreateMerchant(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, "13", m.ID)
+	})
+
+	t.Run("invalid contact email", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionMerchantManage).Return(operation, nil)
+
+		u := NewMerchantUsecase(mockMerchantRepo, mockAuthorizer)
+
+		invalid := input
+		invalid.ContactEmail = "finance"
+		_, err := u.CreateMerchant(ctx, invalid)
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeValidation, appErr.Code)
+	})
+
+	t.Run("unsupported settlement currency", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionMerchantManage).Return(operation, nil)
+
+		u := NewMerchantUsecase(mockMerchantRepo, mockAuthorizer)
+
+		invalid := input
+		invalid.SettlementCurrency = "XYZ"
+		_, err := u.CreateMerchant(ctx, invalid)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported settlement currency XYZ")
+	})
+
+	t.Run("forbidden for cs", func(t *testing.T) {
+		mockAuthorizer.EXPECT().
+			Authorize(ctx, entity.PermissionMerchantManage).
+			Return(nil, entity.ErrorForbidden("missing permission merchant:manage"))
+
+		u := NewMerchantUsecase(mockMerchantRepo, mockAuthorizer)
+
+		_, err := u.CreateMerchant(ctx, input)
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeForbidden, appErr.Code)
+	})
+}
+
+func TestMerchant_UpdateMerchant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMerchantRepo := mm.NewMockMerchantRepository(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+	operation := &entity.User{ID: "u1", Email: "alice@example.com", Role: "operation"}
+	ctx := context.WithValue(context.Background(), config.ContextUserID, "1")
+
+	t.Run("keeps current status", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionMerchantManage).Return(operation, nil)
+		mockMerchantRepo.EXPECT().
+			GetMerchantByID("1").
+			Return(&entity.Merchant{ID: "1", Status: entity.MerchantStatusInactive}, nil)
+		mockMerchantRepo.EXPECT().
+			Update(&entity.Merchant{
+				ID:                 "1",
+				LegalName:          "PT Merchant 1",
+				DisplayName:        "Merchant One",
+				Status:             entity.MerchantStatusInactive,
+				SettlementCurrency: "IDR",
+				ContactEmail:       "finance@merchant1.test",
+			}).
+			Return(&entity.Merchant{ID: "1", DisplayName: "Merchant One"}, nil)
+
+		u := NewMerchantUsecase(mockMerchantRepo, mockAuthorizer)
+
+		m, err := u.UpdateMerchant(ctx, "1", entity.MerchantInput{
+			LegalName:          "PT Merchant 1",
+			DisplayName:        "Merchant One",
+			SettlementCurrency: "IDR",
+			ContactEmail:       "finance@merchant1.test",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "Merchant One", m.DisplayName)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionMerchantManage).Return(operation, nil)
+		mockMerchantRepo.EXPECT().GetMerchantByID("99").Return(nil, entity.ErrorNotFound("merchant not found"))
+
+		u := NewMerchantUsecase(mockMerchantRepo, mockAuthorizer)
+
+		_, err := u.UpdateMerchant(ctx, "99", entity.MerchantInput{})
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeNotFound, appErr.Code)
+	})
+}
+
+func TestMerchant_DeleteMerchant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMerchantRepo := mm.NewMockMerchantRepository(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+	ctx := context.WithValue(context.Background(), config.ContextUserID, "1")
+
+	mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionMerchantManage).Return(&entity.User{ID: "1", Role: "operation"}, nil)
+	mockMerchantRepo.EXPECT().Delete("1").Return(entity.ErrorConflict("merchant has payments, deactivate it instead"))
+
+	u := NewMerchantUsecase(mockMerchantRepo, mockAuthorizer)
+
+	err := u.DeleteMerchant(ctx, "1")
+	var appErr *entity.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, entity.ErrorCodeConflict, appErr.Code)
+}
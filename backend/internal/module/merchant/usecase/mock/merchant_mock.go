@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: merchant.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/fajrinajiseno/mygolangapp/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMerchantUsecase is a mock of MerchantUsecase interface.
+type MockMerchantUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockMerchantUsecaseMockRecorder
+}
+
+// MockMerchantUsecaseMockRecorder is the mock recorder for MockMerchantUsecase.
+type MockMerchantUsecaseMockRecorder struct {
+	mock *MockMerchantUsecase
+}
+
+// NewMockMerchantUsecase creates a new mock instance.
+func NewMockMerchantUsecase(ctrl *gomock.Controller) *MockMerchantUsecase {
+	mock := &MockMerchantUsecase{ctrl: ctrl}
+	mock.recorder = &MockMerchantUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMerchantUsecase) EXPECT() *MockMerchantUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateMerchant mocks base method.
+func (m *MockMerchantUsecase) CreateMerchant(ctx context.Context, input entity.MerchantInput) (*entity.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMerchant", ctx, input)
+	ret0, _ := ret[0].(*entity.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMerchant indicates an expected call of CreateMerchant.
+func (mr *MockMerchantUsecaseMockRecorder) CreateMerchant(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMerchant", reflect.TypeOf((*MockMerchantUsecase)(nil).CreateMerchant), ctx, input)
+}
+
+// DeleteMerchant mocks base method.
+func (m *MockMerchantUsecase) DeleteMerchant(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMerchant", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMerchant indicates an expected call of DeleteMerchant.
+func (mr *MockMerchantUsecaseMockRecorder) DeleteMerchant(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMerchant", reflect.TypeOf((*MockMerchantUsecase)(nil).DeleteMerchant), ctx, id)
+}
+
+// GetMerchant mocks base method.
+func (m *MockMerchantUsecase) GetMerchant(ctx context.Context, id string) (*entity.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerchant", ctx, id)
+	ret0, _ := ret[0].(*entity.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerchant indicates an expected call of GetMerchant.
+func (mr *MockMerchantUsecaseMockRecorder) GetMerchant(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchant", reflect.TypeOf((*MockMerchantUsecase)(nil).GetMerchant), ctx, id)
+}
+
+// ListMerchants mocks base method.
+func (m *MockMerchantUsecase) ListMerchants(ctx context.Context, filter entity.MerchantFilter, limit, offset int) ([]*entity.Merchant, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMerchants", ctx, filter, limit, offset)
+	ret0, _ := ret[0].([]*entity.Merchant)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListMerchants indicates an expected call of ListMerchants.
+func (mr *MockMerchantUsecaseMockRecorder) ListMerchants(ctx, filter, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMerchants", reflect.TypeOf((*MockMerchantUsecase)(nil).ListMerchants), ctx, filter, limit, offset)
+}
+
+// UpdateMerchant mocks base method.
+func (m *MockMerchantUsecase) UpdateMerchant(ctx context.Context, id string, input entity.MerchantInput) (*entity.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMerchant", ctx, id, input)
+	ret0, _ := ret[0].(*entity.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMerchant indicates an expected call of UpdateMerchant.
+func (mr *MockMerchantUsecaseMockRecorder) UpdateMerchant(ctx, id, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMerchant", reflect.TypeOf((*MockMerchantUsecase)(nil).UpdateMerchant), ctx, id, input)
+}
@@ -3,9 +3,15 @@ package handler
 import (
 	"encoding/json"
 	"fmt"
+	"log"
+	"mime"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/fajrinajiseno/mygolangapp/internal/export"
+	"github.com/fajrinajiseno/mygolangapp/internal/middleware"
 	"github.com/fajrinajiseno/mygolangapp/internal/module/payment/usecase"
 	"github.com/fajrinajiseno/mygolangapp/internal/openapigen"
 	"github.com/fajrinajiseno/mygolangapp/internal/transport"
@@ -24,9 +30,12 @@ func NewPaymentHandler(paymentUC usecase.PaymentUsecase) *PaymentHandler {
 func (a *PaymentHandler) GetDashboardV1Payments(w http.ResponseWriter, r *http.Request, body openapigen.GetDashboardV1PaymentsParams) {
 	limit := 10
 	offset := 0
-	sort := "-created_at"
-	status := ""
-	paymentId := ""
+	filter, sort := paymentFilter(body)
+	var err error
+	if filter.MerchantID, err = merchantScope(r, filter.MerchantID); err != nil {
+		transport.WriteError(w, err)
+		return
+	}
 
 	if body.Limit != nil {
 		limit = *body.Limit
@@ -36,44 +45,203 @@ func (a *PaymentHandler) GetDashboardV1Payments(w http.ResponseWriter, r *http.R
 		offset = *body.Offset
 	}
 
-	if body.Sort != nil {
-		sort = *body.Sort
+	summaryScope := entity.PaymentSummaryScopeAll
+	if body.SummaryScope != nil {
+		summaryScope = entity.PaymentSummaryScope(*body.SummaryScope)
 	}
-
-	if body.Status != nil {
-		status = *body.Status
+	if key := middleware.GetAPIKey(r.Context()); key != nil && key.MerchantID != "" {
+		// the summary over all payments would cover the other merchants
+		summaryScope = entity.PaymentSummaryScopeFiltered
 	}
 
-	if body.Id != nil {
-		paymentId = *body.Id
+	cursor := ""
+	if body.Cursor != nil {
+		cursor = *body.Cursor
 	}
 
-	payments, summary, err := a.paymentUC.ListPayment(status, paymentId, sort, limit, offset)
+	page, err := a.paymentUC.ListPayment(r.Context(), filter, sort, limit, offset, cursor, summaryScope)
 	if err != nil {
 		transport.WriteError(w, err)
 		return
 	}
-	genPayments := make([]openapigen.Payment, len(payments))
-	for i, item := range payments {
-		amountStr := fmt.Sprint(item.Amount)
-		genPayments[i] = openapigen.Payment{
-			Id:        &item.ID,
-			Amount:    &amountStr,
-			CreatedAt: &item.CreatedAt,
-			Merchant:  &item.Merchant,
-			Status:    &item.Status,
-		}
+	genPayments := make([]openapigen.Payment, len(page.Payments))
+	for i, item := range page.Payments {
+		genPayments[i] = toGenPayment(item)
 	}
-	err = json.NewEncoder(w).Encode(openapigen.PaymentListResponse{Meta: &openapigen.PaginationMeta{
+	meta := &openapigen.PaginationMeta{
 		Limit:  body.Limit,
 		Offset: body.Offset,
-		Total:  &summary.TotalByFiler,
-	}, Summary: &openapigen.PaymentSummary{
-		Total:     &summary.Total,
-		Failed:    &summary.TotalFailed,
-		Completed: &summary.TotalCompleted,
-		Pending:   &summary.TotalPending,
-	}, Payments: &genPayments})
+		Total:  &page.Summary.TotalByFiler,
+	}
+	if page.NextCursor != "" {
+		meta.NextCursor = &page.NextCursor
+	}
+	if page.PrevCursor != "" {
+		meta.PrevCursor = &page.PrevCursor
+	}
+	err = json.NewEncoder(w).Encode(openapigen.PaymentListResponse{Meta: meta, Summary: toGenPaymentSummary(page.Summary), Payments: &genPayments})
+	if err != nil {
+		transport.WriteAppError(w, entity.ErrorInternal("internal server error"))
+		return
+	}
+}
+
+// GetDashboardV1PaymentsExport streams the payments matching the list filters as a file.
+// Headers are only sent once the first row is read so that invalid filters still get a
+// JSON error, an error after that can only cut the file short.
+func (a *PaymentHandler) GetDashboardV1PaymentsExport(w http.ResponseWriter, r *http.Request, params openapigen.GetDashboardV1PaymentsExportParams) {
+	format := entity.ExportFormatCSV
+	if params.Format != nil {
+		format = entity.ExportFormat(*params.Format)
+	}
+	filter, sort := paymentFilter(openapigen.GetDashboardV1PaymentsParams{
+		Sort:        params.Sort,
+		Q:           params.Q,
+		Status:      params.Status,
+		Id:          params.Id,
+		MerchantId:  params.MerchantId,
+		Reviewed:    params.Reviewed,
+		CreatedFrom: params.CreatedFrom,
+		CreatedTo:   params.CreatedTo,
+		Currency:    params.Currency,
+		AmountMin:   params.AmountMin,
+		AmountMax:   params.AmountMax,
+	})
+	var err error
+	if filter.MerchantID, err = merchantScope(r, filter.MerchantID); err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	var writer export.Writer
+	start := func() error {
+		filename := fmt.Sprintf("payments-%s.%s", time.Now().UTC().Format("20060102-150405"), format)
+		w.Header().Set("Content-Type", export.ContentType(format))
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+		var err error
+		if writer, err = export.NewWriter(format, w); err != nil {
+			return err
+		}
+		return writer.WriteRow(export.PaymentHeader...)
+	}
+	err = a.paymentUC.ExportPayments(r.Context(), filter, sort, func(p *entity.Payment) error {
+		if writer == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		return writer.WriteRow(export.PaymentRow(p)...)
+	})
+	if err == nil && writer == nil {
+		err = start()
+	}
+	if err != nil {
+		if writer == nil {
+			transport.WriteError(w, err)
+			return
+		}
+		log.Printf("payment export aborted: %v", err)
+		return
+	}
+	if err := writer.Close(); err != nil {
+		log.Printf("payment export aborted: %v", err)
+	}
+}
+
+func (a *PaymentHandler) GetDashboardV1AnalyticsPayments(w http.ResponseWriter, r *http.Request, params openapigen.GetDashboardV1AnalyticsPaymentsParams) {
+	query := entity.PaymentAnalyticsQuery{From: params.From, To: params.To}
+	if params.Interval != nil {
+		query.Interval = entity.AnalyticsInterval(*params.Interval)
+	}
+	if params.Timezone != nil {
+		query.Timezone = *params.Timezone
+	}
+	if params.MerchantId != nil {
+		query.MerchantID = *params.MerchantId
+	}
+	var err error
+	if query.MerchantID, err = merchantScope(r, query.MerchantID); err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	analytics, err := a.paymentUC.PaymentAnalytics(r.Context(), query)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	err = json.NewEncoder(w).Encode(toGenPaymentAnalytics(analytics))
+	if err != nil {
+		transport.WriteAppError(w, entity.ErrorInternal("internal server error"))
+		return
+	}
+}
+
+func (a *PaymentHandler) GetDashboardV1AnalyticsMerchants(w http.ResponseWriter, r *http.Request, params openapigen.GetDashboardV1AnalyticsMerchantsParams) {
+	limit := 10
+	offset := 0
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+	filter := entity.MerchantReportFilter{CreatedFrom: params.From, CreatedTo: params.To}
+	if params.Currency != nil {
+		filter.Currency = *params.Currency
+	}
+	sort := ""
+	if params.Sort != nil {
+		sort = *params.Sort
+	}
+
+	reports, total, err := a.paymentUC.MerchantReport(r.Context(), filter, sort, limit, offset)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	genReports := make([]openapigen.MerchantReport, len(reports))
+	for i, item := range reports {
+		genReports[i] = toGenMerchantReport(item)
+	}
+	meta := &openapigen.PaginationMeta{
+		Limit:  params.Limit,
+		Offset: params.Offset,
+		Total:  &total,
+	}
+	err = json.NewEncoder(w).Encode(openapigen.MerchantReportResponse{Meta: meta, Merchants: &genReports})
+	if err != nil {
+		transport.WriteAppError(w, entity.ErrorInternal("internal server error"))
+		return
+	}
+}
+
+func (a *PaymentHandler) PostDashboardV1Payments(w http.ResponseWriter, r *http.Request, params openapigen.PostDashboardV1PaymentsParams) {
+	var req openapigen.PostDashboardV1PaymentsJSONRequestBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+	idempotencyKey := ""
+	if params.IdempotencyKey != nil {
+		idempotencyKey = *params.IdempotencyKey
+	}
+
+	payment, replayed, err := a.paymentUC.CreatePayment(r.Context(), entity.CreatePaymentInput{
+		MerchantID: req.MerchantId,
+		Amount:     req.Amount,
+		Currency:   req.Currency,
+	}, idempotencyKey)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	if replayed {
+		w.Header().Set("Idempotent-Replayed", "true")
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	genPayment := toGenPayment(payment)
+	err = json.NewEncoder(w).Encode(openapigen.PaymentCreateResponse{Payment: &genPayment})
 	if err != nil {
 		transport.WriteAppError(w, entity.ErrorInternal("internal server error"))
 		return
@@ -81,14 +249,339 @@ func (a *PaymentHandler) GetDashboardV1Payments(w http.ResponseWriter, r *http.R
 }
 
 func (a *PaymentHandler) PutDashboardV1PaymentIdReview(w http.ResponseWriter, r *http.Request, id string) {
-	message, err := a.paymentUC.ReviewPayment(r.Context(), id)
+	var req openapigen.PutDashboardV1PaymentIdReviewJSONRequestBody
+	if !transport.DecodeOptionalJSONBody(w, r, &req) {
+		return
+	}
+	var outcome entity.ReviewOutcome
+	if req.Outcome != nil {
+		outcome = entity.ReviewOutcome(*req.Outcome)
+	}
+	note := ""
+	if req.Note != nil {
+		note = *req.Note
+	}
+
+	review, err := a.paymentUC.ReviewPayment(r.Context(), id, outcome, note)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	message := "Success Review"
+	genReview := toGenReview(review)
+	err = json.NewEncoder(w).Encode(openapigen.PaymentReviewResponse{Message: &message, Review: &genReview})
+	if err != nil {
+		transport.WriteAppError(w, entity.ErrorInternal("internal server error"))
+		return
+	}
+}
+
+func (a *PaymentHandler) PutDashboardV1PaymentIdStatus(w http.ResponseWriter, r *http.Request, id string) {
+	var req openapigen.PutDashboardV1PaymentIdStatusJSONRequestBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+	reason := ""
+	if req.Reason != nil {
+		reason = *req.Reason
+	}
+
+	payment, err := a.paymentUC.UpdatePaymentStatus(r.Context(), id, entity.PaymentStatus(req.Status), reason)
 	if err != nil {
 		transport.WriteError(w, err)
 		return
 	}
-	err = json.NewEncoder(w).Encode(openapigen.PaymentReviewResponse{Message: &message})
+	message := "Success Update Status"
+	genPayment := toGenPayment(payment)
+	err = json.NewEncoder(w).Encode(openapigen.PaymentStatusResponse{Message: &message, Payment: &genPayment})
 	if err != nil {
 		transport.WriteAppError(w, entity.ErrorInternal("internal server error"))
 		return
 	}
 }
+
+func (a *PaymentHandler) PostDashboardV1PaymentIdRefunds(w http.ResponseWriter, r *http.Request, id string) {
+	var req openapigen.PostDashboardV1PaymentIdRefundsJSONRequestBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+	reason := ""
+	if req.Reason != nil {
+		reason = *req.Reason
+	}
+
+	refund, payment, err := a.paymentUC.RefundPayment(r.Context(), id, req.Amount, reason)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	genRefund := toGenRefund(refund, payment.Currency)
+	genPayment := toGenPayment(payment)
+	err = json.NewEncoder(w).Encode(openapigen.PaymentRefundResponse{Refund: &genRefund, Payment: &genPayment})
+	if err != nil {
+		transport.WriteAppError(w, entity.ErrorInternal("internal server error"))
+		return
+	}
+}
+
+func (a *PaymentHandler) GetDashboardV1PaymentId(w http.ResponseWriter, r *http.Request, id string) {
+	payment, events, err := a.paymentUC.GetPayment(r.Context(), id)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	if key := middleware.GetAPIKey(r.Context()); key != nil && !key.AllowsMerchant(payment.MerchantID) {
+		transport.WriteError(w, entity.ErrorNotFound("payment not found"))
+		return
+	}
+	genPayment := toGenPayment(payment)
+	timeline := make([]openapigen.PaymentEvent, len(events))
+	for i, event := range events {
+		timeline[i] = toGenPaymentEvent(event, payment.Currency)
+	}
+	err = json.NewEncoder(w).Encode(openapigen.PaymentDetailResponse{Payment: &genPayment, Timeline: &timeline})
+	if err != nil {
+		transport.WriteAppError(w, entity.ErrorInternal("internal server error"))
+		return
+	}
+}
+
+func (a *PaymentHandler) PostDashboardV1PaymentIdNotes(w http.ResponseWriter, r *http.Request, id string) {
+	var req openapigen.PostDashboardV1PaymentIdNotesJSONRequestBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	note, err := a.paymentUC.AddPaymentNote(r.Context(), id, req.Note)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(w).Encode(openapigen.PaymentNoteResponse{Note: &openapigen.PaymentNote{
+		Id:          &note.ID,
+		AuthorId:    &note.AuthorID,
+		AuthorEmail: &note.AuthorEmail,
+		Note:        &note.Body,
+		CreatedAt:   &note.CreatedAt,
+	}})
+	if err != nil {
+		transport.WriteAppError(w, entity.ErrorInternal("internal server error"))
+		return
+	}
+}
+
+func toGenPaymentSummary(summary *entity.PaymentSummary) *openapigen.PaymentSummary {
+	scope := openapigen.PaymentSummaryScope(summary.Scope)
+	statuses := toGenStatusSummaries(summary.Statuses)
+	return &openapigen.PaymentSummary{
+		Scope:     &scope,
+		Total:     &summary.Total,
+		Failed:    &summary.TotalFailed,
+		Completed: &summary.TotalCompleted,
+		Pending:   &summary.TotalPending,
+		Statuses:  &statuses,
+	}
+}
+
+func toGenMerchantReport(report *entity.MerchantReport) openapigen.MerchantReport {
+	volume := entity.FormatAmount(report.Volume, report.Currency)
+	averageAmount := entity.FormatAmount(report.AverageAmount, report.Currency)
+	return openapigen.MerchantReport{
+		MerchantId:         &report.MerchantID,
+		Merchant:           &report.Merchant,
+		Currency:           &report.Currency,
+		Count:              &report.Count,
+		Succeeded:          &report.Succeeded,
+		Failed:             &report.Failed,
+		SuccessRate:        &report.SuccessRate,
+		FailureRate:        &report.FailureRate,
+		Volume:             &volume,
+		VolumeMinor:        &report.Volume,
+		AverageAmount:      &averageAmount,
+		AverageAmountMinor: &report.AverageAmount,
+		LastPaymentAt:      &report.LastPaymentAt,
+	}
+}
+
+func toGenStatusSummaries(summaries []*entity.PaymentStatusSummary) []openapigen.PaymentStatusSummary {
+	statuses := make([]openapigen.PaymentStatusSummary, len(summaries))
+	for i, item := range summaries {
+		status := openapigen.PaymentStatus(item.Status)
+		amounts := make([]openapigen.CurrencyAmount, len(item.Amounts))
+		for j, amount := range item.Amounts {
+			amountStr := entity.FormatAmount(amount.Amount, amount.Currency)
+			amounts[j] = openapigen.CurrencyAmount{
+				Currency:    &item.Amounts[j].Currency,
+				Amount:      &amountStr,
+				AmountMinor: &item.Amounts[j].Amount,
+			}
+		}
+		statuses[i] = openapigen.PaymentStatusSummary{
+			Status:  &status,
+			Count:   &summaries[i].Count,
+			Amounts: &amounts,
+		}
+	}
+	return statuses
+}
+
+func toGenPaymentAnalytics(analytics *entity.PaymentAnalytics) openapigen.PaymentAnalyticsResponse {
+	interval := openapigen.AnalyticsInterval(analytics.Interval)
+	buckets := make([]openapigen.PaymentBucket, len(analytics.Buckets))
+	for i, item := range analytics.Buckets {
+		statuses := toGenStatusSummaries(item.Statuses)
+		buckets[i] = openapigen.PaymentBucket{
+			Start:    &analytics.Buckets[i].Start,
+			End:      &analytics.Buckets[i].End,
+			Count:    &analytics.Buckets[i].Count,
+			Statuses: &statuses,
+		}
+	}
+	return openapigen.PaymentAnalyticsResponse{
+		Interval: &interval,
+		Timezone: &analytics.Timezone,
+		Buckets:  &buckets,
+	}
+}
+
+// merchantScope limits merchantID to the merchant of a merchant API key, which is refused
+// the payments of other merchants.
+func merchantScope(r *http.Request, merchantID string) (string, error) {
+	key := middleware.GetAPIKey(r.Context())
+	if key == nil || key.MerchantID == "" {
+		return merchantID, nil
+	}
+	if merchantID != "" && merchantID != key.MerchantID {
+		return "", entity.ErrorForbidden("api key is limited to merchant " + key.MerchantID)
+	}
+	return key.MerchantID, nil
+}
+
+// paymentFilter reads the payment list filters and sort, a search is sorted by relevance
+// unless a sort is given.
+func paymentFilter(params openapigen.GetDashboardV1PaymentsParams) (entity.PaymentFilter, string) {
+	sort := "-created_at"
+	filter := entity.PaymentFilter{}
+
+	if params.Q != nil && strings.TrimSpace(*params.Q) != "" {
+		filter.Search = strings.TrimSpace(*params.Q)
+		sort = "relevance"
+	}
+
+	if params.Sort != nil {
+		sort = *params.Sort
+	}
+
+	if params.Status != nil {
+		filter.Status = entity.PaymentStatus(*params.Status)
+	}
+
+	if params.Id != nil {
+		filter.ID = *params.Id
+	}
+
+	if params.MerchantId != nil {
+		filter.MerchantID = *params.MerchantId
+	}
+
+	filter.Reviewed = params.Reviewed
+	filter.CreatedFrom = params.CreatedFrom
+	filter.CreatedTo = params.CreatedTo
+	if params.Currency != nil {
+		filter.Currency = *params.Currency
+	}
+	filter.AmountMin = params.AmountMin
+	filter.AmountMax = params.AmountMax
+	return filter, sort
+}
+
+func toGenPayment(item *entity.Payment) openapigen.Payment {
+	amountStr := entity.FormatAmount(item.Amount, item.Currency)
+	refundedAmountStr := entity.FormatAmount(item.RefundedAmount, item.Currency)
+	reviewed := item.Review != nil
+	status := openapigen.PaymentStatus(item.Status)
+	p := openapigen.Payment{
+		Id:             &item.ID,
+		Amount:         &amountStr,
+		AmountMinor:    &item.Amount,
+		Currency:       &item.Currency,
+		CreatedAt:      &item.CreatedAt,
+		MerchantId:     &item.MerchantID,
+		Merchant:       &item.Merchant,
+		Status:         &status,
+		Reviewed:       &reviewed,
+		RefundedAmount: &refundedAmountStr,
+	}
+	if item.Review != nil {
+		review := toGenReview(item.Review)
+		p.Review = &review
+	}
+	if item.Refunds != nil {
+		refunds := make([]openapigen.Refund, len(item.Refunds))
+		for i, refund := range item.Refunds {
+			refunds[i] = toGenRefund(refund, item.Currency)
+		}
+		p.Refunds = &refunds
+	}
+	return p
+}
+
+func toGenRefund(refund *entity.Refund, currency string) openapigen.Refund {
+	amountStr := entity.FormatAmount(refund.Amount, currency)
+	return openapigen.Refund{
+		Id:          &refund.ID,
+		Amount:      &amountStr,
+		AmountMinor: &refund.Amount,
+		Reason:      &refund.Reason,
+		CreatedBy:   &refund.CreatedBy,
+		CreatedAt:   &refund.CreatedAt,
+	}
+}
+
+func toGenReview(review *entity.PaymentReview) openapigen.PaymentReview {
+	outcome := openapigen.PaymentReviewOutcome(review.Outcome)
+	return openapigen.PaymentReview{
+		Id:            &review.ID,
+		ReviewerId:    &review.ReviewerID,
+		ReviewerEmail: &review.ReviewerEmail,
+		Outcome:       &outcome,
+		Note:          &review.Note,
+		ReviewedAt:    &review.ReviewedAt,
+	}
+}
+
+func toGenPaymentEvent(event *entity.PaymentEvent, currency string) openapigen.PaymentEvent {
+	eventType := openapigen.PaymentEventType(event.Type)
+	e := openapigen.PaymentEvent{
+		Type: &eventType,
+		Id:   &event.ID,
+		At:   &event.At,
+	}
+	if event.ActorID != "" {
+		e.ActorId = &event.ActorID
+		e.ActorEmail = &event.ActorEmail
+	}
+	if event.FromStatus != "" {
+		from := openapigen.PaymentStatus(event.FromStatus)
+		to := openapigen.PaymentStatus(event.ToStatus)
+		e.FromStatus = &from
+		e.ToStatus = &to
+	}
+	if event.Outcome != "" {
+		outcome := openapigen.PaymentEventOutcome(event.Outcome)
+		e.Outcome = &outcome
+	}
+	if event.Type == entity.PaymentEventCreated || event.Type == entity.PaymentEventRefunded {
+		amountStr := entity.FormatAmount(event.Amount, currency)
+		e.Amount = &amountStr
+		e.AmountMinor = &event.Amount
+	}
+	if event.Note != "" {
+		e.Note = &event.Note
+	}
+	return e
+}
@@ -6,6 +6,7 @@ package mock
 
 import (
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/fajrinajiseno/mygolangapp/internal/entity"
 	gomock "github.com/golang/mock/gomock"
@@ -34,33 +35,227 @@ func (m *MockPaymentRepository) EXPECT() *MockPaymentRepositoryMockRecorder {
 	return m.recorder
 }
 
-// GetPayments mocks base method.
-func (m *MockPaymentRepository) GetPayments(status, id, sortExpr string, limit, offset int) ([]*entity.Payment, *entity.PaymentSummary, error) {
+// AddNote mocks base method.
+func (m *MockPaymentRepository) AddNote(note *entity.PaymentNote) (*entity.PaymentNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddNote", note)
+	ret0, _ := ret[0].(*entity.PaymentNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddNote indicates an expected call of AddNote.
+func (mr *MockPaymentRepositoryMockRecorder) AddNote(note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNote", reflect.TypeOf((*MockPaymentRepository)(nil).AddNote), note)
+}
+
+// Create mocks base method.
+func (m *MockPaymentRepository) Create(p *entity.Payment, idempotencyKey *entity.IdempotencyKey) (*entity.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", p, idempotencyKey)
+	ret0, _ := ret[0].(*entity.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPaymentRepositoryMockRecorder) Create(p, idempotencyKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPaymentRepository)(nil).Create), p, idempotencyKey)
+}
+
+// CreateRefund mocks base method.
+func (m *MockPaymentRepository) CreateRefund(refund *entity.Refund, from, to entity.PaymentStatus) (*entity.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRefund", refund, from, to)
+	ret0, _ := ret[0].(*entity.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRefund indicates an expected call of CreateRefund.
+func (mr *MockPaymentRepositoryMockRecorder) CreateRefund(refund, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefund", reflect.TypeOf((*MockPaymentRepository)(nil).CreateRefund), refund, from, to)
+}
+
+// GetIdempotencyKey mocks base method.
+func (m *MockPaymentRepository) GetIdempotencyKey(userID, key string) (*entity.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdempotencyKey", userID, key)
+	ret0, _ := ret[0].(*entity.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdempotencyKey indicates an expected call of GetIdempotencyKey.
+func (mr *MockPaymentRepositoryMockRecorder) GetIdempotencyKey(userID, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdempotencyKey", reflect.TypeOf((*MockPaymentRepository)(nil).GetIdempotencyKey), userID, key)
+}
+
+// GetMerchantReport mocks base method.
+func (m *MockPaymentRepository) GetMerchantReport(filter entity.MerchantReportFilter, sortExpr string, limit, offset int) ([]*entity.MerchantReport, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPayments", status, id, sortExpr, limit, offset)
-	ret0, _ := ret[0].([]*entity.Payment)
-	ret1, _ := ret[1].(*entity.PaymentSummary)
+	ret := m.ctrl.Call(m, "GetMerchantReport", filter, sortExpr, limit, offset)
+	ret0, _ := ret[0].([]*entity.MerchantReport)
+	ret1, _ := ret[1].(int)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
+// GetMerchantReport indicates an expected call of GetMerchantReport.
+func (mr *MockPaymentRepositoryMockRecorder) GetMerchantReport(filter, sortExpr, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchantReport", reflect.TypeOf((*MockPaymentRepository)(nil).GetMerchantReport), filter, sortExpr, limit, offset)
+}
+
+// GetPaymentByID mocks base method.
+func (m *MockPaymentRepository) GetPaymentByID(id string) (*entity.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentByID", id)
+	ret0, _ := ret[0].(*entity.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentByID indicates an expected call of GetPaymentByID.
+func (mr *MockPaymentRepositoryMockRecorder) GetPaymentByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentByID", reflect.TypeOf((*MockPaymentRepository)(nil).GetPaymentByID), id)
+}
+
+// GetPaymentSeries mocks base method.
+func (m *MockPaymentRepository) GetPaymentSeries(filter entity.PaymentFilter, bounds []time.Time) ([]*entity.PaymentBucket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentSeries", filter, bounds)
+	ret0, _ := ret[0].([]*entity.PaymentBucket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentSeries indicates an expected call of GetPaymentSeries.
+func (mr *MockPaymentRepositoryMockRecorder) GetPaymentSeries(filter, bounds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentSeries", reflect.TypeOf((*MockPaymentRepository)(nil).GetPaymentSeries), filter, bounds)
+}
+
+// GetPayments mocks base method.
+func (m *MockPaymentRepository) GetPayments(filter entity.PaymentFilter, sortExpr string, limit, offset int, cursor *entity.PageCursor, summaryScope entity.PaymentSummaryScope) (*entity.PaymentPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayments", filter, sortExpr, limit, offset, cursor, summaryScope)
+	ret0, _ := ret[0].(*entity.PaymentPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // GetPayments indicates an expected call of GetPayments.
-func (mr *MockPaymentRepositoryMockRecorder) GetPayments(status, id, sortExpr, limit, offset interface{}) *gomock.Call {
+func (mr *MockPaymentRepositoryMockRecorder) GetPayments(filter, sortExpr, limit, offset, cursor, summaryScope interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayments", reflect.TypeOf((*MockPaymentRepository)(nil).GetPayments), filter, sortExpr, limit, offset, cursor, summaryScope)
+}
+
+// ListNotes mocks base method.
+func (m *MockPaymentRepository) ListNotes(paymentID string) ([]*entity.PaymentNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotes", paymentID)
+	ret0, _ := ret[0].([]*entity.PaymentNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotes indicates an expected call of ListNotes.
+func (mr *MockPaymentRepositoryMockRecorder) ListNotes(paymentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotes", reflect.TypeOf((*MockPaymentRepository)(nil).ListNotes), paymentID)
+}
+
+// ListRefunds mocks base method.
+func (m *MockPaymentRepository) ListRefunds(paymentID string) ([]*entity.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRefunds", paymentID)
+	ret0, _ := ret[0].([]*entity.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRefunds indicates an expected call of ListRefunds.
+func (mr *MockPaymentRepositoryMockRecorder) ListRefunds(paymentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRefunds", reflect.TypeOf((*MockPaymentRepository)(nil).ListRefunds), paymentID)
+}
+
+// ListReviews mocks base method.
+func (m *MockPaymentRepository) ListReviews(paymentID string) ([]*entity.PaymentReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReviews", paymentID)
+	ret0, _ := ret[0].([]*entity.PaymentReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReviews indicates an expected call of ListReviews.
+func (mr *MockPaymentRepositoryMockRecorder) ListReviews(paymentID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayments", reflect.TypeOf((*MockPaymentRepository)(nil).GetPayments), status, id, sortExpr, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReviews", reflect.TypeOf((*MockPaymentRepository)(nil).ListReviews), paymentID)
+}
+
+// ListStatusHistory mocks base method.
+func (m *MockPaymentRepository) ListStatusHistory(paymentID string) ([]*entity.PaymentStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStatusHistory", paymentID)
+	ret0, _ := ret[0].([]*entity.PaymentStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStatusHistory indicates an expected call of ListStatusHistory.
+func (mr *MockPaymentRepositoryMockRecorder) ListStatusHistory(paymentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStatusHistory", reflect.TypeOf((*MockPaymentRepository)(nil).ListStatusHistory), paymentID)
 }
 
 // Review mocks base method.
-func (m *MockPaymentRepository) Review(id string) (string, error) {
+func (m *MockPaymentRepository) Review(id, reviewerID string, outcome entity.ReviewOutcome, note string) (*entity.PaymentReview, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Review", id)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "Review", id, reviewerID, outcome, note)
+	ret0, _ := ret[0].(*entity.PaymentReview)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Review indicates an expected call of Review.
-func (mr *MockPaymentRepositoryMockRecorder) Review(id interface{}) *gomock.Call {
+func (mr *MockPaymentRepositoryMockRecorder) Review(id, reviewerID, outcome, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Review", reflect.TypeOf((*MockPaymentRepository)(nil).Review), id, reviewerID, outcome, note)
+}
+
+// StreamPayments mocks base method.
+func (m *MockPaymentRepository) StreamPayments(filter entity.PaymentFilter, sortExpr string, fn func(*entity.Payment) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamPayments", filter, sortExpr, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamPayments indicates an expected call of StreamPayments.
+func (mr *MockPaymentRepositoryMockRecorder) StreamPayments(filter, sortExpr, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamPayments", reflect.TypeOf((*MockPaymentRepository)(nil).StreamPayments), filter, sortExpr, fn)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockPaymentRepository) UpdateStatus(id string, from, to entity.PaymentStatus, changedBy, reason string) (*entity.PaymentStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", id, from, to, changedBy, reason)
+	ret0, _ := ret[0].(*entity.PaymentStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockPaymentRepositoryMockRecorder) UpdateStatus(id, from, to, changedBy, reason interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Review", reflect.TypeOf((*MockPaymentRepository)(nil).Review), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockPaymentRepository)(nil).UpdateStatus), id, from, to, changedBy, reason)
 }
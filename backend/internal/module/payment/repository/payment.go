@@ -2,17 +2,36 @@ package repository
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
+	"unicode"
 
+	"github.com/fajrinajiseno/mygolangapp/internal/database"
 	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/mattn/go-sqlite3"
 )
 
 //go:generate mockgen -source payment.go -destination mock/payment_mock.go -package=mock
 type PaymentRepository interface {
-	GetPayments(status, id string, sortExpr string, limit, offset int) ([]*entity.Payment, *entity.PaymentSummary, error)
-	Review(id string) (string, error)
+	GetPayments(filter entity.PaymentFilter, sortExpr string, limit, offset int, cursor *entity.PageCursor, summaryScope entity.PaymentSummaryScope) (*entity.PaymentPage, error)
+	StreamPayments(filter entity.PaymentFilter, sortExpr string, fn func(p *entity.Payment) error) error
+	GetPaymentSeries(filter entity.PaymentFilter, bounds []time.Time) ([]*entity.PaymentBucket, error)
+	GetMerchantReport(filter entity.MerchantReportFilter, sortExpr string, limit, offset int) ([]*entity.MerchantReport, int, error)
+	GetPaymentByID(id string) (*entity.Payment, error)
+	Create(p *entity.Payment, idempotencyKey *entity.IdempotencyKey) (*entity.Payment, error)
+	GetIdempotencyKey(userID, key string) (*entity.IdempotencyKey, error)
+	Review(id, reviewerID string, outcome entity.ReviewOutcome, note string) (*entity.PaymentReview, error)
+	UpdateStatus(id string, from, to entity.PaymentStatus, changedBy, reason string) (*entity.PaymentStatusChange, error)
+	CreateRefund(refund *entity.Refund, from, to entity.PaymentStatus) (*entity.Refund, error)
+	ListRefunds(paymentID string) ([]*entity.Refund, error)
+	ListStatusHistory(paymentID string) ([]*entity.PaymentStatusChange, error)
+	ListReviews(paymentID string) ([]*entity.PaymentReview, error)
+	AddNote(note *entity.PaymentNote) (*entity.PaymentNote, error)
+	ListNotes(paymentID string) ([]*entity.PaymentNote, error)
 }
 
 type Payment struct {
@@ -23,51 +42,247 @@ func NewPaymentRepo(db *sql.DB) *Payment {
 	return &Payment{db: db}
 }
 
-func (r *Payment) GetPayments(status, id string, sortExpr string, limit, offset int) ([]*entity.Payment, *entity.PaymentSummary, error) {
-	// whitelist sort columns to avoid SQL injection
-	allowedSort := map[string]string{
-		"amount":     "amount",
-		"created_at": "created_at",
+const paymentSelect = `SELECT p.id, p.merchant_id, m.display_name, p.amount, p.currency, p.status, p.created_at,
+	(SELECT COALESCE(SUM(amount), 0) FROM refunds WHERE payment_id = p.id),
+	r.id, r.reviewer_id, u.email, r.outcome, r.note, r.reviewed_at
+	FROM payments p
+	JOIN merchants m ON m.id = p.merchant_id
+	LEFT JOIN payment_reviews r ON r.id = (SELECT MAX(id) FROM payment_reviews WHERE payment_id = p.id)
+	LEFT JOIN users u ON u.id = r.reviewer_id`
+
+// GetPayments returns a page of the payments matching the filter together with a summary
+// of either all payments or only the filtered ones. Without a cursor the page starts at
+// offset, with one it starts right after (or ends right before) the cursor key.
+func (r *Payment) GetPayments(filter entity.PaymentFilter, sortExpr string, limit, offset int, cursor *entity.PageCursor, summaryScope entity.PaymentSummaryScope) (*entity.PaymentPage, error) {
+	keys, err := parseSort(sortExpr, "created_at", paymentSortColumns, "id")
+	if err != nil {
+		return nil, err
+	}
+	if filter.Search == "" && hasField(keys, "relevance") {
+		return nil, entity.ErrorValidation("sorting by relevance requires q")
+	}
+	reversed := cursor != nil && cursor.Before
+
+	where, whereArgs := paymentWhere(filter)
+	q := paymentSelect + where
+	args := append([]interface{}{}, whereArgs...)
+	if cursor != nil {
+		keyset, keysetArgs, err := keysetWhere(keys, cursor.Key, reversed)
+		if err != nil {
+			return nil, err
+		}
+		if where == "" {
+			q += " WHERE " + keyset
+		} else {
+			q += " AND " + keyset
+		}
+		args = append(args, keysetArgs...)
+	}
+	q += orderBy(keys, reversed)
+	if limit > 0 {
+		// one extra row tells whether there is another page
+		q += " LIMIT ?"
+		args = append(args, limit+1)
+	}
+	if offset > 0 {
+		q += " OFFSET ?"
+		args = append(args, offset)
+	}
+
+	rows, err := r.db.Query(q, args...)
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	defer rows.Close()
+	res := []*entity.Payment{}
+	for rows.Next() {
+		p, err := scanPayment(rows)
+		if err != nil {
+			return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		}
+		res = append(res, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+
+	hasMore := limit > 0 && len(res) > limit
+	if hasMore {
+		res = res[:limit]
+	}
+	if reversed {
+		for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+			res[i], res[j] = res[j], res[i]
+		}
+	}
+	page := &entity.PaymentPage{Payments: res}
+	if len(res) > 0 {
+		hasNext, hasPrev := hasMore, offset > 0 || cursor != nil
+		if reversed {
+			hasNext, hasPrev = true, hasMore
+		}
+		if hasNext {
+			page.NextKey = keyOf(keys, res[len(res)-1])
+		}
+		if hasPrev {
+			page.PrevKey = keyOf(keys, res[0])
+		}
 	}
 
-	// default sort
-	col := "created_at"
-	dir := "ASC"
+	if summaryScope == entity.PaymentSummaryScopeFiltered {
+		summary, err := r.getSummary(where, whereArgs)
+		if err != nil {
+			return nil, err
+		}
+		summary.Scope = summaryScope
+		summary.TotalByFiler = summary.Total
+		page.Summary = summary
+		return page, nil
+	}
+
+	summary, err := r.getSummary("", nil)
+	if err != nil {
+		return nil, err
+	}
+	summary.Scope = entity.PaymentSummaryScopeAll
+	row := r.db.QueryRow("SELECT COUNT(1) FROM payments p"+where, whereArgs...)
+	if err := row.Scan(&summary.TotalByFiler); err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	page.Summary = summary
+	return page, nil
+}
 
-	// parse sort expression (e.g., "-created_at" => DESC)
-	if sortExpr != "" {
-		s := strings.TrimSpace(sortExpr)
-		if strings.HasPrefix(s, "-") {
-			dir = "DESC"
-			s = strings.TrimPrefix(s, "-")
+// StreamPayments calls fn for every payment matching the filter in sort order, reading one
+// row at a time. An error returned by fn stops the iteration and is returned as is.
+func (r *Payment) StreamPayments(filter entity.PaymentFilter, sortExpr string, fn func(p *entity.Payment) error) error {
+	keys, err := parseSort(sortExpr, "created_at", paymentSortColumns, "id")
+	if err != nil {
+		return err
+	}
+	if filter.Search == "" && hasField(keys, "relevance") {
+		return entity.ErrorValidation("sorting by relevance requires q")
+	}
+	where, args := paymentWhere(filter)
+	rows, err := r.db.Query(paymentSelect+where+orderBy(keys, false), args...)
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		p, err := scanPayment(rows)
+		if err != nil {
+			return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
 		}
-		if v, ok := allowedSort[s]; ok {
-			col = v
+		if err := fn(p); err != nil {
+			return err
 		}
 	}
+	if err := rows.Err(); err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return nil
+}
 
-	q := "SELECT id, merchant, amount, status, created_at FROM payments"
-	where := []string{}
-	args := []interface{}{}
-	qt := "SELECT COUNT(1) FROM payments"
-	argsT := []interface{}{}
-	if status != "" {
-		where = append(where, "status = ?")
-		args = append(args, status)
-		argsT = append(argsT, status)
+// seriesSlot is the width in seconds of the UTC slots payments are grouped into before
+// they are assigned to buckets. Every timezone offset is a multiple of 15 minutes, so a
+// bucket bound in any timezone falls on a slot bound and no slot spans two buckets.
+const seriesSlot = 15 * 60
+
+// GetPaymentSeries counts and sums the payments matching the filter per status in each of
+// the buckets delimited by consecutive bounds, bounds[0] up to bounds[1] being the first.
+func (r *Payment) GetPaymentSeries(filter entity.PaymentFilter, bounds []time.Time) ([]*entity.PaymentBucket, error) {
+	buckets := make([]*entity.PaymentBucket, 0, len(bounds))
+	for i := 0; i+1 < len(bounds); i++ {
+		buckets = append(buckets, &entity.PaymentBucket{Start: bounds[i], End: bounds[i+1], Statuses: []*entity.PaymentStatusSummary{}})
+	}
+	if len(buckets) == 0 {
+		return buckets, nil
 	}
-	if id != "" {
-		where = append(where, "id = ?")
-		args = append(args, id)
-		argsT = append(argsT, id)
+	from, to := bounds[0], bounds[len(bounds)-1]
+	filter.CreatedFrom, filter.CreatedTo = &from, &to
+	where, args := paymentWhere(filter)
+	rows, err := r.db.Query(fmt.Sprintf("SELECT CAST(strftime('%%s', p.created_at) AS INTEGER) / %[1]d * %[1]d AS slot, p.status, p.currency, COUNT(1), COALESCE(SUM(p.amount), 0) FROM payments p", seriesSlot)+where+
+		" GROUP BY slot, p.status, p.currency ORDER BY slot, p.status, p.currency", args...)
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			slot   int64
+			status entity.PaymentStatus
+			amount entity.CurrencyAmount
+			count  int
+		)
+		if err := rows.Scan(&slot, &status, &amount.Currency, &count, &amount.Amount); err != nil {
+			return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		}
+		at := time.Unix(slot, 0)
+		i := sort.Search(len(buckets), func(i int) bool { return buckets[i].End.After(at) })
+		if i == len(buckets) {
+			continue
+		}
+		addToBucket(buckets[i], status, count, amount)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return buckets, nil
+}
+
+// addToBucket adds count payments summing to amount in status to the bucket, keeping its
+// statuses and their amounts sorted.
+func addToBucket(bucket *entity.PaymentBucket, status entity.PaymentStatus, count int, amount entity.CurrencyAmount) {
+	bucket.Count += count
+	i := sort.Search(len(bucket.Statuses), func(i int) bool { return bucket.Statuses[i].Status >= status })
+	if i == len(bucket.Statuses) || bucket.Statuses[i].Status != status {
+		bucket.Statuses = append(bucket.Statuses, nil)
+		copy(bucket.Statuses[i+1:], bucket.Statuses[i:])
+		bucket.Statuses[i] = &entity.PaymentStatusSummary{Status: status, Amounts: []entity.CurrencyAmount{}}
+	}
+	summary := bucket.Statuses[i]
+	summary.Count += count
+	j := sort.Search(len(summary.Amounts), func(j int) bool { return summary.Amounts[j].Currency >= amount.Currency })
+	if j < len(summary.Amounts) && summary.Amounts[j].Currency == amount.Currency {
+		summary.Amounts[j].Amount += amount.Amount
+		return
+	}
+	summary.Amounts = append(summary.Amounts, entity.CurrencyAmount{})
+	copy(summary.Amounts[j+1:], summary.Amounts[j:])
+	summary.Amounts[j] = amount
+}
+
+const (
+	reportSucceeded = "SUM(CASE WHEN p.status IN ('completed', 'partially_refunded', 'refunded') THEN 1 ELSE 0 END)"
+	reportFailed    = "SUM(CASE WHEN p.status = 'failed' THEN 1 ELSE 0 END)"
+)
+
+// GetMerchantReport aggregates the payments matching the filter per merchant and currency
+// in a single GROUP BY query, the number of groups is counted alongside by a window. The
+// sort defaults to the highest failure rate, ties are broken by merchant id and currency.
+func (r *Payment) GetMerchantReport(filter entity.MerchantReportFilter, sortExpr string, limit, offset int) ([]*entity.MerchantReport, int, error) {
+	keys, err := parseSort(sortExpr, "-failure_rate", merchantReportSortColumns, "merchant_id")
+	if err != nil {
+		return nil, 0, err
 	}
-	if len(where) > 0 {
-		q += " WHERE " + strings.Join(where, " AND ")
-		qt += " WHERE " + strings.Join(where, " AND ")
+	if !hasField(keys, "currency") {
+		keys = append(keys, sortKey{field: "currency", column: merchantReportSortColumns["currency"]})
 	}
-	if col != "" && dir != "" {
-		q += fmt.Sprintf(" ORDER BY %s %s", col, dir)
+	where, whereArgs := paymentWhere(entity.PaymentFilter{CreatedFrom: filter.CreatedFrom, CreatedTo: filter.CreatedTo})
+	if filter.Currency != "" {
+		if where == "" {
+			where = " WHERE p.currency = ?"
+		} else {
+			where += " AND p.currency = ?"
+		}
+		whereArgs = append(whereArgs, filter.Currency)
 	}
+	args := append([]interface{}{}, whereArgs...)
+	q := "SELECT p.merchant_id, m.display_name, p.currency, COUNT(1), " + reportSucceeded + ", " + reportFailed + ", SUM(p.amount)," +
+		" strftime('%Y-%m-%dT%H:%M:%fZ', MAX(julianday(p.created_at))), COUNT(1) OVER ()" +
+		" FROM payments p JOIN merchants m ON m.id = p.merchant_id" + where +
+		" GROUP BY p.merchant_id, m.display_name, p.currency" + orderBy(keys, false)
 	if limit > 0 {
 		q += " LIMIT ?"
 		args = append(args, limit)
@@ -79,74 +294,487 @@ func (r *Payment) GetPayments(status, id string, sortExpr string, limit, offset
 
 	rows, err := r.db.Query(q, args...)
 	if err != nil {
-		return nil, nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		return nil, 0, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
 	}
 	defer rows.Close()
-	res := []*entity.Payment{}
+	res := []*entity.MerchantReport{}
+	total := 0
 	for rows.Next() {
-		var p entity.Payment
-		if err := rows.Scan(&p.ID, &p.Merchant, &p.Amount, &p.Status, &p.CreatedAt); err != nil {
-			return nil, nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		var (
+			report      entity.MerchantReport
+			lastPayment string
+		)
+		if err := rows.Scan(&report.MerchantID, &report.Merchant, &report.Currency, &report.Count, &report.Succeeded, &report.Failed,
+			&report.Volume, &lastPayment, &total); err != nil {
+			return nil, 0, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		}
+		if report.LastPaymentAt, err = time.Parse(time.RFC3339Nano, lastPayment); err != nil {
+			return nil, 0, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		}
+		report.SuccessRate = float64(report.Succeeded) / float64(report.Count)
+		report.FailureRate = float64(report.Failed) / float64(report.Count)
+		report.AverageAmount = report.Volume / int64(report.Count)
+		res = append(res, &report)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if len(res) == 0 && offset > 0 {
+		// the window count comes with the rows, a page past the end has to count apart
+		row := r.db.QueryRow("SELECT COUNT(1) FROM (SELECT 1 FROM payments p"+where+" GROUP BY p.merchant_id, p.currency)", whereArgs...)
+		if err := row.Scan(&total); err != nil {
+			return nil, 0, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		}
+	}
+	return res, total, nil
+}
+
+// paymentWhere builds the WHERE clause shared by the payment list, its count and its summary.
+// A search also joins the payment_search index, which the relevance sort ranks by.
+func paymentWhere(filter entity.PaymentFilter) (string, []interface{}) {
+	join := ""
+	where := []string{}
+	args := []interface{}{}
+	if filter.Search != "" {
+		join = " JOIN payment_search ON payment_search.rowid = p.id"
+		where = append(where, "payment_search MATCH ?")
+		args = append(args, searchQuery(filter.Search))
+	}
+	if filter.Status != "" {
+		where = append(where, "p.status = ?")
+		args = append(args, filter.Status)
+	}
+	if filter.ID != "" {
+		where = append(where, "p.id = ?")
+		args = append(args, filter.ID)
+	}
+	if filter.MerchantID != "" {
+		where = append(where, "p.merchant_id = ?")
+		args = append(args, filter.MerchantID)
+	}
+	if filter.Currency != "" {
+		where = append(where, "p.currency = ?")
+		args = append(args, filter.Currency)
+	}
+	if filter.CreatedFrom != nil {
+		where = append(where, "julianday(p.created_at) >= julianday(?)")
+		args = append(args, filter.CreatedFrom.UTC().Format(time.RFC3339Nano))
+	}
+	if filter.CreatedTo != nil {
+		where = append(where, "julianday(p.created_at) < julianday(?)")
+		args = append(args, filter.CreatedTo.UTC().Format(time.RFC3339Nano))
+	}
+	if filter.AmountMin != nil {
+		where = append(where, "p.amount >= ?")
+		args = append(args, *filter.AmountMin)
+	}
+	if filter.AmountMax != nil {
+		where = append(where, "p.amount <= ?")
+		args = append(args, *filter.AmountMax)
+	}
+	if filter.Reviewed != nil {
+		reviewed := "EXISTS (SELECT 1 FROM payment_reviews WHERE payment_id = p.id)"
+		if !*filter.Reviewed {
+			reviewed = "NOT " + reviewed
 		}
-		res = append(res, &p)
+		where = append(where, reviewed)
+	}
+	if len(where) == 0 {
+		return "", args
 	}
+	return join + " WHERE " + strings.Join(where, " AND "), args
+}
 
-	var totalByFiler int
+// searchQuery turns free text into an FTS5 query matching every word as a prefix, so
+// "tok sum" finds "Toko Sumber Rejeki". Words are quoted to keep FTS5 operators out.
+func searchQuery(text string) string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = `"` + word + `"*`
+	}
+	return strings.Join(terms, " ")
+}
 
-	row := r.db.QueryRow(qt, argsT...)
-	err = row.Scan(&totalByFiler)
+func (r *Payment) GetPaymentByID(id string) (*entity.Payment, error) {
+	p, err := scanPayment(r.db.QueryRow(paymentSelect+" WHERE p.id = ?", id))
 	if err != nil {
-		return nil, nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrorNotFound("payment not found")
+		}
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
 	}
+	return p, nil
+}
 
-	total, totalCompleted, totalFailed, totalPending := getSummary(r.db)
+// Create inserts a new payment. When an idempotency key is given it is stored in the same
+// transaction together with the created payment as the response to replay, a key that
+// already exists for the user returns a conflict error.
+func (r *Payment) Create(p *entity.Payment, idempotencyKey *entity.IdempotencyKey) (*entity.Payment, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	defer tx.Rollback()
 
-	return res, &entity.PaymentSummary{
-		TotalByFiler:   totalByFiler,
-		Total:          total,
-		TotalCompleted: totalCompleted,
-		TotalFailed:    totalFailed,
-		TotalPending:   totalPending,
-	}, nil
+	created := *p
+	created.CreatedAt = time.Now().UTC()
+	res, err := tx.Exec("INSERT INTO payments(merchant_id, amount, currency, status, created_at) VALUES (?, ?, ?, ?, ?)",
+		created.MerchantID, created.Amount, created.Currency, string(created.Status), created.CreatedAt)
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	paymentID, err := res.LastInsertId()
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	created.ID = fmt.Sprint(paymentID)
+
+	if idempotencyKey != nil {
+		response, err := json.Marshal(created)
+		if err != nil {
+			return nil, entity.WrapError(err, entity.ErrorCodeInternal, "internal error")
+		}
+		_, err = tx.Exec("INSERT INTO idempotency_keys(idempotency_key, user_id, request_hash, payment_id, response, created_at) VALUES (?, ?, ?, ?, ?, ?)",
+			idempotencyKey.Key, idempotencyKey.UserID, idempotencyKey.RequestHash, created.ID, string(response), created.CreatedAt)
+		if err != nil {
+			var sqliteErr sqlite3.Error
+			if errors.As(err, &sqliteErr) && sqliteErr.Code == sqlite3.ErrConstraint {
+				return nil, entity.ErrorConflict("idempotency key already used")
+			}
+			return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return &created, nil
 }
 
-func (r *Payment) Review(id string) (string, error) {
+func (r *Payment) GetIdempotencyKey(userID, key string) (*entity.IdempotencyKey, error) {
+	row := r.db.QueryRow("SELECT idempotency_key, user_id, request_hash, payment_id, response, created_at FROM idempotency_keys WHERE user_id = ? AND idempotency_key = ?", userID, key)
+	var k entity.IdempotencyKey
+	var response string
+	if err := row.Scan(&k.Key, &k.UserID, &k.RequestHash, &k.PaymentID, &response, &k.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrorNotFound("idempotency key not found")
+		}
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	k.Response = []byte(response)
+	return &k, nil
+}
+
+// Review records a review of the payment by the given reviewer.
+func (r *Payment) Review(id, reviewerID string, outcome entity.ReviewOutcome, note string) (*entity.PaymentReview, error) {
 	row := r.db.QueryRow("SELECT id FROM payments WHERE id = ?", id)
 	var p entity.Payment
 	if err := row.Scan(&p.ID); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return "", entity.ErrorNotFound("payment not found")
+			return nil, entity.ErrorNotFound("payment not found")
 		}
-		return "", entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+
+	reviewedAt := time.Now().UTC()
+	res, err := r.db.Exec("INSERT INTO payment_reviews(payment_id, reviewer_id, outcome, note, reviewed_at) VALUES (?, ?, ?, ?, ?)",
+		p.ID, reviewerID, string(outcome), note, reviewedAt)
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	reviewID, err := res.LastInsertId()
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
 	}
-	return "Success Review", nil
+	return &entity.PaymentReview{
+		ID:         fmt.Sprint(reviewID),
+		PaymentID:  p.ID,
+		ReviewerID: reviewerID,
+		Outcome:    outcome,
+		Note:       note,
+		ReviewedAt: reviewedAt,
+	}, nil
 }
 
-func getSummary(db *sql.DB) (int, int, int, int) {
-	var total, totalCompleted, totalFailed, totalPending int
+// UpdateStatus moves the payment from one status to another and records the transition.
+// The update only applies while the payment is still in the from status, so concurrent
+// transitions cannot both succeed.
+func (r *Payment) UpdateStatus(id string, from, to entity.PaymentStatus, changedBy, reason string) (*entity.PaymentStatusChange, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	defer tx.Rollback()
+
+	change, err := updateStatus(tx, id, from, to, changedBy, reason)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return change, nil
+}
+
+// CreateRefund records a refund and moves the payment from one status to another, both
+// in one transaction. The refund is only inserted while the payment is still in the from
+// status and the refunds including this one do not exceed the payment amount.
+func (r *Payment) CreateRefund(refund *entity.Refund, from, to entity.PaymentStatus) (*entity.Refund, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	defer tx.Rollback()
 
-	row := db.QueryRow("SELECT COUNT(1) FROM payments")
-	err := row.Scan(&total)
+	created := *refund
+	created.CreatedAt = time.Now().UTC()
+	res, err := tx.Exec(`INSERT INTO refunds(payment_id, amount, reason, created_by, created_at)
+		SELECT p.id, ?, ?, ?, ? FROM payments p
+		WHERE p.id = ? AND p.status = ?
+		AND p.amount >= ? + (SELECT COALESCE(SUM(amount), 0) FROM refunds WHERE payment_id = p.id)`,
+		created.Amount, created.Reason, created.CreatedBy, created.CreatedAt,
+		created.PaymentID, string(from), created.Amount)
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	affected, err := res.RowsAffected()
 	if err != nil {
-		return 0, 0, 0, 0
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if affected == 0 {
+		return nil, entity.ErrorConflict("payment was changed by another request")
 	}
+	refundID, err := res.LastInsertId()
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	created.ID = fmt.Sprint(refundID)
 
-	row = db.QueryRow("SELECT COUNT(1) FROM payments WHERE status = 'completed'")
-	err = row.Scan(&totalCompleted)
+	if from != to {
+		reason := fmt.Sprintf("refund %s", created.ID)
+		if _, err := updateStatus(tx, created.PaymentID, from, to, created.CreatedBy, reason); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return &created, nil
+}
+
+func (r *Payment) ListRefunds(paymentID string) ([]*entity.Refund, error) {
+	rows, err := r.db.Query(`SELECT f.id, f.payment_id, f.amount, f.reason, f.created_by, COALESCE(u.email, ''), f.created_at
+		FROM refunds f LEFT JOIN users u ON u.id = f.created_by
+		WHERE f.payment_id = ? ORDER BY f.id ASC`, paymentID)
 	if err != nil {
-		return 0, 0, 0, 0
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	defer rows.Close()
+	res := []*entity.Refund{}
+	for rows.Next() {
+		var refund entity.Refund
+		if err := rows.Scan(&refund.ID, &refund.PaymentID, &refund.Amount, &refund.Reason, &refund.CreatedBy, &refund.CreatedByEmail, &refund.CreatedAt); err != nil {
+			return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		}
+		res = append(res, &refund)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return res, nil
+}
 
-	row = db.QueryRow("SELECT COUNT(1) FROM payments WHERE status = 'failed'")
-	err = row.Scan(&totalFailed)
+func (r *Payment) ListStatusHistory(paymentID string) ([]*entity.PaymentStatusChange, error) {
+	rows, err := r.db.Query(`SELECT h.id, h.payment_id, h.from_status, h.to_status, h.changed_by, COALESCE(u.email, ''), h.reason, h.changed_at
+		FROM payment_status_history h LEFT JOIN users u ON u.id = h.changed_by
+		WHERE h.payment_id = ? ORDER BY h.id ASC`, paymentID)
 	if err != nil {
-		return 0, 0, 0, 0
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
 	}
+	defer rows.Close()
+	res := []*entity.PaymentStatusChange{}
+	for rows.Next() {
+		var c entity.PaymentStatusChange
+		if err := rows.Scan(&c.ID, &c.PaymentID, &c.FromStatus, &c.ToStatus, &c.ChangedBy, &c.ChangedByEmail, &c.Reason, &c.ChangedAt); err != nil {
+			return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		}
+		res = append(res, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return res, nil
+}
 
-	row = db.QueryRow("SELECT COUNT(1) FROM payments WHERE status = 'pending'")
-	err = row.Scan(&totalPending)
+func (r *Payment) ListReviews(paymentID string) ([]*entity.PaymentReview, error) {
+	rows, err := r.db.Query(`SELECT r.id, r.payment_id, r.reviewer_id, COALESCE(u.email, ''), r.outcome, r.note, r.reviewed_at
+		FROM payment_reviews r LEFT JOIN users u ON u.id = r.reviewer_id
+		WHERE r.payment_id = ? ORDER BY r.id ASC`, paymentID)
 	if err != nil {
-		return 0, 0, 0, 0
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	defer rows.Close()
+	res := []*entity.PaymentReview{}
+	for rows.Next() {
+		var review entity.PaymentReview
+		if err := rows.Scan(&review.ID, &review.PaymentID, &review.ReviewerID, &review.ReviewerEmail, &review.Outcome, &review.Note, &review.ReviewedAt); err != nil {
+			return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		}
+		res = append(res, &review)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return res, nil
+}
+
+// AddNote stores a note on an existing payment.
+func (r *Payment) AddNote(note *entity.PaymentNote) (*entity.PaymentNote, error) {
+	created := *note
+	created.CreatedAt = time.Now().UTC()
+	res, err := r.db.Exec("INSERT INTO payment_notes(payment_id, author_id, body, created_at) SELECT id, ?, ?, ? FROM payments WHERE id = ?",
+		created.AuthorID, created.Body, created.CreatedAt, created.PaymentID)
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if affected == 0 {
+		return nil, entity.ErrorNotFound("payment not found")
+	}
+	noteID, err := res.LastInsertId()
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	created.ID = fmt.Sprint(noteID)
+	return &created, nil
+}
+
+func (r *Payment) ListNotes(paymentID string) ([]*entity.PaymentNote, error) {
+	rows, err := r.db.Query(`SELECT n.id, n.payment_id, n.author_id, COALESCE(u.email, ''), n.body, n.created_at
+		FROM payment_notes n LEFT JOIN users u ON u.id = n.author_id
+		WHERE n.payment_id = ? ORDER BY n.id ASC`, paymentID)
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	defer rows.Close()
+	res := []*entity.PaymentNote{}
+	for rows.Next() {
+		var note entity.PaymentNote
+		if err := rows.Scan(&note.ID, &note.PaymentID, &note.AuthorID, &note.AuthorEmail, &note.Body, &note.CreatedAt); err != nil {
+			return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		}
+		res = append(res, &note)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return res, nil
+}
+
+func updateStatus(tx *sql.Tx, id string, from, to entity.PaymentStatus, changedBy, reason string) (*entity.PaymentStatusChange, error) {
+	res, err := tx.Exec("UPDATE payments SET status = ? WHERE id = ? AND status = ?", string(to), id, string(from))
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if affected == 0 {
+		return nil, entity.ErrorConflict("payment status was changed by another request")
+	}
+
+	changedAt := time.Now().UTC()
+	res, err = tx.Exec("INSERT INTO payment_status_history(payment_id, from_status, to_status, changed_by, reason, changed_at) VALUES (?, ?, ?, ?, ?, ?)",
+		id, string(from), string(to), changedBy, reason, changedAt)
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	changeID, err := res.LastInsertId()
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return &entity.PaymentStatusChange{
+		ID:         fmt.Sprint(changeID),
+		PaymentID:  id,
+		FromStatus: from,
+		ToStatus:   to,
+		ChangedBy:  changedBy,
+		Reason:     reason,
+		ChangedAt:  changedAt,
+	}, nil
+}
+
+func scanPayment(row database.RowScanner) (*entity.Payment, error) {
+	var p entity.Payment
+	var (
+		reviewID, reviewerID, reviewerEmail, outcome, note sql.NullString
+		reviewedAt                                         sql.NullTime
+	)
+	if err := row.Scan(&p.ID, &p.MerchantID, &p.Merchant, &p.Amount, &p.Currency, &p.Status, &p.CreatedAt, &p.RefundedAmount,
+		&reviewID, &reviewerID, &reviewerEmail, &outcome, &note, &reviewedAt); err != nil {
+		return nil, err
+	}
+	if reviewID.Valid {
+		p.Review = &entity.PaymentReview{
+			ID:            reviewID.String,
+			PaymentID:     p.ID,
+			ReviewerID:    reviewerID.String,
+			ReviewerEmail: reviewerEmail.String,
+			Outcome:       entity.ReviewOutcome(outcome.String),
+			Note:          note.String,
+			ReviewedAt:    reviewedAt.Time,
+		}
+	}
+	return &p, nil
+}
+
+// getSummary counts and sums the payments matching where per status in a single query.
+func (r *Payment) getSummary(where string, args []interface{}) (*entity.PaymentSummary, error) {
+	rows, err := r.db.Query("SELECT p.status, p.currency, COUNT(1), COALESCE(SUM(p.amount), 0) FROM payments p"+where+
+		" GROUP BY p.status, p.currency ORDER BY p.status, p.currency", args...)
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	defer rows.Close()
+	summary := &entity.PaymentSummary{Statuses: []*entity.PaymentStatusSummary{}}
+	byStatus := map[entity.PaymentStatus]*entity.PaymentStatusSummary{}
+	for rows.Next() {
+		var (
+			status entity.PaymentStatus
+			amount entity.CurrencyAmount
+			count  int
+		)
+		if err := rows.Scan(&status, &amount.Currency, &count, &amount.Amount); err != nil {
+			return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		}
+		statusSummary, ok := byStatus[status]
+		if !ok {
+			statusSummary = &entity.PaymentStatusSummary{Status: status}
+			byStatus[status] = statusSummary
+			summary.Statuses = append(summary.Statuses, statusSummary)
+		}
+		statusSummary.Count += count
+		statusSummary.Amounts = append(statusSummary.Amounts, amount)
+
+		summary.Total += count
+		switch status {
+		case entity.PaymentStatusCompleted:
+			summary.TotalCompleted += count
+		case entity.PaymentStatusFailed:
+			summary.TotalFailed += count
+		case entity.PaymentStatusPending:
+			summary.TotalPending += count
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
 	}
-	return total, totalCompleted, totalFailed, totalPending
+	return summary, nil
 }
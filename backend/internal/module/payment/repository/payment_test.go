@@ -2,15 +2,23 @@ package repository
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"regexp"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/assert"
 )
 
+var paymentColumns = []string{
+	"id", "merchant_id", "merchant", "amount", "currency", "status", "created_at", "refunded_amount",
+	"review_id", "reviewer_id", "reviewer_email", "outcome", "note", "reviewed_at",
+}
+
 func newMockRepo(t *testing.T) (*Payment, sqlmock.Sqlmock, func()) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -21,53 +29,151 @@ func newMockRepo(t *testing.T) (*Payment, sqlmock.Sqlmock, func()) {
 	return repo, mock, cleanup
 }
 
+const summaryQuery = "SELECT p.status, p.currency, COUNT(1), COALESCE(SUM(p.amount), 0) FROM payments p"
+
+var summaryColumns = []string{"status", "currency", "count", "amount"}
+
 func TestGetPayments_Success(t *testing.T) {
 	repo, mock, cleanup := newMockRepo(t)
 	defer cleanup()
 
-	rows := sqlmock.NewRows([]string{"id", "merchant", "amount", "status", "created_at"}).
-		AddRow("p1", "m1", 100.0, "pending", time.Now()).
-		AddRow("p2", "m2", 200.0, "completed", time.Now())
+	rows := sqlmock.NewRows(paymentColumns).
+		AddRow("p1", "1", "m1", 100000, "IDR", "pending", time.Now(), 0, nil, nil, nil, nil, nil, nil).
+		AddRow("p2", "2", "m2", 20000, "USD", "completed", time.Now(), 0, "r1", "u1", "op@example.com", "flagged", "double charge", time.Now())
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, merchant, amount, status, created_at FROM payments WHERE status = ? AND id = ? ORDER BY created_at ASC LIMIT ? OFFSET ?")).
-		WithArgs("completed", "1", 10, 1).
+	mock.ExpectQuery(regexp.QuoteMeta(paymentSelect+" WHERE p.status = ? AND p.id = ? AND p.merchant_id = ? ORDER BY julianday(p.created_at) ASC, p.id ASC LIMIT ? OFFSET ?")).
+		WithArgs("completed", "1", "2", 11, 1).
 		WillReturnRows(rows)
-
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(1) FROM payments WHERE status = ? AND id = ?")).
-		WithArgs("completed", "1").
-		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(1) FROM payments")).
-		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(1) FROM payments WHERE status = 'completed'")).
-		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(1) FROM payments WHERE status = 'failed'")).
-		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(1) FROM payments WHERE status = 'pending'")).
+	mock.ExpectQuery(regexp.QuoteMeta(summaryQuery + " GROUP BY p.status, p.currency ORDER BY p.status, p.currency")).
+		WillReturnRows(sqlmock.NewRows(summaryColumns).
+			AddRow("completed", "IDR", 1, 200000).
+			AddRow("completed", "USD", 1, 20000).
+			AddRow("failed", "IDR", 1, 150500).
+			AddRow("pending", "IDR", 1, 100000))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(1) FROM payments p WHERE p.status = ? AND p.id = ? AND p.merchant_id = ?")).
+		WithArgs("completed", "1", "2").
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 
-	items, totalSummary, err := repo.GetPayments("completed", "1", "created_at", 10, 1)
+	page, err := repo.GetPayments(entity.PaymentFilter{Status: "completed", ID: "1", MerchantID: "2"}, "created_at", 10, 1, nil, entity.PaymentSummaryScopeAll)
 	assert.NoError(t, err)
+	items := page.Payments
 	assert.Len(t, items, 2)
-	assert.Equal(t, 1, totalSummary.TotalByFiler)
-	assert.Equal(t, 4, totalSummary.Total)
-	assert.Equal(t, 2, totalSummary.TotalCompleted)
-	assert.Equal(t, 1, totalSummary.TotalFailed)
-	assert.Equal(t, 1, totalSummary.TotalPending)
+	assert.Equal(t, "2", items[1].MerchantID)
+	assert.Nil(t, page.NextKey)
+	assert.Equal(t, []string{items[0].CreatedAt.UTC().Format(time.RFC3339Nano), "p1"}, page.PrevKey)
+	assert.Nil(t, items[0].Review)
+	assert.NotNil(t, items[1].Review)
+	assert.Equal(t, "op@example.com", items[1].Review.ReviewerEmail)
+	assert.Equal(t, entity.ReviewOutcomeFlagged, items[1].Review.Outcome)
+	assert.Equal(t, entity.PaymentSummaryScopeAll, page.Summary.Scope)
+	assert.Equal(t, 1, page.Summary.TotalByFiler)
+	assert.Equal(t, 4, page.Summary.Total)
+	assert.Equal(t, 2, page.Summary.TotalCompleted)
+	assert.Equal(t, 1, page.Summary.TotalFailed)
+	assert.Equal(t, 1, page.Summary.TotalPending)
+	assert.Len(t, page.Summary.Statuses, 3)
+	assert.Equal(t, &entity.PaymentStatusSummary{
+		Status: entity.PaymentStatusCompleted,
+		Count:  2,
+		Amounts: []entity.CurrencyAmount{
+			{Currency: "IDR", Amount: 200000},
+			{Currency: "USD", Amount: 20000},
+		},
+	}, page.Summary.Statuses[0])
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Fatalf("there were unfulfilled expectations: %v", err)
 	}
 }
 
+func TestGetPayments_FilteredSummary(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectQuery(regexp.QuoteMeta(paymentSelect + " WHERE p.merchant_id = ? ORDER BY julianday(p.created_at) ASC, p.id ASC")).
+		WithArgs("5").
+		WillReturnRows(sqlmock.NewRows(paymentColumns))
+	mock.ExpectQuery(regexp.QuoteMeta(summaryQuery + " WHERE p.merchant_id = ? GROUP BY p.status, p.currency ORDER BY p.status, p.currency")).
+		WithArgs("5").
+		WillReturnRows(sqlmock.NewRows(summaryColumns).
+			AddRow("completed", "USD", 2, 35050).
+			AddRow("failed", "USD", 1, 15050))
+
+	page, err := repo.GetPayments(entity.PaymentFilter{MerchantID: "5"}, "created_at", 0, 0, nil, entity.PaymentSummaryScopeFiltered)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.PaymentSummaryScopeFiltered, page.Summary.Scope)
+	assert.Equal(t, 3, page.Summary.TotalByFiler)
+	assert.Equal(t, 3, page.Summary.Total)
+	assert.Equal(t, 2, page.Summary.TotalCompleted)
+	assert.Equal(t, int64(35050), page.Summary.Statuses[0].Amounts[0].Amount)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetPayments_SummaryError(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectQuery(regexp.QuoteMeta(paymentSelect + " ORDER BY julianday(p.created_at) ASC, p.id ASC")).
+		WillReturnRows(sqlmock.NewRows(paymentColumns))
+	mock.ExpectQuery(regexp.QuoteMeta(summaryQuery)).
+		WillReturnError(errors.New("db summary failed"))
+
+	_, err := repo.GetPayments(entity.PaymentFilter{}, "created_at", 0, 0, nil, entity.PaymentSummaryScopeAll)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "db error")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetPayments_RangeFilters(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.FixedZone("WIB", 7*60*60))
+	to := from.Add(24 * time.Hour)
+	amountMin := int64(1000)
+	amountMax := int64(50000)
+	where := " WHERE p.status = ? AND p.currency = ? AND julianday(p.created_at) >= julianday(?) AND julianday(p.created_at) < julianday(?) AND p.amount >= ? AND p.amount <= ?"
+	args := []driver.Value{"failed", "USD", "2024-12-31T17:00:00Z", "2025-01-01T17:00:00Z", amountMin, amountMax}
+
+	mock.ExpectQuery(regexp.QuoteMeta(paymentSelect + where + " ORDER BY julianday(p.created_at) ASC, p.id ASC")).
+		WithArgs(args...).
+		WillReturnRows(sqlmock.NewRows(paymentColumns))
+	mock.ExpectQuery(regexp.QuoteMeta(summaryQuery + where + " GROUP BY")).
+		WithArgs(args...).
+		WillReturnRows(sqlmock.NewRows(summaryColumns).AddRow("failed", "USD", 2, 3000))
+
+	// the amounts are USD cents, IDR payments of the same minor amount stay out
+	page, err := repo.GetPayments(entity.PaymentFilter{
+		Status:      entity.PaymentStatusFailed,
+		Currency:    "USD",
+		CreatedFrom: &from,
+		CreatedTo:   &to,
+		AmountMin:   &amountMin,
+		AmountMax:   &amountMax,
+	}, "created_at", 0, 0, nil, entity.PaymentSummaryScopeFiltered)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, page.Summary.TotalByFiler)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
 func TestGetPayments_QueryError(t *testing.T) {
 	repo, mock, cleanup := newMockRepo(t)
 	defer cleanup()
 
 	// Simulate DB query error on main SELECT
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, merchant, amount, status, created_at FROM payments ORDER BY created_at ASC")).
+	mock.ExpectQuery(regexp.QuoteMeta(paymentSelect + " ORDER BY julianday(p.created_at) ASC, p.id ASC")).
 		WillReturnError(errors.New("db select failed"))
 
-	_, _, err := repo.GetPayments("", "", "created_at", 0, 0)
+	_, err := repo.GetPayments(entity.PaymentFilter{}, "created_at", 0, 0, nil, entity.PaymentSummaryScopeAll)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "db error")
 
@@ -76,6 +182,340 @@ func TestGetPayments_QueryError(t *testing.T) {
 	}
 }
 
+func TestGetPayments_ReviewedFilter(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectQuery(regexp.QuoteMeta(paymentSelect + " WHERE NOT EXISTS (SELECT 1 FROM payment_reviews WHERE payment_id = p.id) ORDER BY julianday(p.created_at) ASC, p.id ASC")).
+		WillReturnRows(sqlmock.NewRows(paymentColumns))
+	mock.ExpectQuery(regexp.QuoteMeta(summaryQuery + " GROUP BY")).
+		WillReturnRows(sqlmock.NewRows(summaryColumns))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(1) FROM payments p WHERE NOT EXISTS (SELECT 1 FROM payment_reviews WHERE payment_id = p.id)")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	reviewed := false
+	page, err := repo.GetPayments(entity.PaymentFilter{Reviewed: &reviewed}, "created_at", 0, 0, nil, entity.PaymentSummaryScopeAll)
+	assert.NoError(t, err)
+	assert.Empty(t, page.Payments)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetPayments_Search(t *testing.T) {
+	t.Run("relevance", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		where := " JOIN payment_search ON payment_search.rowid = p.id WHERE payment_search MATCH ? AND p.status = ?"
+		mock.ExpectQuery(regexp.QuoteMeta(paymentSelect+where+" ORDER BY payment_search.rank ASC, p.id ASC LIMIT ?")).
+			WithArgs(`"toko"* "sum"*`, "completed", 11).
+			WillReturnRows(sqlmock.NewRows(paymentColumns).
+				AddRow("3", "1", "Toko Sumber", 500, "IDR", "completed", time.Now(), 0, nil, nil, nil, nil, nil, nil))
+		mock.ExpectQuery(regexp.QuoteMeta(summaryQuery+where+" GROUP BY")).
+			WithArgs(`"toko"* "sum"*`, "completed").
+			WillReturnRows(sqlmock.NewRows(summaryColumns).AddRow("completed", "IDR", 1, 500))
+
+		page, err := repo.GetPayments(entity.PaymentFilter{Search: "toko sum", Status: entity.PaymentStatusCompleted}, "relevance", 10, 0, nil, entity.PaymentSummaryScopeFiltered)
+		assert.NoError(t, err)
+		assert.Len(t, page.Payments, 1)
+		assert.Equal(t, 1, page.Summary.TotalByFiler)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("relevance without search", func(t *testing.T) {
+		repo, _, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		_, err := repo.GetPayments(entity.PaymentFilter{}, "relevance", 10, 0, nil, entity.PaymentSummaryScopeAll)
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeValidation, appErr.Code)
+	})
+
+	t.Run("cursor with relevance", func(t *testing.T) {
+		repo, _, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		_, err := repo.GetPayments(entity.PaymentFilter{Search: "toko"}, "relevance", 10, 0,
+			&entity.PageCursor{Key: []string{"1", "1"}}, entity.PaymentSummaryScopeAll)
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeValidation, appErr.Code)
+	})
+}
+
+func TestStreamPayments(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		mock.ExpectQuery(regexp.QuoteMeta(paymentSelect + " WHERE p.status = ? ORDER BY p.amount DESC, p.id ASC")).
+			WithArgs("completed").
+			WillReturnRows(sqlmock.NewRows(paymentColumns).
+				AddRow("1", "1", "m1", 500, "IDR", "completed", time.Now(), 0, nil, nil, nil, nil, nil, nil).
+				AddRow("2", "1", "m1", 400, "IDR", "completed", time.Now(), 0, nil, nil, nil, nil, nil, nil))
+
+		ids := []string{}
+		err := repo.StreamPayments(entity.PaymentFilter{Status: entity.PaymentStatusCompleted}, "-amount", func(p *entity.Payment) error {
+			ids = append(ids, p.ID)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1", "2"}, ids)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("callback error stops", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		mock.ExpectQuery(regexp.QuoteMeta(paymentSelect + " ORDER BY julianday(p.created_at) ASC, p.id ASC")).
+			WillReturnRows(sqlmock.NewRows(paymentColumns).
+				AddRow("1", "1", "m1", 500, "IDR", "completed", time.Now(), 0, nil, nil, nil, nil, nil, nil).
+				AddRow("2", "1", "m1", 400, "IDR", "completed", time.Now(), 0, nil, nil, nil, nil, nil, nil))
+
+		calls := 0
+		writeErr := errors.New("client gone")
+		err := repo.StreamPayments(entity.PaymentFilter{}, "", func(p *entity.Payment) error {
+			calls++
+			return writeErr
+		})
+		assert.ErrorIs(t, err, writeErr)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		mock.ExpectQuery(regexp.QuoteMeta(paymentSelect)).
+			WillReturnError(errors.New("db select failed"))
+
+		err := repo.StreamPayments(entity.PaymentFilter{}, "", func(p *entity.Payment) error { return nil })
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "db error")
+	})
+}
+
+func TestGetPaymentSeries(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	assert.NoError(t, err)
+	day1 := time.Date(2025, 1, 1, 0, 0, 0, 0, jakarta)
+	day2 := time.Date(2025, 1, 2, 0, 0, 0, 0, jakarta)
+	day3 := time.Date(2025, 1, 3, 0, 0, 0, 0, jakarta)
+	series := "SELECT CAST(strftime('%s', p.created_at) AS INTEGER) / 900 * 900 AS slot, p.status, p.currency, COUNT(1), COALESCE(SUM(p.amount), 0) FROM payments p" +
+		" WHERE p.merchant_id = ? AND julianday(p.created_at) >= julianday(?) AND julianday(p.created_at) < julianday(?)" +
+		" GROUP BY slot, p.status, p.currency ORDER BY slot, p.status, p.currency"
+
+	t.Run("assigns slots to buckets", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		mock.ExpectQuery(regexp.QuoteMeta(series)).
+			WithArgs("1", "2024-12-31T17:00:00Z", "2025-01-02T17:00:00Z").
+			WillReturnRows(sqlmock.NewRows([]string{"slot", "status", "currency", "count", "amount"}).
+				AddRow(day1.Unix(), "failed", "IDR", 1, 300).
+				AddRow(day1.Add(time.Hour).Unix(), "completed", "IDR", 2, 1000).
+				AddRow(day1.Add(2*time.Hour).Unix(), "completed", "IDR", 1, 500).
+				AddRow(day1.Add(2*time.Hour).Unix(), "completed", "BHD", 1, 1500).
+				AddRow(day3.Add(-15*time.Minute).Unix(), "pending", "USD", 1, 100))
+
+		buckets, err := repo.GetPaymentSeries(entity.PaymentFilter{MerchantID: "1"}, []time.Time{day1, day2, day3})
+		assert.NoError(t, err)
+		assert.Len(t, buckets, 2)
+
+		assert.Equal(t, day1, buckets[0].Start)
+		assert.Equal(t, 5, buckets[0].Count)
+		assert.Equal(t, []*entity.PaymentStatusSummary{
+			{Status: entity.PaymentStatusCompleted, Count: 4, Amounts: []entity.CurrencyAmount{{Currency: "BHD", Amount: 1500}, {Currency: "IDR", Amount: 1500}}},
+			{Status: entity.PaymentStatusFailed, Count: 1, Amounts: []entity.CurrencyAmount{{Currency: "IDR", Amount: 300}}},
+		}, buckets[0].Statuses)
+
+		assert.Equal(t, day3, buckets[1].End)
+		assert.Equal(t, 1, buckets[1].Count)
+		assert.Equal(t, entity.PaymentStatusPending, buckets[1].Statuses[0].Status)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("empty buckets", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		mock.ExpectQuery(regexp.QuoteMeta(series)).
+			WillReturnRows(sqlmock.NewRows([]string{"slot", "status", "currency", "count", "amount"}))
+
+		buckets, err := repo.GetPaymentSeries(entity.PaymentFilter{MerchantID: "1"}, []time.Time{day1, day2, day3})
+		assert.NoError(t, err)
+		assert.Len(t, buckets, 2)
+		assert.Equal(t, 0, buckets[1].Count)
+		assert.Empty(t, buckets[1].Statuses)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		mock.ExpectQuery(regexp.QuoteMeta(series)).
+			WillReturnError(errors.New("db select failed"))
+
+		_, err := repo.GetPaymentSeries(entity.PaymentFilter{MerchantID: "1"}, []time.Time{day1, day2})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "db error")
+	})
+}
+
+func TestGetMerchantReport(t *testing.T) {
+	report := "SELECT p.merchant_id, m.display_name, p.currency, COUNT(1), " + reportSucceeded + ", " + reportFailed + ", SUM(p.amount)," +
+		" strftime('%Y-%m-%dT%H:%M:%fZ', MAX(julianday(p.created_at))), COUNT(1) OVER ()" +
+		" FROM payments p JOIN merchants m ON m.id = p.merchant_id"
+	columns := []string{"merchant_id", "display_name", "currency", "count", "succeeded", "failed", "volume", "last_payment", "total"}
+
+	t.Run("success", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(regexp.QuoteMeta(report+" WHERE julianday(p.created_at) >= julianday(?) AND p.currency = ?"+
+			" GROUP BY p.merchant_id, m.display_name, p.currency ORDER BY SUM(p.amount) DESC, p.merchant_id ASC, p.currency ASC LIMIT ?")).
+			WithArgs("2025-01-01T00:00:00Z", "IDR", 10).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("1", "m1", "IDR", 4, 3, 1, 1001, "2025-01-31T10:00:00.500Z", 2))
+
+		reports, total, err := repo.GetMerchantReport(entity.MerchantReportFilter{CreatedFrom: &from, Currency: "IDR"}, "-volume", 10, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.Len(t, reports, 1)
+		assert.Equal(t, 0.75, reports[0].SuccessRate)
+		assert.Equal(t, 0.25, reports[0].FailureRate)
+		assert.Equal(t, int64(250), reports[0].AverageAmount)
+		assert.Equal(t, time.Date(2025, 1, 31, 10, 0, 0, 500000000, time.UTC), reports[0].LastPaymentAt)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("page past the end counts apart", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		mock.ExpectQuery(regexp.QuoteMeta(report+" GROUP BY p.merchant_id, m.display_name, p.currency"+
+			" ORDER BY 1.0 * "+reportFailed+" / COUNT(1) DESC, p.merchant_id ASC, p.currency ASC LIMIT ? OFFSET ?")).
+			WithArgs(10, 50).
+			WillReturnRows(sqlmock.NewRows(columns))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(1) FROM (SELECT 1 FROM payments p GROUP BY p.merchant_id, p.currency)")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+
+		reports, total, err := repo.GetMerchantReport(entity.MerchantReportFilter{}, "", 10, 50)
+		assert.NoError(t, err)
+		assert.Empty(t, reports)
+		assert.Equal(t, 12, total)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("unknown sort field", func(t *testing.T) {
+		repo, _, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		_, _, err := repo.GetMerchantReport(entity.MerchantReportFilter{}, "amount", 10, 0)
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeValidation, appErr.Code)
+	})
+}
+
+func TestSearchQuery(t *testing.T) {
+	assert.Equal(t, `"toko"* "sumber"*`, searchQuery("toko sumber"))
+	assert.Equal(t, `"toko"* "OR"* "12"*`, searchQuery(`toko" OR 12*`))
+	assert.Equal(t, `"kafé"*`, searchQuery("  kafé!  "))
+	assert.Equal(t, "", searchQuery(`*-\`))
+}
+
+func TestGetPayments_Cursor(t *testing.T) {
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	keyset := "((p.amount < ?) OR (p.amount = ? AND p.id > ?))"
+
+	t.Run("after", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		mock.ExpectQuery(regexp.QuoteMeta(paymentSelect+" WHERE p.status = ? AND "+keyset+" ORDER BY p.amount DESC, p.id ASC LIMIT ?")).
+			WithArgs("completed", "500", "500", "7", 3).
+			WillReturnRows(sqlmock.NewRows(paymentColumns).
+				AddRow("8", "1", "m1", 500, "IDR", "completed", createdAt, 0, nil, nil, nil, nil, nil, nil).
+				AddRow("3", "1", "m1", 400, "IDR", "completed", createdAt, 0, nil, nil, nil, nil, nil, nil).
+				AddRow("4", "1", "m1", 300, "IDR", "completed", createdAt, 0, nil, nil, nil, nil, nil, nil))
+		mock.ExpectQuery(regexp.QuoteMeta(summaryQuery + " GROUP BY")).
+			WillReturnRows(sqlmock.NewRows(summaryColumns))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(1) FROM payments p WHERE p.status = ?")).
+			WithArgs("completed").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+
+		page, err := repo.GetPayments(entity.PaymentFilter{Status: entity.PaymentStatusCompleted}, "-amount", 2, 0,
+			&entity.PageCursor{Key: []string{"500", "7"}}, entity.PaymentSummaryScopeAll)
+		assert.NoError(t, err)
+		assert.Len(t, page.Payments, 2)
+		assert.Equal(t, []string{"400", "3"}, page.NextKey)
+		assert.Equal(t, []string{"500", "8"}, page.PrevKey)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("before", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		mock.ExpectQuery(regexp.QuoteMeta(paymentSelect+" WHERE ((p.amount > ?) OR (p.amount = ? AND p.id < ?)) ORDER BY p.amount ASC, p.id DESC LIMIT ?")).
+			WithArgs("400", "400", "3", 3).
+			WillReturnRows(sqlmock.NewRows(paymentColumns).
+				AddRow("8", "1", "m1", 500, "IDR", "completed", createdAt, 0, nil, nil, nil, nil, nil, nil).
+				AddRow("7", "1", "m1", 500, "IDR", "completed", createdAt, 0, nil, nil, nil, nil, nil, nil))
+		mock.ExpectQuery(regexp.QuoteMeta(summaryQuery + " GROUP BY")).
+			WillReturnRows(sqlmock.NewRows(summaryColumns))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(1) FROM payments p")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+
+		page, err := repo.GetPayments(entity.PaymentFilter{}, "-amount", 2, 0,
+			&entity.PageCursor{Key: []string{"400", "3"}, Before: true}, entity.PaymentSummaryScopeAll)
+		assert.NoError(t, err)
+		assert.Equal(t, "7", page.Payments[0].ID)
+		assert.Equal(t, "8", page.Payments[1].ID)
+		assert.Equal(t, []string{"500", "8"}, page.NextKey)
+		assert.Nil(t, page.PrevKey)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("key does not fit the sort", func(t *testing.T) {
+		repo, _, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		_, err := repo.GetPayments(entity.PaymentFilter{}, "status,-amount", 2, 0,
+			&entity.PageCursor{Key: []string{"500", "7"}}, entity.PaymentSummaryScopeAll)
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeValidation, appErr.Code)
+	})
+}
+
 func TestReview_Success(t *testing.T) {
 	repo, mock, cleanup := newMockRepo(t)
 	defer cleanup()
@@ -83,10 +523,16 @@ func TestReview_Success(t *testing.T) {
 	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM payments WHERE id = ?")).
 		WithArgs("p1").
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("p1"))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO payment_reviews(payment_id, reviewer_id, outcome, note, reviewed_at) VALUES (?, ?, ?, ?, ?)")).
+		WithArgs("p1", "u1", "approved", "looks good", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(7, 1))
 
-	msg, err := repo.Review("p1")
+	review, err := repo.Review("p1", "u1", entity.ReviewOutcomeApproved, "looks good")
 	assert.NoError(t, err)
-	assert.Equal(t, "Success Review", msg)
+	assert.Equal(t, "7", review.ID)
+	assert.Equal(t, "p1", review.PaymentID)
+	assert.Equal(t, "u1", review.ReviewerID)
+	assert.Equal(t, "looks good", review.Note)
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Fatalf("unfulfilled expectations: %v", err)
@@ -101,7 +547,43 @@ func TestReview_NotFound(t *testing.T) {
 		WithArgs("missing").
 		WillReturnError(sql.ErrNoRows)
 
-	_, err := repo.Review("missing")
+	_, err := repo.Review("missing", "u1", entity.ReviewOutcomeApproved, "")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "payment not found")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetPaymentByID_Success(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectQuery(regexp.QuoteMeta(paymentSelect + " WHERE p.id = ?")).
+		WithArgs("p1").
+		WillReturnRows(sqlmock.NewRows(paymentColumns).
+			AddRow("p1", "1", "m1", 100000, "IDR", "pending", time.Now(), 0, nil, nil, nil, nil, nil, nil))
+
+	p, err := repo.GetPaymentByID("p1")
+	assert.NoError(t, err)
+	assert.Equal(t, "p1", p.ID)
+	assert.Equal(t, entity.PaymentStatusPending, p.Status)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetPaymentByID_NotFound(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectQuery(regexp.QuoteMeta(paymentSelect + " WHERE p.id = ?")).
+		WithArgs("missing").
+		WillReturnError(sql.ErrNoRows)
+
+	_, err := repo.GetPaymentByID("missing")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "payment not found")
 
@@ -109,3 +591,265 @@ func TestReview_NotFound(t *testing.T) {
 		t.Fatalf("unfulfilled expectations: %v", err)
 	}
 }
+
+func TestUpdateStatus_Success(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE payments SET status = ? WHERE id = ? AND status = ?")).
+		WithArgs("processing", "p1", "pending").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO payment_status_history(payment_id, from_status, to_status, changed_by, reason, changed_at) VALUES (?, ?, ?, ?, ?, ?)")).
+		WithArgs("p1", "pending", "processing", "u1", "picked up", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectCommit()
+
+	change, err := repo.UpdateStatus("p1", entity.PaymentStatusPending, entity.PaymentStatusProcessing, "u1", "picked up")
+	assert.NoError(t, err)
+	assert.Equal(t, "3", change.ID)
+	assert.Equal(t, entity.PaymentStatusPending, change.FromStatus)
+	assert.Equal(t, entity.PaymentStatusProcessing, change.ToStatus)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUpdateStatus_ConcurrentChange(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE payments SET status = ? WHERE id = ? AND status = ?")).
+		WithArgs("processing", "p1", "pending").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err := repo.UpdateStatus("p1", entity.PaymentStatusPending, entity.PaymentStatusProcessing, "u1", "")
+	assert.Error(t, err)
+	var appErr *entity.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, entity.ErrorCodeConflict, appErr.Code)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCreate_WithIdempotencyKey(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO payments(merchant_id, amount, currency, status, created_at) VALUES (?, ?, ?, ?, ?)")).
+		WithArgs("1", int64(15050), "USD", "pending", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(13, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO idempotency_keys(idempotency_key, user_id, request_hash, payment_id, response, created_at) VALUES (?, ?, ?, ?, ?, ?)")).
+		WithArgs("key-1", "u1", "hash", "13", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	p, err := repo.Create(&entity.Payment{MerchantID: "1", Amount: 15050, Currency: "USD", Status: entity.PaymentStatusPending},
+		&entity.IdempotencyKey{Key: "key-1", UserID: "u1", RequestHash: "hash"})
+	assert.NoError(t, err)
+	assert.Equal(t, "13", p.ID)
+	assert.False(t, p.CreatedAt.IsZero())
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCreate_WithoutIdempotencyKey(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO payments(merchant_id, amount, currency, status, created_at) VALUES (?, ?, ?, ?, ?)")).
+		WithArgs("1", int64(1000), "USD", "pending", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(14, 1))
+	mock.ExpectCommit()
+
+	p, err := repo.Create(&entity.Payment{MerchantID: "1", Amount: 1000, Currency: "USD", Status: entity.PaymentStatusPending}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "14", p.ID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCreate_DuplicateIdempotencyKey(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO payments(merchant_id, amount, currency, status, created_at) VALUES (?, ?, ?, ?, ?)")).
+		WillReturnResult(sqlmock.NewResult(15, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO idempotency_keys")).
+		WillReturnError(sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintPrimaryKey})
+	mock.ExpectRollback()
+
+	_, err := repo.Create(&entity.Payment{MerchantID: "1", Amount: 1000, Currency: "USD", Status: entity.PaymentStatusPending},
+		&entity.IdempotencyKey{Key: "key-1", UserID: "u1", RequestHash: "hash"})
+	var appErr *entity.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, entity.ErrorCodeConflict, appErr.Code)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetIdempotencyKey(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	query := regexp.QuoteMeta("SELECT idempotency_key, user_id, request_hash, payment_id, response, created_at FROM idempotency_keys WHERE user_id = ? AND idempotency_key = ?")
+	mock.ExpectQuery(query).
+		WithArgs("u1", "key-1").
+		WillReturnRows(sqlmock.NewRows([]string{"idempotency_key", "user_id", "request_hash", "payment_id", "response", "created_at"}).
+			AddRow("key-1", "u1", "hash", "13", `{"id":"13"}`, time.Now()))
+	mock.ExpectQuery(query).
+		WithArgs("u1", "missing").
+		WillReturnError(sql.ErrNoRows)
+
+	k, err := repo.GetIdempotencyKey("u1", "key-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "hash", k.RequestHash)
+	assert.JSONEq(t, `{"id":"13"}`, string(k.Response))
+
+	_, err = repo.GetIdempotencyKey("u1", "missing")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "idempotency key not found")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCreateRefund_PartialKeepsStatus(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO refunds(payment_id, amount, reason, created_by, created_at)")).
+		WithArgs(int64(2000), "damaged", "u1", sqlmock.AnyArg(), "p1", "partially_refunded", int64(2000)).
+		WillReturnResult(sqlmock.NewResult(4, 1))
+	mock.ExpectCommit()
+
+	refund, err := repo.CreateRefund(&entity.Refund{PaymentID: "p1", Amount: 2000, Reason: "damaged", CreatedBy: "u1"},
+		entity.PaymentStatusPartiallyRefunded, entity.PaymentStatusPartiallyRefunded)
+	assert.NoError(t, err)
+	assert.Equal(t, "4", refund.ID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCreateRefund_FullRefundMovesStatus(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO refunds(payment_id, amount, reason, created_by, created_at)")).
+		WithArgs(int64(10000), "", "u1", sqlmock.AnyArg(), "p1", "completed", int64(10000)).
+		WillReturnResult(sqlmock.NewResult(5, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE payments SET status = ? WHERE id = ? AND status = ?")).
+		WithArgs("refunded", "p1", "completed").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO payment_status_history")).
+		WithArgs("p1", "completed", "refunded", "u1", "refund 5", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	refund, err := repo.CreateRefund(&entity.Refund{PaymentID: "p1", Amount: 10000, CreatedBy: "u1"},
+		entity.PaymentStatusCompleted, entity.PaymentStatusRefunded)
+	assert.NoError(t, err)
+	assert.Equal(t, "5", refund.ID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCreateRefund_ExceedsAmount(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO refunds(payment_id, amount, reason, created_by, created_at)")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err := repo.CreateRefund(&entity.Refund{PaymentID: "p1", Amount: 50000, CreatedBy: "u1"},
+		entity.PaymentStatusCompleted, entity.PaymentStatusRefunded)
+	var appErr *entity.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, entity.ErrorCodeConflict, appErr.Code)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestListRefunds(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM refunds f LEFT JOIN users u ON u.id = f.created_by")).
+		WithArgs("p1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "payment_id", "amount", "reason", "created_by", "email", "created_at"}).
+			AddRow("1", "p1", 2000, "damaged", "u1", "op@example.com", time.Now()).
+			AddRow("2", "p1", 3000, "", "u1", "op@example.com", time.Now()))
+
+	refunds, err := repo.ListRefunds("p1")
+	assert.NoError(t, err)
+	assert.Len(t, refunds, 2)
+	assert.Equal(t, int64(3000), refunds[1].Amount)
+	assert.Equal(t, "op@example.com", refunds[1].CreatedByEmail)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestListStatusHistory(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM payment_status_history h LEFT JOIN users u ON u.id = h.changed_by")).
+		WithArgs("p1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "payment_id", "from_status", "to_status", "changed_by", "email", "reason", "changed_at"}).
+			AddRow("1", "p1", "pending", "processing", "u1", "op@example.com", "", time.Now()))
+
+	changes, err := repo.ListStatusHistory("p1")
+	assert.NoError(t, err)
+	assert.Len(t, changes, 1)
+	assert.Equal(t, entity.PaymentStatusProcessing, changes[0].ToStatus)
+	assert.Equal(t, "op@example.com", changes[0].ChangedByEmail)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestAddNote_PaymentNotFound(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO payment_notes(payment_id, author_id, body, created_at) SELECT id, ?, ?, ? FROM payments WHERE id = ?")).
+		WithArgs("u1", "called merchant", sqlmock.AnyArg(), "99").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	_, err := repo.AddNote(&entity.PaymentNote{PaymentID: "99", AuthorID: "u1", Body: "called merchant"})
+	var appErr *entity.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, entity.ErrorCodeNotFound, appErr.Code)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
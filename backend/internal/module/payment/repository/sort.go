@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+)
+
+// sortColumn is a sortable field: the SQL expression it sorts by, the expression a cursor
+// value is compared through and how the value is read from a payment. Fields without a
+// value cannot be paged by cursor.
+type sortColumn struct {
+	expr  string
+	param string
+	value func(p *entity.Payment) string
+}
+
+// paymentSortColumns whitelists the payment sort fields to avoid SQL injection. created_at
+// goes through julianday because timestamps are not stored in a single format.
+var paymentSortColumns = map[string]sortColumn{
+	"id":       {"p.id", "?", func(p *entity.Payment) string { return p.ID }},
+	"merchant": {"m.display_name", "?", func(p *entity.Payment) string { return p.Merchant }},
+	"status":   {"p.status", "?", func(p *entity.Payment) string { return string(p.Status) }},
+	"amount":   {"p.amount", "?", func(p *entity.Payment) string { return strconv.FormatInt(p.Amount, 10) }},
+	"created_at": {"julianday(p.created_at)", "julianday(?)", func(p *entity.Payment) string {
+		return p.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}},
+	// relevance is the bm25 rank of the search match, lower ranks match better
+	"relevance": {"payment_search.rank", "", nil},
+}
+
+// merchantReportSortColumns whitelists the merchant report sort fields, the aggregates are
+// repeated rather than referenced by alias so they also work inside expressions.
+var merchantReportSortColumns = map[string]sortColumn{
+	"merchant_id":     {"p.merchant_id", "", nil},
+	"merchant":        {"m.display_name", "", nil},
+	"currency":        {"p.currency", "", nil},
+	"count":           {"COUNT(1)", "", nil},
+	"volume":          {"SUM(p.amount)", "", nil},
+	"success_rate":    {"1.0 * " + reportSucceeded + " / COUNT(1)", "", nil},
+	"failure_rate":    {"1.0 * " + reportFailed + " / COUNT(1)", "", nil},
+	"average_amount":  {"SUM(p.amount) / COUNT(1)", "", nil},
+	"last_payment_at": {"MAX(julianday(p.created_at))", "", nil},
+}
+
+type sortKey struct {
+	field  string
+	column sortColumn
+	desc   bool
+}
+
+// parseSort turns a comma separated sort expression like "status,-amount" into sort keys,
+// a "-" prefix sorts descending. An empty expression sorts by defaultExpr. The id field is
+// always appended as the last key so rows with equal keys keep a stable order.
+func parseSort(sortExpr, defaultExpr string, columns map[string]sortColumn, idField string) ([]sortKey, error) {
+	if strings.TrimSpace(sortExpr) == "" {
+		sortExpr = defaultExpr
+	}
+	keys := []sortKey{}
+	seen := map[string]bool{}
+	for _, field := range strings.Split(sortExpr, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		desc := strings.HasPrefix(field, "-")
+		field = strings.TrimPrefix(field, "-")
+		col, ok := columns[field]
+		if !ok {
+			appErr := entity.ErrorValidation(fmt.Sprintf("unknown sort field %q", field))
+			appErr.Details = map[string]any{"allowed": sortFields(columns)}
+			return nil, appErr
+		}
+		if seen[field] {
+			return nil, entity.ErrorValidation(fmt.Sprintf("sort field %q is given more than once", field))
+		}
+		seen[field] = true
+		keys = append(keys, sortKey{field: field, column: col, desc: desc})
+	}
+	if !seen[idField] {
+		keys = append(keys, sortKey{field: idField, column: columns[idField]})
+	}
+	return keys, nil
+}
+
+// orderBy builds the ORDER BY clause of the keys, reversed walks the list backwards.
+func orderBy(keys []sortKey, reversed bool) string {
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		dir := "ASC"
+		if k.desc != reversed {
+			dir = "DESC"
+		}
+		parts[i] = k.column.expr + " " + dir
+	}
+	return " ORDER BY " + strings.Join(parts, ", ")
+}
+
+// keysetWhere builds the condition selecting the rows after the cursor key in the order of
+// keys, or before it when reversed: (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ...
+func keysetWhere(keys []sortKey, values []string, reversed bool) (string, []interface{}, error) {
+	for _, k := range keys {
+		if k.column.value == nil {
+			return "", nil, entity.ErrorValidation(fmt.Sprintf("cursor cannot be used when sorting by %s, use offset", k.field))
+		}
+	}
+	if len(values) != len(keys) {
+		return "", nil, entity.ErrorValidation("invalid cursor")
+	}
+	or := []string{}
+	args := []interface{}{}
+	for i, k := range keys {
+		and := []string{}
+		for _, prev := range keys[:i] {
+			and = append(and, prev.column.expr+" = "+prev.column.param)
+		}
+		op := ">"
+		if k.desc != reversed {
+			op = "<"
+		}
+		and = append(and, k.column.expr+" "+op+" "+k.column.param)
+		or = append(or, "("+strings.Join(and, " AND ")+")")
+		for _, v := range values[:i+1] {
+			args = append(args, v)
+		}
+	}
+	return "(" + strings.Join(or, " OR ") + ")", args, nil
+}
+
+// keyOf reads the cursor key of a payment for the given sort keys, nil when one of the
+// keys cannot be paged by cursor.
+func keyOf(keys []sortKey, p *entity.Payment) []string {
+	values := make([]string, len(keys))
+	for i, k := range keys {
+		if k.column.value == nil {
+			return nil
+		}
+		values[i] = k.column.value(p)
+	}
+	return values
+}
+
+// hasField reports whether the sort keys sort by field.
+func hasField(keys []sortKey, field string) bool {
+	for _, k := range keys {
+		if k.field == field {
+			return true
+		}
+	}
+	return false
+}
+
+func sortFields(columns map[string]sortColumn) []string {
+	fields := make([]string, 0, len(columns))
+	for field := range columns {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		name     string
+		sortExpr string
+		expected string
+	}{
+		{"default", "", " ORDER BY julianday(p.created_at) ASC, p.id ASC"},
+		{"single descending", "-created_at", " ORDER BY julianday(p.created_at) DESC, p.id ASC"},
+		{"multiple keys", "status, -amount,created_at", " ORDER BY p.status ASC, p.amount DESC, julianday(p.created_at) ASC, p.id ASC"},
+		{"merchant", "merchant", " ORDER BY m.display_name ASC, p.id ASC"},
+		{"explicit id", "-id", " ORDER BY p.id DESC"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := parseSort(tt.sortExpr, "created_at", paymentSortColumns, "id")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, orderBy(keys, false))
+		})
+	}
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := parseSort("status,-fee", "created_at", paymentSortColumns, "id")
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeValidation, appErr.Code)
+		assert.Equal(t, map[string]any{"allowed": []string{"amount", "created_at", "id", "merchant", "relevance", "status"}}, appErr.Details)
+	})
+
+	t.Run("duplicate field", func(t *testing.T) {
+		_, err := parseSort("amount,-amount", "created_at", paymentSortColumns, "id")
+		assert.Error(t, err)
+	})
+}
+
+func TestKeysetWhere(t *testing.T) {
+	keys, err := parseSort("status,-created_at", "created_at", paymentSortColumns, "id")
+	assert.NoError(t, err)
+
+	t.Run("after", func(t *testing.T) {
+		where, args, err := keysetWhere(keys, []string{"completed", "2025-01-02T03:04:05Z", "9"}, false)
+		assert.NoError(t, err)
+		assert.Equal(t, "((p.status > ?) OR (p.status = ? AND julianday(p.created_at) < julianday(?)) OR "+
+			"(p.status = ? AND julianday(p.created_at) = julianday(?) AND p.id > ?))", where)
+		assert.Equal(t, []interface{}{"completed", "completed", "2025-01-02T03:04:05Z", "completed", "2025-01-02T03:04:05Z", "9"}, args)
+	})
+
+	t.Run("before", func(t *testing.T) {
+		where, _, err := keysetWhere(keys, []string{"completed", "2025-01-02T03:04:05Z", "9"}, true)
+		assert.NoError(t, err)
+		assert.Equal(t, "((p.status < ?) OR (p.status = ? AND julianday(p.created_at) > julianday(?)) OR "+
+			"(p.status = ? AND julianday(p.created_at) = julianday(?) AND p.id < ?))", where)
+		assert.Equal(t, " ORDER BY p.status DESC, julianday(p.created_at) ASC, p.id DESC", orderBy(keys, true))
+	})
+
+	t.Run("wrong key length", func(t *testing.T) {
+		_, _, err := keysetWhere(keys, []string{"completed"}, false)
+		assert.Error(t, err)
+	})
+}
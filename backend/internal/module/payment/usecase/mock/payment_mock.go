@@ -35,33 +35,155 @@ func (m *MockPaymentUsecase) EXPECT() *MockPaymentUsecaseMockRecorder {
 	return m.recorder
 }
 
-// ListPayment mocks base method.
-func (m *MockPaymentUsecase) ListPayment(status, id, sortExpr string, limit, offset int) ([]*entity.Payment, *entity.PaymentSummary, error) {
+// AddPaymentNote mocks base method.
+func (m *MockPaymentUsecase) AddPaymentNote(ctx context.Context, id, body string) (*entity.PaymentNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPaymentNote", ctx, id, body)
+	ret0, _ := ret[0].(*entity.PaymentNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPaymentNote indicates an expected call of AddPaymentNote.
+func (mr *MockPaymentUsecaseMockRecorder) AddPaymentNote(ctx, id, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPaymentNote", reflect.TypeOf((*MockPaymentUsecase)(nil).AddPaymentNote), ctx, id, body)
+}
+
+// CreatePayment mocks base method.
+func (m *MockPaymentUsecase) CreatePayment(ctx context.Context, input entity.CreatePaymentInput, idempotencyKey string) (*entity.Payment, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePayment", ctx, input, idempotencyKey)
+	ret0, _ := ret[0].(*entity.Payment)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreatePayment indicates an expected call of CreatePayment.
+func (mr *MockPaymentUsecaseMockRecorder) CreatePayment(ctx, input, idempotencyKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayment", reflect.TypeOf((*MockPaymentUsecase)(nil).CreatePayment), ctx, input, idempotencyKey)
+}
+
+// ExportPayments mocks base method.
+func (m *MockPaymentUsecase) ExportPayments(ctx context.Context, filter entity.PaymentFilter, sortExpr string, fn func(*entity.Payment) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportPayments", ctx, filter, sortExpr, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportPayments indicates an expected call of ExportPayments.
+func (mr *MockPaymentUsecaseMockRecorder) ExportPayments(ctx, filter, sortExpr, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportPayments", reflect.TypeOf((*MockPaymentUsecase)(nil).ExportPayments), ctx, filter, sortExpr, fn)
+}
+
+// GetPayment mocks base method.
+func (m *MockPaymentUsecase) GetPayment(ctx context.Context, id string) (*entity.Payment, []*entity.PaymentEvent, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPayment", status, id, sortExpr, limit, offset)
-	ret0, _ := ret[0].([]*entity.Payment)
-	ret1, _ := ret[1].(*entity.PaymentSummary)
+	ret := m.ctrl.Call(m, "GetPayment", ctx, id)
+	ret0, _ := ret[0].(*entity.Payment)
+	ret1, _ := ret[1].([]*entity.PaymentEvent)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
+// GetPayment indicates an expected call of GetPayment.
+func (mr *MockPaymentUsecaseMockRecorder) GetPayment(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayment", reflect.TypeOf((*MockPaymentUsecase)(nil).GetPayment), ctx, id)
+}
+
+// ListPayment mocks base method.
+func (m *MockPaymentUsecase) ListPayment(ctx context.Context, filter entity.PaymentFilter, sortExpr string, limit, offset int, cursor string, summaryScope entity.PaymentSummaryScope) (*entity.PaymentPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPayment", ctx, filter, sortExpr, limit, offset, cursor, summaryScope)
+	ret0, _ := ret[0].(*entity.PaymentPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // ListPayment indicates an expected call of ListPayment.
-func (mr *MockPaymentUsecaseMockRecorder) ListPayment(status, id, sortExpr, limit, offset interface{}) *gomock.Call {
+func (mr *MockPaymentUsecaseMockRecorder) ListPayment(ctx, filter, sortExpr, limit, offset, cursor, summaryScope interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPayment", reflect.TypeOf((*MockPaymentUsecase)(nil).ListPayment), status, id, sortExpr, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPayment", reflect.TypeOf((*MockPaymentUsecase)(nil).ListPayment), ctx, filter, sortExpr, limit, offset, cursor, summaryScope)
+}
+
+// MerchantReport mocks base method.
+func (m *MockPaymentUsecase) MerchantReport(ctx context.Context, filter entity.MerchantReportFilter, sortExpr string, limit, offset int) ([]*entity.MerchantReport, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MerchantReport", ctx, filter, sortExpr, limit, offset)
+	ret0, _ := ret[0].([]*entity.MerchantReport)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// MerchantReport indicates an expected call of MerchantReport.
+func (mr *MockPaymentUsecaseMockRecorder) MerchantReport(ctx, filter, sortExpr, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MerchantReport", reflect.TypeOf((*MockPaymentUsecase)(nil).MerchantReport), ctx, filter, sortExpr, limit, offset)
+}
+
+// PaymentAnalytics mocks base method.
+func (m *MockPaymentUsecase) PaymentAnalytics(ctx context.Context, query entity.PaymentAnalyticsQuery) (*entity.PaymentAnalytics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PaymentAnalytics", ctx, query)
+	ret0, _ := ret[0].(*entity.PaymentAnalytics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PaymentAnalytics indicates an expected call of PaymentAnalytics.
+func (mr *MockPaymentUsecaseMockRecorder) PaymentAnalytics(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaymentAnalytics", reflect.TypeOf((*MockPaymentUsecase)(nil).PaymentAnalytics), ctx, query)
+}
+
+// RefundPayment mocks base method.
+func (m *MockPaymentUsecase) RefundPayment(ctx context.Context, id, amount, reason string) (*entity.Refund, *entity.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundPayment", ctx, id, amount, reason)
+	ret0, _ := ret[0].(*entity.Refund)
+	ret1, _ := ret[1].(*entity.Payment)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RefundPayment indicates an expected call of RefundPayment.
+func (mr *MockPaymentUsecaseMockRecorder) RefundPayment(ctx, id, amount, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundPayment", reflect.TypeOf((*MockPaymentUsecase)(nil).RefundPayment), ctx, id, amount, reason)
 }
 
 // ReviewPayment mocks base method.
-func (m *MockPaymentUsecase) ReviewPayment(ctx context.Context, id string) (string, error) {
+func (m *MockPaymentUsecase) ReviewPayment(ctx context.Context, id string, outcome entity.ReviewOutcome, note string) (*entity.PaymentReview, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReviewPayment", ctx, id)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "ReviewPayment", ctx, id, outcome, note)
+	ret0, _ := ret[0].(*entity.PaymentReview)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ReviewPayment indicates an expected call of ReviewPayment.
-func (mr *MockPaymentUsecaseMockRecorder) ReviewPayment(ctx, id interface{}) *gomock.Call {
+func (mr *MockPaymentUsecaseMockRecorder) ReviewPayment(ctx, id, outcome, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewPayment", reflect.TypeOf((*MockPaymentUsecase)(nil).ReviewPayment), ctx, id, outcome, note)
+}
+
+// UpdatePaymentStatus mocks base method.
+func (m *MockPaymentUsecase) UpdatePaymentStatus(ctx context.Context, id string, status entity.PaymentStatus, reason string) (*entity.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentStatus", ctx, id, status, reason)
+	ret0, _ := ret[0].(*entity.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePaymentStatus indicates an expected call of UpdatePaymentStatus.
+func (mr *MockPaymentUsecaseMockRecorder) UpdatePaymentStatus(ctx, id, status, reason interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewPayment", reflect.TypeOf((*MockPaymentUsecase)(nil).ReviewPayment), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentStatus", reflect.TypeOf((*MockPaymentUsecase)(nil).UpdatePaymentStatus), ctx, id, status, reason)
 }
@@ -2,44 +2,540 @@ package usecase
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
 
+	"github.com/fajrinajiseno/mygolangapp/internal/authz"
 	"github.com/fajrinajiseno/mygolangapp/internal/entity"
-	"github.com/fajrinajiseno/mygolangapp/internal/middleware"
-	authRepository "github.com/fajrinajiseno/mygolangapp/internal/module/auth/repository"
+	merchantRepository "github.com/fajrinajiseno/mygolangapp/internal/module/merchant/repository"
 	paymentRepository "github.com/fajrinajiseno/mygolangapp/internal/module/payment/repository"
+	"github.com/fajrinajiseno/mygolangapp/internal/pagination"
 )
 
 //go:generate mockgen -source payment.go -destination mock/payment_mock.go -package=mock
 type PaymentUsecase interface {
-	ListPayment(status string, id string, sortExpr string, limit int, offset int) ([]*entity.Payment, *entity.PaymentSummary, error)
-	ReviewPayment(ctx context.Context, id string) (string, error)
+	ListPayment(ctx context.Context, filter entity.PaymentFilter, sortExpr string, limit int, offset int, cursor string, summaryScope entity.PaymentSummaryScope) (*entity.PaymentPage, error)
+	ExportPayments(ctx context.Context, filter entity.PaymentFilter, sortExpr string, fn func(p *entity.Payment) error) error
+	PaymentAnalytics(ctx context.Context, query entity.PaymentAnalyticsQuery) (*entity.PaymentAnalytics, error)
+	MerchantReport(ctx context.Context, filter entity.MerchantReportFilter, sortExpr string, limit int, offset int) ([]*entity.MerchantReport, int, error)
+	ReviewPayment(ctx context.Context, id string, outcome entity.ReviewOutcome, note string) (*entity.PaymentReview, error)
+	UpdatePaymentStatus(ctx context.Context, id string, status entity.PaymentStatus, reason string) (*entity.Payment, error)
+	CreatePayment(ctx context.Context, input entity.CreatePaymentInput, idempotencyKey string) (*entity.Payment, bool, error)
+	RefundPayment(ctx context.Context, id string, amount string, reason string) (*entity.Refund, *entity.Payment, error)
+	GetPayment(ctx context.Context, id string) (*entity.Payment, []*entity.PaymentEvent, error)
+	AddPaymentNote(ctx context.Context, id string, body string) (*entity.PaymentNote, error)
 }
 
 type Payment struct {
-	userRepo    authRepository.UserRepository
-	paymentRepo paymentRepository.PaymentRepository
+	authorizer   authz.Authorizer
+	paymentRepo  paymentRepository.PaymentRepository
+	merchantRepo merchantRepository.MerchantRepository
+	cursorSigner *pagination.Signer
 }
 
-func NewPaymentUsecase(pr paymentRepository.PaymentRepository, ur authRepository.UserRepository) *Payment {
-	return &Payment{paymentRepo: pr, userRepo: ur}
+func NewPaymentUsecase(pr paymentRepository.PaymentRepository, az authz.Authorizer, mr merchantRepository.MerchantRepository, cs *pagination.Signer) *Payment {
+	return &Payment{paymentRepo: pr, authorizer: az, merchantRepo: mr, cursorSigner: cs}
 }
 
-func (u *Payment) ListPayment(status string, id string, sortExpr string, limit int, offset int) ([]*entity.Payment, *entity.PaymentSummary, error) {
-	return u.paymentRepo.GetPayments(status, id, sortExpr, limit, offset)
+// ListPayment returns a page of payments. The page starts at offset, or at cursor when one
+// of the next_cursor/prev_cursor tokens of a previous page is given. A cursor only fits the
+// sort and filter it was issued for.
+func (u *Payment) ListPayment(ctx context.Context, filter entity.PaymentFilter, sortExpr string, limit int, offset int, cursor string, summaryScope entity.PaymentSummaryScope) (*entity.PaymentPage, error) {
+	if _, err := u.authorizer.Authorize(ctx, entity.PermissionPaymentRead); err != nil {
+		return nil, err
+	}
+	if summaryScope == "" {
+		summaryScope = entity.PaymentSummaryScopeAll
+	}
+	if !summaryScope.Valid() {
+		return nil, entity.ErrorValidation("invalid summary scope")
+	}
+	if err := validatePaymentFilter(&filter); err != nil {
+		return nil, err
+	}
+
+	query := pagination.Fingerprint(struct {
+		Sort   string
+		Filter entity.PaymentFilter
+	}{sortExpr, filter})
+	var pageCursor *entity.PageCursor
+	if cursor != "" {
+		if offset > 0 {
+			return nil, entity.ErrorValidation("cursor and offset cannot be used together")
+		}
+		decoded, err := u.cursorSigner.Decode(cursor)
+		if err != nil {
+			return nil, err
+		}
+		if decoded.Query != query {
+			return nil, entity.ErrorValidation("cursor does not match the sort and filter of the request")
+		}
+		pageCursor = &entity.PageCursor{Key: decoded.Key, Before: decoded.Before}
+	}
+
+	page, err := u.paymentRepo.GetPayments(filter, sortExpr, limit, offset, pageCursor, summaryScope)
+	if err != nil {
+		return nil, err
+	}
+	if page.NextKey != nil {
+		page.NextCursor = u.cursorSigner.Encode(pagination.Cursor{Query: query, Key: page.NextKey})
+	}
+	if page.PrevKey != nil {
+		page.PrevCursor = u.cursorSigner.Encode(pagination.Cursor{Query: query, Before: true, Key: page.PrevKey})
+	}
+	return page, nil
+}
+
+// ExportPayments calls fn for every payment matching the filter, for writing them out
+// without holding the whole list in memory.
+func (u *Payment) ExportPayments(ctx context.Context, filter entity.PaymentFilter, sortExpr string, fn func(p *entity.Payment) error) error {
+	if _, err := u.authorizer.Authorize(ctx, entity.PermissionPaymentRead); err != nil {
+		return err
+	}
+	if err := validatePaymentFilter(&filter); err != nil {
+		return err
+	}
+	return u.paymentRepo.StreamPayments(filter, sortExpr, fn)
+}
+
+// maxAnalyticsBuckets bounds the length of a time series so that a wide range at a fine
+// interval cannot ask for an unbounded response.
+const maxAnalyticsBuckets = 1000
+
+// PaymentAnalytics returns the payment volume per status bucketed by interval, with the
+// buckets aligned to the query timezone. The range defaults to the 30 days before now, its
+// first and last buckets are widened to whole intervals.
+func (u *Payment) PaymentAnalytics(ctx context.Context, query entity.PaymentAnalyticsQuery) (*entity.PaymentAnalytics, error) {
+	if _, err := u.authorizer.Authorize(ctx, entity.PermissionPaymentRead); err != nil {
+		return nil, err
+	}
+	if query.Interval == "" {
+		query.Interval = entity.AnalyticsIntervalDay
+	}
+	if !query.Interval.Valid() {
+		return nil, entity.ErrorValidation("invalid interval")
+	}
+	if query.Timezone == "" {
+		query.Timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(query.Timezone)
+	if err != nil || query.Timezone == "Local" {
+		return nil, entity.ErrorValidation("unknown timezone " + query.Timezone)
+	}
+	to := time.Now()
+	if query.To != nil {
+		to = *query.To
+	}
+	from := to.AddDate(0, 0, -30)
+	if query.From != nil {
+		from = *query.From
+	}
+	if !to.After(from) {
+		return nil, entity.ErrorValidation("to must be after from")
+	}
+
+	bounds := []time.Time{query.Interval.Truncate(from.In(loc))}
+	for bounds[len(bounds)-1].Before(to) {
+		if len(bounds) > maxAnalyticsBuckets {
+			return nil, entity.ErrorValidation(fmt.Sprintf("range is too long for the interval, at most %d buckets are returned", maxAnalyticsBuckets))
+		}
+		bounds = append(bounds, query.Interval.Next(bounds[len(bounds)-1]))
+	}
+
+	buckets, err := u.paymentRepo.GetPaymentSeries(entity.PaymentFilter{MerchantID: query.MerchantID}, bounds)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.PaymentAnalytics{Interval: query.Interval, Timezone: loc.String(), Buckets: buckets}, nil
 }
 
-func (u *Payment) ReviewPayment(ctx context.Context, id string) (string, error) {
-	userId := middleware.GetUserID(ctx)
-	if userId == "" {
-		return "", entity.ErrorNotFound("user not found")
+// MerchantReport returns a page of per merchant and currency payment aggregates together
+// with the number of merchant and currency pairs.
+func (u *Payment) MerchantReport(ctx context.Context, filter entity.MerchantReportFilter, sortExpr string, limit int, offset int) ([]*entity.MerchantReport, int, error) {
+	if _, err := u.authorizer.Authorize(ctx, entity.PermissionPaymentRead); err != nil {
+		return nil, 0, err
+	}
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedTo.After(*filter.CreatedFrom) {
+		return nil, 0, entity.ErrorValidation("to must be after from")
 	}
-	user, err := u.userRepo.GetUserById(userId)
+	if filter.Currency != "" {
+		filter.Currency = strings.ToUpper(filter.Currency)
+		if _, ok := entity.CurrencyExponent(filter.Currency); !ok {
+			return nil, 0, entity.ErrorValidation("unsupported currency " + filter.Currency)
+		}
+	}
+	return u.paymentRepo.GetMerchantReport(filter, sortExpr, limit, offset)
+}
+
+func (u *Payment) ReviewPayment(ctx context.Context, id string, outcome entity.ReviewOutcome, note string) (*entity.PaymentReview, error) {
+	user, err := u.authorizer.Authorize(ctx, entity.PermissionPaymentReview)
 	if err != nil {
-		return "", entity.ErrorNotFound("user not found")
+		return nil, err
+	}
+	if outcome == "" {
+		outcome = entity.ReviewOutcomeApproved
 	}
-	const OperationRole = "operation"
-	if user.Role != OperationRole {
-		return "", entity.ErrorForbidden("user forbidden")
+	if !outcome.Valid() {
+		return nil, entity.ErrorValidation("invalid review outcome")
+	}
+	review, err := u.paymentRepo.Review(id, user.ID, outcome, note)
+	if err != nil {
+		return nil, err
+	}
+	review.ReviewerEmail = user.Email
+	return review, nil
+}
+
+// validatePaymentFilter rejects invalid filters and upper cases the currency.
+func validatePaymentFilter(filter *entity.PaymentFilter) error {
+	if filter.Search != "" && strings.IndexFunc(filter.Search, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) < 0 {
+		return entity.ErrorValidation("q must contain a letter or digit")
+	}
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedTo.After(*filter.CreatedFrom) {
+		return entity.ErrorValidation("created_to must be after created_from")
+	}
+	if filter.Currency != "" {
+		filter.Currency = strings.ToUpper(filter.Currency)
+		if _, ok := entity.CurrencyExponent(filter.Currency); !ok {
+			return entity.ErrorValidation("unsupported currency " + filter.Currency)
+		}
+	}
+	// minor units of different currencies are not comparable
+	if (filter.AmountMin != nil || filter.AmountMax != nil) && filter.Currency == "" {
+		return entity.ErrorValidation("amount_min and amount_max require currency")
+	}
+	if (filter.AmountMin != nil && *filter.AmountMin < 0) || (filter.AmountMax != nil && *filter.AmountMax < 0) {
+		return entity.ErrorValidation("amount_min and amount_max must not be negative")
+	}
+	if filter.AmountMin != nil && filter.AmountMax != nil && *filter.AmountMin > *filter.AmountMax {
+		return entity.ErrorValidation("amount_min must not be greater than amount_max")
+	}
+	return nil
+}
+
+// UpdatePaymentStatus moves a payment to the given status when the transition is allowed.
+func (u *Payment) UpdatePaymentStatus(ctx context.Context, id string, status entity.PaymentStatus, reason string) (*entity.Payment, error) {
+	user, err := u.authorizer.Authorize(ctx, entity.PermissionPaymentUpdateStatus)
+	if err != nil {
+		return nil, err
+	}
+	if !status.Valid() {
+		return nil, entity.ErrorValidation("invalid payment status")
+	}
+	if status.SetByRefund() {
+		return nil, entity.ErrorConflict(fmt.Sprintf("payment status %s is set by creating refunds", status))
+	}
+	payment, err := u.paymentRepo.GetPaymentByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !payment.Status.CanTransitionTo(status) {
+		appErr := entity.ErrorConflict(fmt.Sprintf("cannot change payment status from %s to %s", payment.Status, status))
+		appErr.Details = map[string]any{"allowed": payment.Status.NextStatuses()}
+		return nil, appErr
+	}
+	if _, err := u.paymentRepo.UpdateStatus(payment.ID, payment.Status, status, user.ID, reason); err != nil {
+		return nil, err
+	}
+	return u.paymentDetail(payment.ID)
+}
+
+// RefundPayment refunds part or all of a completed payment. The payment becomes
+// partially_refunded, or refunded once the refunds add up to the payment amount.
+func (u *Payment) RefundPayment(ctx context.Context, id string, amount string, reason string) (*entity.Refund, *entity.Payment, error) {
+	user, err := u.authorizer.Authorize(ctx, entity.PermissionPaymentRefund)
+	if err != nil {
+		return nil, nil, err
+	}
+	payment, err := u.paymentRepo.GetPaymentByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	refundAmount, err := entity.ParseAmount(amount, payment.Currency)
+	if err != nil || refundAmount <= 0 {
+		return nil, nil, entity.ErrorValidation(fmt.Sprintf("amount must be a positive %s amount", payment.Currency))
+	}
+	if !payment.Status.Refundable() {
+		return nil, nil, entity.ErrorConflict(fmt.Sprintf("payment with status %s cannot be refunded", payment.Status))
+	}
+	remaining := payment.Amount - payment.RefundedAmount
+	if refundAmount > remaining {
+		appErr := entity.ErrorValidation("refund amount exceeds the remaining refundable amount")
+		appErr.Details = map[string]any{"remaining": entity.FormatAmount(remaining, payment.Currency)}
+		return nil, nil, appErr
+	}
+	status := entity.PaymentStatusPartiallyRefunded
+	if refundAmount == remaining {
+		status = entity.PaymentStatusRefunded
+	}
+
+	refund, err := u.paymentRepo.CreateRefund(&entity.Refund{
+		PaymentID: payment.ID,
+		Amount:    refundAmount,
+		Reason:    reason,
+		CreatedBy: user.ID,
+	}, payment.Status, status)
+	if err != nil {
+		return nil, nil, err
+	}
+	payment, err = u.paymentDetail(payment.ID)
+	if err != nil {
+		return nil, nil, err
 	}
-	return u.paymentRepo.Review(id)
+	return refund, payment, nil
+}
+
+// GetPayment returns a payment with its refunds and the timeline of everything that
+// happened to it, oldest event first.
+func (u *Payment) GetPayment(ctx context.Context, id string) (*entity.Payment, []*entity.PaymentEvent, error) {
+	if _, err := u.authorizer.Authorize(ctx, entity.PermissionPaymentRead); err != nil {
+		return nil, nil, err
+	}
+	payment, err := u.paymentDetail(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	changes, err := u.paymentRepo.ListStatusHistory(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	reviews, err := u.paymentRepo.ListReviews(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	notes, err := u.paymentRepo.ListNotes(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return payment, paymentTimeline(payment, changes, reviews, notes), nil
+}
+
+// AddPaymentNote leaves a note on a payment, any dashboard user may add notes.
+func (u *Payment) AddPaymentNote(ctx context.Context, id string, body string) (*entity.PaymentNote, error) {
+	user, err := u.authorizer.Authorize(ctx, entity.PermissionPaymentNote)
+	if err != nil {
+		return nil, err
+	}
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, entity.ErrorValidation("note is required")
+	}
+	note, err := u.paymentRepo.AddNote(&entity.PaymentNote{
+		PaymentID: id,
+		AuthorID:  user.ID,
+		Body:      body,
+	})
+	if err != nil {
+		return nil, err
+	}
+	note.AuthorEmail = user.Email
+	return note, nil
+}
+
+// paymentTimeline merges the records of a payment into events ordered by time. Events
+// with the same time keep the order of creation, status changes, reviews, refunds and notes.
+func paymentTimeline(payment *entity.Payment, changes []*entity.PaymentStatusChange, reviews []*entity.PaymentReview, notes []*entity.PaymentNote) []*entity.PaymentEvent {
+	events := []*entity.PaymentEvent{{
+		Type:   entity.PaymentEventCreated,
+		ID:     payment.ID,
+		At:     payment.CreatedAt,
+		Amount: payment.Amount,
+	}}
+	for _, c := range changes {
+		events = append(events, &entity.PaymentEvent{
+			Type:       entity.PaymentEventStatusChanged,
+			ID:         c.ID,
+			At:         c.ChangedAt,
+			ActorID:    c.ChangedBy,
+			ActorEmail: c.ChangedByEmail,
+			FromStatus: c.FromStatus,
+			ToStatus:   c.ToStatus,
+			Note:       c.Reason,
+		})
+	}
+	for _, r := range reviews {
+		events = append(events, &entity.PaymentEvent{
+			Type:       entity.PaymentEventReviewed,
+			ID:         r.ID,
+			At:         r.ReviewedAt,
+			ActorID:    r.ReviewerID,
+			ActorEmail: r.ReviewerEmail,
+			Outcome:    r.Outcome,
+			Note:       r.Note,
+		})
+	}
+	for _, r := range payment.Refunds {
+		events = append(events, &entity.PaymentEvent{
+			Type:       entity.PaymentEventRefunded,
+			ID:         r.ID,
+			At:         r.CreatedAt,
+			ActorID:    r.CreatedBy,
+			ActorEmail: r.CreatedByEmail,
+			Amount:     r.Amount,
+			Note:       r.Reason,
+		})
+	}
+	for _, n := range notes {
+		events = append(events, &entity.PaymentEvent{
+			Type:       entity.PaymentEventNoteAdded,
+			ID:         n.ID,
+			At:         n.CreatedAt,
+			ActorID:    n.AuthorID,
+			ActorEmail: n.AuthorEmail,
+			Note:       n.Body,
+		})
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].At.Before(events[j].At)
+	})
+	return events
+}
+
+// paymentDetail loads a payment together with its refunds.
+func (u *Payment) paymentDetail(id string) (*entity.Payment, error) {
+	payment, err := u.paymentRepo.GetPaymentByID(id)
+	if err != nil {
+		return nil, err
+	}
+	refunds, err := u.paymentRepo.ListRefunds(id)
+	if err != nil {
+		return nil, err
+	}
+	payment.Refunds = refunds
+	return payment, nil
+}
+
+// CreatePayment creates a pending payment. When an idempotency key is given, a retry with
+// the same key and body returns the originally created payment and true, while a retry
+// with a different body is rejected with a conflict.
+func (u *Payment) CreatePayment(ctx context.Context, input entity.CreatePaymentInput, idempotencyKey string) (*entity.Payment, bool, error) {
+	user, err := u.authorizer.Authorize(ctx, entity.PermissionPaymentCreate)
+	if err != nil {
+		return nil, false, err
+	}
+	payment, err := newPayment(input)
+	if err != nil {
+		return nil, false, err
+	}
+	if idempotencyKey == "" {
+		if err := u.setPaymentMerchant(payment); err != nil {
+			return nil, false, err
+		}
+		created, err := u.paymentRepo.Create(payment, nil)
+		return created, false, err
+	}
+
+	requestHash, err := hashNewPayment(payment)
+	if err != nil {
+		return nil, false, err
+	}
+	replayed, err := u.replayCreatePayment(user.ID, idempotencyKey, requestHash)
+	if err == nil {
+		return replayed, true, nil
+	}
+	if !isNotFound(err) {
+		return nil, false, err
+	}
+
+	if err := u.setPaymentMerchant(payment); err != nil {
+		return nil, false, err
+	}
+	created, err := u.paymentRepo.Create(payment, &entity.IdempotencyKey{
+		Key:         idempotencyKey,
+		UserID:      user.ID,
+		RequestHash: requestHash,
+	})
+	var appErr *entity.AppError
+	if errors.As(err, &appErr) && appErr.Code == entity.ErrorCodeConflict {
+		// a concurrent request stored the key first, answer like a retry would
+		replayed, err = u.replayCreatePayment(user.ID, idempotencyKey, requestHash)
+		if err != nil {
+			return nil, false, err
+		}
+		return replayed, true, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return created, false, nil
+}
+
+// setPaymentMerchant checks that the merchant of a new payment exists and is active and
+// fills in its display name.
+func (u *Payment) setPaymentMerchant(payment *entity.Payment) error {
+	merchant, err := u.merchantRepo.GetMerchantByID(payment.MerchantID)
+	if isNotFound(err) {
+		return entity.ErrorValidation("merchant not found")
+	}
+	if err != nil {
+		return err
+	}
+	if merchant.Status != entity.MerchantStatusActive {
+		return entity.ErrorConflict("merchant is not active")
+	}
+	payment.Merchant = merchant.DisplayName
+	return nil
+}
+
+func (u *Payment) replayCreatePayment(userID, idempotencyKey, requestHash string) (*entity.Payment, error) {
+	stored, err := u.paymentRepo.GetIdempotencyKey(userID, idempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	if stored.RequestHash != requestHash {
+		return nil, entity.ErrorConflict("idempotency key was already used with a different request")
+	}
+	var payment entity.Payment
+	if err := json.Unmarshal(stored.Response, &payment); err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "internal error")
+	}
+	return &payment, nil
+}
+
+func newPayment(input entity.CreatePaymentInput) (*entity.Payment, error) {
+	merchantID := strings.TrimSpace(input.MerchantID)
+	if merchantID == "" {
+		return nil, entity.ErrorValidation("merchant_id is required")
+	}
+	currency := strings.ToUpper(input.Currency)
+	if _, ok := entity.CurrencyExponent(currency); !ok {
+		return nil, entity.ErrorValidation("unsupported currency " + input.Currency)
+	}
+	amount, err := entity.ParseAmount(input.Amount, currency)
+	if err != nil || amount <= 0 {
+		return nil, entity.ErrorValidation(fmt.Sprintf("amount must be a positive %s amount", currency))
+	}
+	return &entity.Payment{
+		MerchantID: merchantID,
+		Amount:     amount,
+		Currency:   currency,
+		Status:     entity.PaymentStatusPending,
+	}, nil
+}
+
+// hashNewPayment hashes the normalized request, so a retry spelling the same payment
+// differently, like 100 and 100.00 or usd and USD, is still a replay.
+func hashNewPayment(payment *entity.Payment) (string, error) {
+	body, err := json.Marshal(struct {
+		MerchantID string `json:"merchant_id"`
+		Amount     int64  `json:"amount"`
+		Currency   string `json:"currency"`
+	}{payment.MerchantID, payment.Amount, payment.Currency})
+	if err != nil {
+		return "", entity.WrapError(err, entity.ErrorCodeInternal, "internal error")
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func isNotFound(err error) bool {
+	var appErr *entity.AppError
+	return errors.As(err, &appErr) && appErr.Code == entity.ErrorCodeNotFound
 }
@@ -6,14 +6,18 @@ import (
 	"testing"
 	"time"
 
+	azm "github.com/fajrinajiseno/mygolangapp/internal/authz/mock"
 	"github.com/fajrinajiseno/mygolangapp/internal/config"
 	"github.com/fajrinajiseno/mygolangapp/internal/entity"
-	am "github.com/fajrinajiseno/mygolangapp/internal/module/auth/repository/mock"
+	mm "github.com/fajrinajiseno/mygolangapp/internal/module/merchant/repository/mock"
 	pm "github.com/fajrinajiseno/mygolangapp/internal/module/payment/repository/mock"
+	"github.com/fajrinajiseno/mygolangapp/internal/pagination"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
 
+var cursorSigner = pagination.NewSigner([]byte("secret"))
+
 func TestPayment_ListPayment(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -29,24 +33,30 @@ func TestPayment_ListPayment(t *testing.T) {
 	}
 
 	mockPaymentRepo := pm.NewMockPaymentRepository(ctrl)
-	mockUserRepo := am.NewMockUserRepository(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+	ctx := context.WithValue(context.Background(), config.ContextUserID, "1")
+	mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentRead).Return(&entity.User{ID: "1", Role: "cs"}, nil).AnyTimes()
+	mockMerchantRepo := mm.NewMockMerchantRepository(ctrl)
 
 	t.Run("success", func(t *testing.T) {
 		mockPaymentRepo.EXPECT().
-			GetPayments("completed", "1", "created_at", 10, 1).
-			Return(expected, &entity.PaymentSummary{
+			GetPayments(entity.PaymentFilter{Status: "completed", ID: "1"}, "created_at", 10, 1, nil, entity.PaymentSummaryScopeAll).
+			Return(&entity.PaymentPage{Payments: expected, Summary: &entity.PaymentSummary{
 				TotalByFiler:   1,
 				Total:          4,
 				TotalCompleted: 2,
 				TotalFailed:    1,
 				TotalPending:   1,
-			}, nil)
+			}}, nil)
 
-		u := NewPaymentUsecase(mockPaymentRepo, mockUserRepo)
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
 
-		items, totalSummary, err := u.ListPayment("completed", "1", "created_at", 10, 1)
+		page, err := u.ListPayment(ctx, entity.PaymentFilter{Status: "completed", ID: "1"}, "created_at", 10, 1, "", "")
 		assert.NoError(t, err)
-		assert.Equal(t, expected, items)
+		assert.Equal(t, expected, page.Payments)
+		assert.Empty(t, page.NextCursor)
+		assert.Empty(t, page.PrevCursor)
+		totalSummary := page.Summary
 		assert.Equal(t, 1, totalSummary.TotalByFiler)
 		assert.Equal(t, 4, totalSummary.Total)
 		assert.Equal(t, 2, totalSummary.TotalCompleted)
@@ -56,15 +66,307 @@ func TestPayment_ListPayment(t *testing.T) {
 
 	t.Run("Repo Error", func(t *testing.T) {
 		mockPaymentRepo.EXPECT().
-			GetPayments("completed", "1", "created_at", 10, 1).
-			Return(nil, nil, errors.New("db fail"))
+			GetPayments(entity.PaymentFilter{Status: "completed", ID: "1"}, "created_at", 10, 1, nil, entity.PaymentSummaryScopeAll).
+			Return(nil, errors.New("db fail"))
 
-		u := NewPaymentUsecase(mockPaymentRepo, mockUserRepo)
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
 
-		_, _, err := u.ListPayment("completed", "1", "created_at", 10, 1)
+		_, err := u.ListPayment(ctx, entity.PaymentFilter{Status: "completed", ID: "1"}, "created_at", 10, 1, "", "")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "db fail")
 	})
+
+	t.Run("inverted created range", func(t *testing.T) {
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		from := time.Now()
+		to := from.Add(-time.Hour)
+		_, err := u.ListPayment(ctx, entity.PaymentFilter{CreatedFrom: &from, CreatedTo: &to}, "created_at", 10, 1, "", "")
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeValidation, appErr.Code)
+	})
+
+	t.Run("inverted amount range", func(t *testing.T) {
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		amountMin := int64(5000)
+		amountMax := int64(1000)
+		_, err := u.ListPayment(ctx, entity.PaymentFilter{Currency: "USD", AmountMin: &amountMin, AmountMax: &amountMax}, "created_at", 10, 1, "", "")
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeValidation, appErr.Code)
+	})
+
+	t.Run("amount range without currency", func(t *testing.T) {
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		// 1000 would match IDR 1,000 and USD 10.00 alike
+		amountMin := int64(1000)
+		_, err := u.ListPayment(ctx, entity.PaymentFilter{AmountMin: &amountMin}, "created_at", 10, 1, "", "")
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeValidation, appErr.Code)
+		assert.Equal(t, "amount_min and amount_max require currency", appErr.Message)
+
+		_, err = u.ListPayment(ctx, entity.PaymentFilter{Currency: "XYZ"}, "created_at", 10, 1, "", "")
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeValidation, appErr.Code)
+	})
+
+	t.Run("amount range in one currency", func(t *testing.T) {
+		amountMin := int64(1000)
+		mockPaymentRepo.EXPECT().
+			GetPayments(entity.PaymentFilter{Currency: "USD", AmountMin: &amountMin}, "created_at", 10, 1, nil, entity.PaymentSummaryScopeAll).
+			Return(&entity.PaymentPage{Payments: expected, Summary: &entity.PaymentSummary{}}, nil)
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+		_, err := u.ListPayment(ctx, entity.PaymentFilter{Currency: "usd", AmountMin: &amountMin}, "created_at", 10, 1, "", "")
+		assert.NoError(t, err)
+	})
+
+	t.Run("cursor round trip", func(t *testing.T) {
+		filter := entity.PaymentFilter{Status: "completed"}
+		mockPaymentRepo.EXPECT().
+			GetPayments(filter, "-amount", 10, 0, nil, entity.PaymentSummaryScopeAll).
+			Return(&entity.PaymentPage{Payments: expected, Summary: &entity.PaymentSummary{}, NextKey: []string{"100", "1"}}, nil)
+		mockPaymentRepo.EXPECT().
+			GetPayments(filter, "-amount", 10, 0, &entity.PageCursor{Key: []string{"100", "1"}}, entity.PaymentSummaryScopeAll).
+			Return(&entity.PaymentPage{Payments: expected, Summary: &entity.PaymentSummary{}, PrevKey: []string{"90", "2"}}, nil)
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		first, err := u.ListPayment(ctx, filter, "-amount", 10, 0, "", "")
+		assert.NoError(t, err)
+		assert.NotEmpty(t, first.NextCursor)
+		assert.Empty(t, first.PrevCursor)
+
+		second, err := u.ListPayment(ctx, filter, "-amount", 10, 0, first.NextCursor, "")
+		assert.NoError(t, err)
+		assert.NotEmpty(t, second.PrevCursor)
+
+		prev, err := cursorSigner.Decode(second.PrevCursor)
+		assert.NoError(t, err)
+		assert.True(t, prev.Before)
+		assert.Equal(t, []string{"90", "2"}, prev.Key)
+
+		_, err = u.ListPayment(ctx, entity.PaymentFilter{Status: "failed"}, "-amount", 10, 0, first.NextCursor, "")
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeValidation, appErr.Code)
+
+		_, err = u.ListPayment(ctx, filter, "amount", 10, 0, first.NextCursor, "")
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeValidation, appErr.Code)
+	})
+
+	t.Run("cursor with offset", func(t *testing.T) {
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		cursor := cursorSigner.Encode(pagination.Cursor{Key: []string{"1"}})
+		_, err := u.ListPayment(ctx, entity.PaymentFilter{}, "id", 10, 5, cursor, "")
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeValidation, appErr.Code)
+	})
+
+	t.Run("tampered cursor", func(t *testing.T) {
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		cursor := pagination.NewSigner([]byte("other")).Encode(pagination.Cursor{Key: []string{"1"}})
+		_, err := u.ListPayment(ctx, entity.PaymentFilter{}, "id", 10, 0, cursor, "")
+		assert.Error(t, err)
+	})
+
+	t.Run("search without words", func(t *testing.T) {
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		_, err := u.ListPayment(ctx, entity.PaymentFilter{Search: "*-"}, "relevance", 10, 0, "", "")
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeValidation, appErr.Code)
+	})
+
+	t.Run("invalid summary scope", func(t *testing.T) {
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		_, err := u.ListPayment(ctx, entity.PaymentFilter{}, "created_at", 10, 1, "", "merchant")
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeValidation, appErr.Code)
+	})
+
+	t.Run("without payment read", func(t *testing.T) {
+		otherCtx := context.WithValue(context.Background(), config.ContextUserID, "2")
+		mockAuthorizer.EXPECT().Authorize(otherCtx, entity.PermissionPaymentRead).
+			Return(nil, entity.ErrorForbidden("missing permission payment:read"))
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		_, err := u.ListPayment(otherCtx, entity.PaymentFilter{}, "created_at", 10, 1, "", "")
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeForbidden, appErr.Code)
+	})
+}
+
+func TestPayment_ExportPayments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPaymentRepo := pm.NewMockPaymentRepository(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+	ctx := context.WithValue(context.Background(), config.ContextUserID, "1")
+	mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentRead).Return(&entity.User{ID: "1", Role: "cs"}, nil).AnyTimes()
+	mockMerchantRepo := mm.NewMockMerchantRepository(ctrl)
+
+	t.Run("success", func(t *testing.T) {
+		filter := entity.PaymentFilter{Status: entity.PaymentStatusCompleted}
+		mockPaymentRepo.EXPECT().
+			StreamPayments(filter, "-amount", gomock.Any()).
+			DoAndReturn(func(_ entity.PaymentFilter, _ string, fn func(p *entity.Payment) error) error {
+				return fn(&entity.Payment{ID: "1"})
+			})
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		ids := []string{}
+		err := u.ExportPayments(ctx, filter, "-amount", func(p *entity.Payment) error {
+			ids = append(ids, p.ID)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1"}, ids)
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		amountMin := int64(-1)
+		err := u.ExportPayments(ctx, entity.PaymentFilter{Currency: "IDR", AmountMin: &amountMin}, "", func(p *entity.Payment) error { return nil })
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeValidation, appErr.Code)
+	})
+}
+
+func TestPayment_PaymentAnalytics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPaymentRepo := pm.NewMockPaymentRepository(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+	ctx := context.WithValue(context.Background(), config.ContextUserID, "1")
+	mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentRead).Return(&entity.User{ID: "1", Role: "cs"}, nil).AnyTimes()
+	mockMerchantRepo := mm.NewMockMerchantRepository(ctrl)
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	assert.NoError(t, err)
+
+	t.Run("buckets aligned to timezone", func(t *testing.T) {
+		from := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		to := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+		bounds := []time.Time{
+			time.Date(2025, 1, 1, 0, 0, 0, 0, jakarta),
+			time.Date(2025, 1, 2, 0, 0, 0, 0, jakarta),
+			time.Date(2025, 1, 3, 0, 0, 0, 0, jakarta),
+			time.Date(2025, 1, 4, 0, 0, 0, 0, jakarta),
+		}
+		buckets := []*entity.PaymentBucket{{Start: bounds[0], End: bounds[1]}, {Start: bounds[1], End: bounds[2]}, {Start: bounds[2], End: bounds[3]}}
+		mockPaymentRepo.EXPECT().
+			GetPaymentSeries(entity.PaymentFilter{MerchantID: "2"}, bounds).
+			Return(buckets, nil)
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		res, err := u.PaymentAnalytics(ctx, entity.PaymentAnalyticsQuery{From: &from, To: &to, Timezone: "Asia/Jakarta", MerchantID: "2"})
+		assert.NoError(t, err)
+		assert.Equal(t, entity.AnalyticsIntervalDay, res.Interval)
+		assert.Equal(t, "Asia/Jakarta", res.Timezone)
+		assert.Equal(t, buckets, res.Buckets)
+	})
+
+	t.Run("defaults to the last 30 days in UTC", func(t *testing.T) {
+		mockPaymentRepo.EXPECT().
+			GetPaymentSeries(entity.PaymentFilter{}, gomock.Any()).
+			DoAndReturn(func(_ entity.PaymentFilter, bounds []time.Time) ([]*entity.PaymentBucket, error) {
+				assert.Len(t, bounds, 32)
+				assert.Equal(t, time.UTC, bounds[0].Location())
+				return []*entity.PaymentBucket{}, nil
+			})
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		res, err := u.PaymentAnalytics(ctx, entity.PaymentAnalyticsQuery{})
+		assert.NoError(t, err)
+		assert.Equal(t, "UTC", res.Timezone)
+	})
+
+	invalid := []struct {
+		name  string
+		query entity.PaymentAnalyticsQuery
+	}{
+		{"unknown interval", entity.PaymentAnalyticsQuery{Interval: "minute"}},
+		{"unknown timezone", entity.PaymentAnalyticsQuery{Timezone: "Mars/Base"}},
+		{"inverted range", entity.PaymentAnalyticsQuery{From: timePtr(time.Now()), To: timePtr(time.Now().Add(-time.Hour))}},
+		{"too many buckets", entity.PaymentAnalyticsQuery{Interval: entity.AnalyticsIntervalHour, From: timePtr(time.Now().AddDate(-1, 0, 0))}},
+	}
+	for _, c := range invalid {
+		t.Run(c.name, func(t *testing.T) {
+			u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+			_, err := u.PaymentAnalytics(ctx, c.query)
+			var appErr *entity.AppError
+			assert.ErrorAs(t, err, &appErr)
+			assert.Equal(t, entity.ErrorCodeValidation, appErr.Code)
+		})
+	}
+}
+
+func TestPayment_MerchantReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPaymentRepo := pm.NewMockPaymentRepository(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+	ctx := context.WithValue(context.Background(), config.ContextUserID, "1")
+	mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentRead).Return(&entity.User{ID: "1", Role: "cs"}, nil).AnyTimes()
+	mockMerchantRepo := mm.NewMockMerchantRepository(ctrl)
+
+	t.Run("success", func(t *testing.T) {
+		expected := []*entity.MerchantReport{{MerchantID: "1", Currency: "USD", Count: 2}}
+		mockPaymentRepo.EXPECT().
+			GetMerchantReport(entity.MerchantReportFilter{Currency: "USD"}, "-volume", 10, 0).
+			Return(expected, 1, nil)
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		res, total, err := u.MerchantReport(ctx, entity.MerchantReportFilter{Currency: "usd"}, "-volume", 10, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, expected, res)
+	})
+
+	t.Run("unsupported currency", func(t *testing.T) {
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		_, _, err := u.MerchantReport(ctx, entity.MerchantReportFilter{Currency: "XXX"}, "", 10, 0)
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeValidation, appErr.Code)
+	})
+
+	t.Run("inverted range", func(t *testing.T) {
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		_, _, err := u.MerchantReport(ctx, entity.MerchantReportFilter{CreatedFrom: timePtr(time.Now()), CreatedTo: timePtr(time.Now().Add(-time.Hour))}, "", 10, 0)
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeValidation, appErr.Code)
+	})
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
 }
 
 func TestPayment_ReviewPayment(t *testing.T) {
@@ -72,67 +374,505 @@ func TestPayment_ReviewPayment(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockPaymentRepo := pm.NewMockPaymentRepository(ctrl)
-	mockUserRepo := am.NewMockUserRepository(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+	mockMerchantRepo := mm.NewMockMerchantRepository(ctrl)
+	ctx := context.WithValue(context.Background(), config.ContextUserID, "1")
 
-	t.Run("GetUserById middleware return empty", func(t *testing.T) {
-		u := NewPaymentUsecase(mockPaymentRepo, mockUserRepo)
+	t.Run("user not found", func(t *testing.T) {
+		mockAuthorizer.EXPECT().
+			Authorize(ctx, entity.PermissionPaymentReview).
+			Return(nil, entity.ErrorNotFound("user not found"))
 
-		message, err := u.ReviewPayment(context.Background(), "1")
-		assert.Equal(t, "", message)
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		review, err := u.ReviewPayment(ctx, "1", "", "")
+		assert.Nil(t, review)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "user not found")
 	})
-	t.Run("GetUserById Repo Error", func(t *testing.T) {
-		mockUserRepo.EXPECT().
-			GetUserById("1").
-			Return(nil, errors.New("user not found"))
 
-		u := NewPaymentUsecase(mockPaymentRepo, mockUserRepo)
+	t.Run("missing permission", func(t *testing.T) {
+		mockAuthorizer.EXPECT().
+			Authorize(ctx, entity.PermissionPaymentReview).
+			Return(nil, entity.ErrorForbidden("missing permission payment:review"))
 
-		ctx := context.WithValue(context.Background(), config.ContextUserID, "1")
-		message, err := u.ReviewPayment(ctx, "1")
-		assert.Equal(t, "", message)
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "user not found")
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		review, err := u.ReviewPayment(ctx, "1", "", "")
+		assert.Nil(t, review)
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeForbidden, appErr.Code)
 	})
 
-	t.Run("GetUserById not operation role", func(t *testing.T) {
-		mockUserRepo.EXPECT().
-			GetUserById("1").
+	t.Run("success", func(t *testing.T) {
+		mockAuthorizer.EXPECT().
+			Authorize(ctx, entity.PermissionPaymentReview).
 			Return(&entity.User{
 				ID:           "u1",
 				Email:        "alice@example.com",
 				PasswordHash: "123456",
-				Role:         "cs",
+				Role:         "operation",
 			}, nil)
+		mockPaymentRepo.EXPECT().
+			Review("123", "u1", entity.ReviewOutcomeApproved, "").
+			Return(&entity.PaymentReview{ID: "9", PaymentID: "123", ReviewerID: "u1", Outcome: entity.ReviewOutcomeApproved}, nil)
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		review, err := u.ReviewPayment(ctx, "123", "", "")
+		assert.NoError(t, err)
+		assert.Equal(t, "9", review.ID)
+		assert.Equal(t, "alice@example.com", review.ReviewerEmail)
+	})
+
+	t.Run("invalid outcome", func(t *testing.T) {
+		mockAuthorizer.EXPECT().
+			Authorize(ctx, entity.PermissionPaymentReview).
+			Return(&entity.User{ID: "u1", Role: "operation"}, nil)
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		review, err := u.ReviewPayment(ctx, "123", "bogus", "")
+		assert.Nil(t, review)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid review outcome")
+	})
+}
+
+func TestPayment_UpdatePaymentStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPaymentRepo := pm.NewMockPaymentRepository(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+	mockMerchantRepo := mm.NewMockMerchantRepository(ctrl)
+	operation := &entity.User{ID: "u1", Email: "alice@example.com", Role: "operation"}
+	ctx := context.WithValue(context.Background(), config.ContextUserID, "1")
+
+	t.Run("missing permission", func(t *testing.T) {
+		mockAuthorizer.EXPECT().
+			Authorize(ctx, entity.PermissionPaymentUpdateStatus).
+			Return(nil, entity.ErrorForbidden("missing permission payment:update_status"))
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		payment, err := u.UpdatePaymentStatus(ctx, "p1", entity.PaymentStatusProcessing, "")
+		assert.Nil(t, payment)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "missing permission payment:update_status")
+	})
+
+	t.Run("illegal transition", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentUpdateStatus).Return(operation, nil)
+		mockPaymentRepo.EXPECT().
+			GetPaymentByID("p1").
+			Return(&entity.Payment{ID: "p1", Status: entity.PaymentStatusFailed}, nil)
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		payment, err := u.UpdatePaymentStatus(ctx, "p1", entity.PaymentStatusCompleted, "")
+		assert.Nil(t, payment)
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeConflict, appErr.Code)
+		assert.Contains(t, err.Error(), "from failed to completed")
+	})
+
+	t.Run("refund status is rejected", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentUpdateStatus).Return(operation, nil)
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		_, err := u.UpdatePaymentStatus(ctx, "p1", entity.PaymentStatusRefunded, "")
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeConflict, appErr.Code)
+		assert.Contains(t, err.Error(), "set by creating refunds")
+	})
+
+	t.Run("payment not found", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentUpdateStatus).Return(operation, nil)
+		mockPaymentRepo.EXPECT().
+			GetPaymentByID("missing").
+			Return(nil, entity.ErrorNotFound("payment not found"))
 
-		u := NewPaymentUsecase(mockPaymentRepo, mockUserRepo)
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
 
-		ctx := context.WithValue(context.Background(), config.ContextUserID, "1")
-		message, err := u.ReviewPayment(ctx, "1")
-		assert.Equal(t, "", message)
+		_, err := u.UpdatePaymentStatus(ctx, "missing", entity.PaymentStatusProcessing, "")
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "user forbidden")
+		assert.Contains(t, err.Error(), "payment not found")
 	})
 
 	t.Run("success", func(t *testing.T) {
-		mockUserRepo.EXPECT().
-			GetUserById("1").
-			Return(&entity.User{
-				ID:           "u1",
-				Email:        "alice@example.com",
-				PasswordHash: "123456",
-				Role:         "operation",
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentUpdateStatus).Return(operation, nil)
+		mockPaymentRepo.EXPECT().
+			GetPaymentByID("p1").
+			Return(&entity.Payment{ID: "p1", Status: entity.PaymentStatusPending}, nil)
+		mockPaymentRepo.EXPECT().
+			UpdateStatus("p1", entity.PaymentStatusPending, entity.PaymentStatusProcessing, "u1", "picked up").
+			Return(&entity.PaymentStatusChange{ID: "1"}, nil)
+		mockPaymentRepo.EXPECT().
+			GetPaymentByID("p1").
+			Return(&entity.Payment{ID: "p1", Status: entity.PaymentStatusProcessing}, nil)
+		mockPaymentRepo.EXPECT().
+			ListRefunds("p1").
+			Return([]*entity.Refund{}, nil)
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		payment, err := u.UpdatePaymentStatus(ctx, "p1", entity.PaymentStatusProcessing, "picked up")
+		assert.NoError(t, err)
+		assert.Equal(t, entity.PaymentStatusProcessing, payment.Status)
+	})
+}
+
+func TestPayment_CreatePayment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPaymentRepo := pm.NewMockPaymentRepository(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+	mockMerchantRepo := mm.NewMockMerchantRepository(ctrl)
+	operation := &entity.User{ID: "u1", Email: "alice@example.com", Role: "operation"}
+	ctx := context.WithValue(context.Background(), config.ContextUserID, "1")
+	merchant := &entity.Merchant{ID: "1", DisplayName: "merchant 1", Status: entity.MerchantStatusActive}
+	input := entity.CreatePaymentInput{MerchantID: "1", Amount: "150.50", Currency: "USD"}
+	normalized, err := newPayment(input)
+	assert.NoError(t, err)
+	requestHash, err := hashNewPayment(normalized)
+	assert.NoError(t, err)
+	created := &entity.Payment{ID: "13", MerchantID: "1", Merchant: "merchant 1", Amount: 15050, Currency: "USD", Status: entity.PaymentStatusPending}
+
+	t.Run("invalid amount", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentCreate).Return(operation, nil)
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		_, _, err := u.CreatePayment(ctx, entity.CreatePaymentInput{MerchantID: "1", Amount: "150.5", Currency: "IDR"}, "")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "amount must be a positive IDR amount")
+	})
+
+	t.Run("unsupported currency", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentCreate).Return(operation, nil)
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		_, _, err := u.CreatePayment(ctx, entity.CreatePaymentInput{MerchantID: "1", Amount: "1", Currency: "XYZ"}, "")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported currency XYZ")
+	})
+
+	t.Run("merchant not found", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentCreate).Return(operation, nil)
+		mockMerchantRepo.EXPECT().GetMerchantByID("1").Return(nil, entity.ErrorNotFound("merchant not found"))
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		_, _, err := u.CreatePayment(ctx, input, "")
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeValidation, appErr.Code)
+	})
+
+	t.Run("inactive merchant", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentCreate).Return(operation, nil)
+		mockMerchantRepo.EXPECT().
+			GetMerchantByID("1").
+			Return(&entity.Merchant{ID: "1", DisplayName: "merchant 1", Status: entity.MerchantStatusInactive}, nil)
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		_, _, err := u.CreatePayment(ctx, input, "")
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeConflict, appErr.Code)
+	})
+
+	t.Run("without idempotency key", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentCreate).Return(operation, nil)
+		mockMerchantRepo.EXPECT().GetMerchantByID("1").Return(merchant, nil)
+		mockPaymentRepo.EXPECT().
+			Create(&entity.Payment{MerchantID: "1", Merchant: "merchant 1", Amount: 15050, Currency: "USD", Status: entity.PaymentStatusPending}, nil).
+			Return(created, nil)
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		payment, replayed, err := u.CreatePayment(ctx, input, "")
+		assert.NoError(t, err)
+		assert.False(t, replayed)
+		assert.Equal(t, "13", payment.ID)
+	})
+
+	t.Run("first use of idempotency key", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentCreate).Return(operation, nil)
+		mockMerchantRepo.EXPECT().GetMerchantByID("1").Return(merchant, nil)
+		mockPaymentRepo.EXPECT().
+			GetIdempotencyKey("u1", "key-1").
+			Return(nil, entity.ErrorNotFound("idempotency key not found"))
+		mockPaymentRepo.EXPECT().
+			Create(gomock.Any(), &entity.IdempotencyKey{Key: "key-1", UserID: "u1", RequestHash: requestHash}).
+			Return(created, nil)
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		payment, replayed, err := u.CreatePayment(ctx, input, "key-1")
+		assert.NoError(t, err)
+		assert.False(t, replayed)
+		assert.Equal(t, "13", payment.ID)
+	})
+
+	t.Run("replay with same body", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentCreate).Return(operation, nil)
+		mockPaymentRepo.EXPECT().
+			GetIdempotencyKey("u1", "key-1").
+			Return(&entity.IdempotencyKey{Key: "key-1", UserID: "u1", RequestHash: requestHash, Response: []byte(`{"id":"13","status":"pending"}`)}, nil)
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		payment, replayed, err := u.CreatePayment(ctx, input, "key-1")
+		assert.NoError(t, err)
+		assert.True(t, replayed)
+		assert.Equal(t, "13", payment.ID)
+		assert.Equal(t, entity.PaymentStatusPending, payment.Status)
+	})
+
+	t.Run("replay with equivalent body", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentCreate).Return(operation, nil)
+		mockPaymentRepo.EXPECT().
+			GetIdempotencyKey("u1", "key-1").
+			Return(&entity.IdempotencyKey{Key: "key-1", UserID: "u1", RequestHash: requestHash, Response: []byte(`{"id":"13","status":"pending"}`)}, nil)
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		payment, replayed, err := u.CreatePayment(ctx, entity.CreatePaymentInput{MerchantID: " 1 ", Amount: "150.5", Currency: "usd"}, "key-1")
+		assert.NoError(t, err)
+		assert.True(t, replayed)
+		assert.Equal(t, "13", payment.ID)
+	})
+
+	t.Run("reuse with different body", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentCreate).Return(operation, nil)
+		mockPaymentRepo.EXPECT().
+			GetIdempotencyKey("u1", "key-1").
+			Return(&entity.IdempotencyKey{Key: "key-1", UserID: "u1", RequestHash: "other"}, nil)
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		_, _, err := u.CreatePayment(ctx, input, "key-1")
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeConflict, appErr.Code)
+	})
+
+	t.Run("concurrent request stored the key first", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentCreate).Return(operation, nil)
+		mockMerchantRepo.EXPECT().GetMerchantByID("1").Return(merchant, nil)
+		gomock.InOrder(
+			mockPaymentRepo.EXPECT().
+				GetIdempotencyKey("u1", "key-2").
+				Return(nil, entity.ErrorNotFound("idempotency key not found")),
+			mockPaymentRepo.EXPECT().
+				Create(gomock.Any(), gomock.Any()).
+				Return(nil, entity.ErrorConflict("idempotency key already used")),
+			mockPaymentRepo.EXPECT().
+				GetIdempotencyKey("u1", "key-2").
+				Return(&entity.IdempotencyKey{Key: "key-2", UserID: "u1", RequestHash: requestHash, Response: []byte(`{"id":"14"}`)}, nil),
+		)
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		payment, replayed, err := u.CreatePayment(ctx, input, "key-2")
+		assert.NoError(t, err)
+		assert.True(t, replayed)
+		assert.Equal(t, "14", payment.ID)
+	})
+}
+
+func TestPayment_RefundPayment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPaymentRepo := pm.NewMockPaymentRepository(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+	mockMerchantRepo := mm.NewMockMerchantRepository(ctrl)
+	operation := &entity.User{ID: "u1", Email: "alice@example.com", Role: "operation"}
+	ctx := context.WithValue(context.Background(), config.ContextUserID, "1")
+
+	t.Run("payment not refundable", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentRefund).Return(operation, nil)
+		mockPaymentRepo.EXPECT().
+			GetPaymentByID("p1").
+			Return(&entity.Payment{ID: "p1", Amount: 10000, Currency: "USD", Status: entity.PaymentStatusPending}, nil)
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		_, _, err := u.RefundPayment(ctx, "p1", "10", "")
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeConflict, appErr.Code)
+	})
+
+	t.Run("exceeds remaining amount", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentRefund).Return(operation, nil)
+		mockPaymentRepo.EXPECT().
+			GetPaymentByID("p1").
+			Return(&entity.Payment{ID: "p1", Amount: 10000, Currency: "USD", RefundedAmount: 8000, Status: entity.PaymentStatusPartiallyRefunded}, nil)
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		_, _, err := u.RefundPayment(ctx, "p1", "30", "")
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeValidation, appErr.Code)
+		assert.Equal(t, map[string]any{"remaining": "20.00"}, appErr.Details)
+	})
+
+	t.Run("partial refund", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentRefund).Return(operation, nil)
+		mockPaymentRepo.EXPECT().
+			GetPaymentByID("p1").
+			Return(&entity.Payment{ID: "p1", Amount: 10000, Currency: "USD", Status: entity.PaymentStatusCompleted}, nil)
+		mockPaymentRepo.EXPECT().
+			CreateRefund(&entity.Refund{PaymentID: "p1", Amount: 4000, Reason: "damaged", CreatedBy: "u1"},
+				entity.PaymentStatusCompleted, entity.PaymentStatusPartiallyRefunded).
+			Return(&entity.Refund{ID: "1", PaymentID: "p1", Amount: 4000}, nil)
+		mockPaymentRepo.EXPECT().
+			GetPaymentByID("p1").
+			Return(&entity.Payment{ID: "p1", Amount: 10000, Currency: "USD", RefundedAmount: 4000, Status: entity.PaymentStatusPartiallyRefunded}, nil)
+		mockPaymentRepo.EXPECT().
+			ListRefunds("p1").
+			Return([]*entity.Refund{{ID: "1", PaymentID: "p1", Amount: 4000}}, nil)
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		refund, payment, err := u.RefundPayment(ctx, "p1", "40", "damaged")
+		assert.NoError(t, err)
+		assert.Equal(t, "1", refund.ID)
+		assert.Equal(t, entity.PaymentStatusPartiallyRefunded, payment.Status)
+		assert.Len(t, payment.Refunds, 1)
+	})
+
+	t.Run("remaining amount refunds fully", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentRefund).Return(operation, nil)
+		mockPaymentRepo.EXPECT().
+			GetPaymentByID("p1").
+			Return(&entity.Payment{ID: "p1", Amount: 10000, Currency: "USD", RefundedAmount: 4000, Status: entity.PaymentStatusPartiallyRefunded}, nil)
+		mockPaymentRepo.EXPECT().
+			CreateRefund(gomock.Any(), entity.PaymentStatusPartiallyRefunded, entity.PaymentStatusRefunded).
+			Return(&entity.Refund{ID: "2", PaymentID: "p1", Amount: 6000}, nil)
+		mockPaymentRepo.EXPECT().
+			GetPaymentByID("p1").
+			Return(&entity.Payment{ID: "p1", Amount: 10000, Currency: "USD", RefundedAmount: 10000, Status: entity.PaymentStatusRefunded}, nil)
+		mockPaymentRepo.EXPECT().
+			ListRefunds("p1").
+			Return([]*entity.Refund{{ID: "1"}, {ID: "2"}}, nil)
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		_, payment, err := u.RefundPayment(ctx, "p1", "60", "")
+		assert.NoError(t, err)
+		assert.Equal(t, entity.PaymentStatusRefunded, payment.Status)
+	})
+}
+
+func TestPayment_GetPayment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPaymentRepo := pm.NewMockPaymentRepository(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+	ctx := context.WithValue(context.Background(), config.ContextUserID, "1")
+	mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentRead).Return(&entity.User{ID: "1", Role: "cs"}, nil).AnyTimes()
+	mockMerchantRepo := mm.NewMockMerchantRepository(ctrl)
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("timeline ordered by time", func(t *testing.T) {
+		mockPaymentRepo.EXPECT().
+			GetPaymentByID("p1").
+			Return(&entity.Payment{ID: "p1", Amount: 10000, Currency: "USD", Status: entity.PaymentStatusPartiallyRefunded, CreatedAt: createdAt}, nil)
+		mockPaymentRepo.EXPECT().
+			ListRefunds("p1").
+			Return([]*entity.Refund{{ID: "1", PaymentID: "p1", Amount: 2500, CreatedBy: "u1", CreatedAt: createdAt.Add(3 * time.Hour)}}, nil)
+		mockPaymentRepo.EXPECT().
+			ListStatusHistory("p1").
+			Return([]*entity.PaymentStatusChange{
+				{ID: "1", FromStatus: entity.PaymentStatusPending, ToStatus: entity.PaymentStatusProcessing, ChangedBy: "u1", ChangedAt: createdAt.Add(time.Hour)},
+				{ID: "2", FromStatus: entity.PaymentStatusProcessing, ToStatus: entity.PaymentStatusCompleted, ChangedBy: "u1", ChangedAt: createdAt.Add(2 * time.Hour)},
+				{ID: "3", FromStatus: entity.PaymentStatusCompleted, ToStatus: entity.PaymentStatusPartiallyRefunded, ChangedBy: "u1", ChangedAt: createdAt.Add(3 * time.Hour)},
 			}, nil)
 		mockPaymentRepo.EXPECT().
-			Review("123").
-			Return("Success Review", nil)
+			ListReviews("p1").
+			Return([]*entity.PaymentReview{{ID: "1", ReviewerID: "u2", Outcome: entity.ReviewOutcomeFlagged, ReviewedAt: createdAt.Add(90 * time.Minute)}}, nil)
+		mockPaymentRepo.EXPECT().
+			ListNotes("p1").
+			Return([]*entity.PaymentNote{{ID: "1", AuthorID: "u3", Body: "called merchant", CreatedAt: createdAt.Add(30 * time.Minute)}}, nil)
 
-		u := NewPaymentUsecase(mockPaymentRepo, mockUserRepo)
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
 
-		ctx := context.WithValue(context.Background(), config.ContextUserID, "1")
-		message, err := u.ReviewPayment(ctx, "123")
+		payment, events, err := u.GetPayment(ctx, "p1")
 		assert.NoError(t, err)
-		assert.Equal(t, "Success Review", message)
+		assert.Len(t, payment.Refunds, 1)
+		types := make([]entity.PaymentEventType, len(events))
+		for i, e := range events {
+			types[i] = e.Type
+		}
+		assert.Equal(t, []entity.PaymentEventType{
+			entity.PaymentEventCreated,
+			entity.PaymentEventNoteAdded,
+			entity.PaymentEventStatusChanged,
+			entity.PaymentEventReviewed,
+			entity.PaymentEventStatusChanged,
+			entity.PaymentEventStatusChanged,
+			entity.PaymentEventRefunded,
+		}, types)
+		assert.Equal(t, int64(2500), events[6].Amount)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockPaymentRepo.EXPECT().GetPaymentByID("99").Return(nil, entity.ErrorNotFound("payment not found"))
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		_, _, err := u.GetPayment(ctx, "99")
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeNotFound, appErr.Code)
+	})
+}
+
+func TestPayment_AddPaymentNote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPaymentRepo := pm.NewMockPaymentRepository(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+	mockMerchantRepo := mm.NewMockMerchantRepository(ctrl)
+	cs := &entity.User{ID: "u3", Email: "cs@example.com", Role: "cs"}
+	ctx := context.WithValue(context.Background(), config.ContextUserID, "3")
+
+	t.Run("success", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentNote).Return(cs, nil)
+		mockPaymentRepo.EXPECT().
+			AddNote(&entity.PaymentNote{PaymentID: "p1", AuthorID: "u3", Body: "called merchant"}).
+			Return(&entity.PaymentNote{ID: "1", PaymentID: "p1", AuthorID: "u3", Body: "called merchant"}, nil)
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		note, err := u.AddPaymentNote(ctx, "p1", " called merchant ")
+		assert.NoError(t, err)
+		assert.Equal(t, "cs@example.com", note.AuthorEmail)
+	})
+
+	t.Run("empty note", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionPaymentNote).Return(cs, nil)
+
+		u := NewPaymentUsecase(mockPaymentRepo, mockAuthorizer, mockMerchantRepo, cursorSigner)
+
+		_, err := u.AddPaymentNote(ctx, "p1", "  ")
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeValidation, appErr.Code)
 	})
 }
@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/fajrinajiseno/mygolangapp/internal/module/user/usecase"
+	"github.com/fajrinajiseno/mygolangapp/internal/openapigen"
+	"github.com/fajrinajiseno/mygolangapp/internal/transport"
+)
+
+type UserHandler struct {
+	userUC usecase.UserUsecase
+}
+
+func NewUserHandler(userUC usecase.UserUsecase) *UserHandler {
+	return &UserHandler{
+		userUC: userUC,
+	}
+}
+
+func (a *UserHandler) GetDashboardV1Users(w http.ResponseWriter, r *http.Request, params openapigen.GetDashboardV1UsersParams) {
+	limit := 10
+	offset := 0
+	filter := entity.UserFilter{}
+
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+
+	if params.Role != nil {
+		filter.Role = *params.Role
+	}
+
+	if params.Status != nil {
+		filter.Status = entity.UserStatus(*params.Status)
+	}
+
+	users, total, err := a.userUC.ListUsers(r.Context(), filter, limit, offset)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	genUsers := make([]openapigen.UserAccount, len(users))
+	for i, item := range users {
+		genUsers[i] = toGenUser(item)
+	}
+	err = json.NewEncoder(w).Encode(openapigen.UserListResponse{Meta: &openapigen.PaginationMeta{
+		Limit:  params.Limit,
+		Offset: params.Offset,
+		Total:  &total,
+	}, Users: &genUsers})
+	if err != nil {
+		transport.WriteAppError(w, entity.ErrorInternal("internal server error"))
+		return
+	}
+}
+
+func (a *UserHandler) PostDashboardV1Users(w http.ResponseWriter, r *http.Request) {
+	var req openapigen.PostDashboardV1UsersJSONRequestBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	user, err := a.userUC.CreateUser(r.Context(), entity.UserInput{Email: req.Email, Password: req.Password, Role: req.Role})
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	writeUser(w, user)
+}
+
+func (a *UserHandler) PutDashboardV1UserIdRole(w http.ResponseWriter, r *http.Request, id string) {
+	var req openapigen.PutDashboardV1UserIdRoleJSONRequestBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	user, err := a.userUC.UpdateUserRole(r.Context(), id, req.Role)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	writeUser(w, user)
+}
+
+func (a *UserHandler) PutDashboardV1UserIdStatus(w http.ResponseWriter, r *http.Request, id string) {
+	var req openapigen.PutDashboardV1UserIdStatusJSONRequestBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	user, err := a.userUC.UpdateUserStatus(r.Context(), id, entity.UserStatus(req.Status))
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	writeUser(w, user)
+}
+
+func (a *UserHandler) DeleteDashboardV1UserId(w http.ResponseWriter, r *http.Request, id string) {
+	if err := a.userUC.DeleteUser(r.Context(), id); err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *UserHandler) GetDashboardV1Lockouts(w http.ResponseWriter, r *http.Request) {
+	lockouts, err := a.userUC.ListLockouts(r.Context())
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	genLockouts := make([]openapigen.LoginLockout, len(lockouts))
+	for i, item := range lockouts {
+		scope := openapigen.LockoutScope(item.Scope)
+		genLockouts[i] = openapigen.LoginLockout{
+			Scope:         &scope,
+			Key:           &item.Key,
+			Failures:      &item.Failures,
+			LastFailureAt: &item.LastFailureAt,
+			LockedUntil:   item.LockedUntil,
+		}
+	}
+	err = json.NewEncoder(w).Encode(openapigen.LockoutListResponse{Lockouts: &genLockouts})
+	if err != nil {
+		transport.WriteAppError(w, entity.ErrorInternal("internal server error"))
+		return
+	}
+}
+
+func (a *UserHandler) DeleteDashboardV1LockoutsScopeKey(w http.ResponseWriter, r *http.Request, scope openapigen.LockoutScope, key string) {
+	if err := a.userUC.ClearLockout(r.Context(), entity.LockoutScope(scope), key); err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeUser(w http.ResponseWriter, user *entity.User) {
+	genUser := toGenUser(user)
+	err := json.NewEncoder(w).Encode(openapigen.UserAccountResponse{User: &genUser})
+	if err != nil {
+		transport.WriteAppError(w, entity.ErrorInternal("internal server error"))
+		return
+	}
+}
+
+func toGenUser(item *entity.User) openapigen.UserAccount {
+	status := openapigen.UserStatus(item.Status)
+	return openapigen.UserAccount{
+		Id:        &item.ID,
+		Email:     &item.Email,
+		Role:      &item.Role,
+		Status:    &status,
+		CreatedAt: &item.CreatedAt,
+	}
+}
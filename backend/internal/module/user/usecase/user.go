@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/authz"
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	authRepository "github.com/fajrinajiseno/mygolangapp/internal/module/auth/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+//go:generate mockgen -source user.go -destination mock/user_mock.go -package=mock
+type UserUsecase interface {
+	ListUsers(ctx context.Context, filter entity.UserFilter, limit int, offset int) ([]*entity.User, int, error)
+	CreateUser(ctx context.Context, input entity.UserInput) (*entity.User, error)
+	UpdateUserRole(ctx context.Context, id string, role string) (*entity.User, error)
+	UpdateUserStatus(ctx context.Context, id string, status entity.UserStatus) (*entity.User, error)
+	DeleteUser(ctx context.Context, id string) error
+	ListLockouts(ctx context.Context) ([]*entity.LoginLockout, error)
+	ClearLockout(ctx context.Context, scope entity.LockoutScope, key string) error
+}
+
+type User struct {
+	authorizer  authz.Authorizer
+	revocations authz.RevocationList
+	userRepo    authRepository.UserRepository
+	lockoutRepo authRepository.LockoutRepository
+}
+
+func NewUserUsecase(ur authRepository.UserRepository, az authz.Authorizer, revocations authz.RevocationList,
+	lr authRepository.LockoutRepository) *User {
+	return &User{userRepo: ur, authorizer: az, revocations: revocations, lockoutRepo: lr}
+}
+
+func (u *User) ListUsers(ctx context.Context, filter entity.UserFilter, limit int, offset int) ([]*entity.User, int, error) {
+	if _, err := u.authorizer.Authorize(ctx, entity.PermissionUserManage); err != nil {
+		return nil, 0, err
+	}
+	if filter.Status != "" && !filter.Status.Valid() {
+		return nil, 0, entity.ErrorValidation("invalid user status")
+	}
+	return u.userRepo.GetUsers(filter, limit, offset)
+}
+
+func (u *User) CreateUser(ctx context.Context, input entity.UserInput) (*entity.User, error) {
+	if _, err := u.authorizer.Authorize(ctx, entity.PermissionUserManage); err != nil {
+		return nil, err
+	}
+	email, err := mail.ParseAddress(strings.TrimSpace(input.Email))
+	if err != nil || email.Name != "" {
+		return nil, entity.ErrorValidation("invalid email")
+	}
+	if err := entity.ValidatePassword(input.Password); err != nil {
+		return nil, err
+	}
+	if err := u.checkRole(input.Role); err != nil {
+		return nil, err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "internal error")
+	}
+	return u.userRepo.CreateUser(&entity.User{
+		// stored lower case like the login lockouts count it
+		Email:        strings.ToLower(email.Address),
+		PasswordHash: string(hash),
+		Role:         input.Role,
+		Status:       entity.UserStatusActive,
+	})
+}
+
+// UpdateUserRole moves a user to another role, the last admin cannot leave the admin roles.
+func (u *User) UpdateUserRole(ctx context.Context, id string, role string) (*entity.User, error) {
+	if _, err := u.authorizer.Authorize(ctx, entity.PermissionUserManage); err != nil {
+		return nil, err
+	}
+	if err := u.checkRole(role); err != nil {
+		return nil, err
+	}
+	return u.userRepo.UpdateUserRole(id, role)
+}
+
+// UpdateUserStatus enables or disables a user, disabling revokes all their tokens. The last
+// admin cannot be disabled.
+func (u *User) UpdateUserStatus(ctx context.Context, id string, status entity.UserStatus) (*entity.User, error) {
+	if _, err := u.authorizer.Authorize(ctx, entity.PermissionUserManage); err != nil {
+		return nil, err
+	}
+	if !status.Valid() {
+		return nil, entity.ErrorValidation("invalid user status")
+	}
+	user, err := u.userRepo.UpdateUserStatus(id, status)
+	if err != nil {
+		return nil, err
+	}
+	if status == entity.UserStatusDisabled {
+		if err := u.revocations.RevokeUser(id); err != nil {
+			return nil, err
+		}
+	}
+	return user, nil
+}
+
+// DeleteUser removes a user without activity, the last admin cannot be deleted.
+func (u *User) DeleteUser(ctx context.Context, id string) error {
+	if _, err := u.authorizer.Authorize(ctx, entity.PermissionUserManage); err != nil {
+		return err
+	}
+	return u.userRepo.DeleteUser(id)
+}
+
+// ListLockouts returns the accounts and IPs currently locked out of login.
+func (u *User) ListLockouts(ctx context.Context) ([]*entity.LoginLockout, error) {
+	if _, err := u.authorizer.Authorize(ctx, entity.PermissionUserManage); err != nil {
+		return nil, err
+	}
+	return u.lockoutRepo.ListLockouts(time.Now())
+}
+
+// ClearLockout forgets the failed logins of an account or IP, unlocking it right away. The
+// key of an account is its email.
+func (u *User) ClearLockout(ctx context.Context, scope entity.LockoutScope, key string) error {
+	if _, err := u.authorizer.Authorize(ctx, entity.PermissionUserManage); err != nil {
+		return err
+	}
+	if !scope.Valid() {
+		return entity.ErrorValidation("invalid lockout scope")
+	}
+	if scope == entity.LockoutScopeAccount {
+		key = strings.ToLower(strings.TrimSpace(key))
+	}
+	return u.lockoutRepo.ClearLockout(scope, key)
+}
+
+// checkRole accepts the roles that are granted at least one permission.
+func (u *User) checkRole(role string) error {
+	if role == "" {
+		return entity.ErrorValidation("role is required")
+	}
+	permissions, err := u.userRepo.GetRolePermissions(role)
+	if err != nil {
+		return err
+	}
+	if len(permissions) == 0 {
+		return entity.ErrorValidation("unknown role " + role)
+	}
+	return nil
+}
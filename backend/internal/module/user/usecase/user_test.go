@@ -0,0 +1,294 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	azm "github.com/fajrinajiseno/mygolangapp/internal/authz/mock"
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	am "github.com/fajrinajiseno/mygolangapp/internal/module/auth/repository/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var admin = &entity.User{ID: "1", Email: "admin@test.com", Role: "admin", Status: entity.UserStatusActive}
+
+func TestUser_ListUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUserRepo := am.NewMockUserRepository(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+
+	t.Run("success", func(t *testing.T) {
+		expected := []*entity.User{{ID: "2", Email: "cs@test.com", Role: "cs", Status: entity.UserStatusActive}}
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionUserManage).Return(admin, nil)
+		mockUserRepo.EXPECT().
+			GetUsers(entity.UserFilter{Role: "cs"}, 10, 0).
+			Return(expected, 1, nil)
+
+		u := NewUserUsecase(mockUserRepo, mockAuthorizer, nil, nil)
+
+		items, total, err := u.ListUsers(ctx, entity.UserFilter{Role: "cs"}, 10, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, items)
+		assert.Equal(t, 1, total)
+	})
+
+	t.Run("missing permission", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionUserManage).Return(nil, entity.ErrorForbidden("missing permission user:manage"))
+
+		u := NewUserUsecase(mockUserRepo, mockAuthorizer, nil, nil)
+
+		_, _, err := u.ListUsers(ctx, entity.UserFilter{}, 10, 0)
+		assert.EqualError(t, err, "missing permission user:manage")
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionUserManage).Return(admin, nil)
+
+		u := NewUserUsecase(mockUserRepo, mockAuthorizer, nil, nil)
+
+		_, _, err := u.ListUsers(ctx, entity.UserFilter{Status: "gone"}, 10, 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestUser_CreateUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUserRepo := am.NewMockUserRepository(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+
+	t.Run("success", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionUserManage).Return(admin, nil)
+		mockUserRepo.EXPECT().GetRolePermissions("cs").Return([]entity.Permission{entity.PermissionPaymentRead}, nil)
+		mockUserRepo.EXPECT().
+			CreateUser(gomock.Any()).
+			DoAndReturn(func(user *entity.User) (*entity.User, error) {
+				assert.Equal(t, "agent@test.com", user.Email)
+				assert.Equal(t, entity.UserStatusActive, user.Status)
+				assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte("password123")))
+				created := *user
+				created.ID = "4"
+				return &created, nil
+			})
+
+		u := NewUserUsecase(mockUserRepo, mockAuthorizer, nil, nil)
+
+		user, err := u.CreateUser(ctx, entity.UserInput{Email: " Agent@Test.com ", Password: "password123", Role: "cs"})
+		assert.NoError(t, err)
+		assert.Equal(t, "4", user.ID)
+	})
+
+	t.Run("unknown role", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionUserManage).Return(admin, nil)
+		mockUserRepo.EXPECT().GetRolePermissions("root").Return([]entity.Permission{}, nil)
+
+		u := NewUserUsecase(mockUserRepo, mockAuthorizer, nil, nil)
+
+		_, err := u.CreateUser(ctx, entity.UserInput{Email: "agent@test.com", Password: "password123", Role: "root"})
+		assert.EqualError(t, err, "unknown role root")
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		u := NewUserUsecase(mockUserRepo, mockAuthorizer, nil, nil)
+
+		for _, input := range []entity.UserInput{
+			{Email: "not an email", Password: "password123", Role: "cs"},
+			{Email: "Agent <agent@test.com>", Password: "password123", Role: "cs"},
+			{Email: "agent@test.com", Password: "short", Role: "cs"},
+			{Email: "agent@test.com", Password: "password123"},
+		} {
+			mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionUserManage).Return(admin, nil)
+			_, err := u.CreateUser(ctx, input)
+			var appErr *entity.AppError
+			assert.ErrorAs(t, err, &appErr)
+			assert.Equal(t, entity.ErrorCodeValidation, appErr.Code)
+		}
+	})
+
+	t.Run("duplicate email", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionUserManage).Return(admin, nil)
+		mockUserRepo.EXPECT().GetRolePermissions("cs").Return([]entity.Permission{entity.PermissionPaymentRead}, nil)
+		mockUserRepo.EXPECT().CreateUser(gomock.Any()).Return(nil, entity.ErrorConflict("email already registered"))
+
+		u := NewUserUsecase(mockUserRepo, mockAuthorizer, nil, nil)
+
+		_, err := u.CreateUser(ctx, entity.UserInput{Email: "cs@test.com", Password: "password123", Role: "cs"})
+		assert.EqualError(t, err, "email already registered")
+	})
+
+	t.Run("duplicate email in other case", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionUserManage).Return(admin, nil)
+		mockUserRepo.EXPECT().GetRolePermissions("cs").Return([]entity.Permission{entity.PermissionPaymentRead}, nil)
+		mockUserRepo.EXPECT().
+			CreateUser(gomock.Any()).
+			DoAndReturn(func(user *entity.User) (*entity.User, error) {
+				// collides with the existing cs@test.com
+				assert.Equal(t, "cs@test.com", user.Email)
+				return nil, entity.ErrorConflict("email already registered")
+			})
+
+		u := NewUserUsecase(mockUserRepo, mockAuthorizer, nil, nil)
+
+		_, err := u.CreateUser(ctx, entity.UserInput{Email: "CS@Test.com", Password: "password123", Role: "cs"})
+		assert.EqualError(t, err, "email already registered")
+	})
+}
+
+func TestUser_UpdateUserRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUserRepo := am.NewMockUserRepository(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+
+	t.Run("success", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionUserManage).Return(admin, nil)
+		mockUserRepo.EXPECT().GetRolePermissions("operation").Return([]entity.Permission{entity.PermissionPaymentReview}, nil)
+		mockUserRepo.EXPECT().UpdateUserRole("2", "operation").Return(&entity.User{ID: "2", Role: "operation"}, nil)
+
+		u := NewUserUsecase(mockUserRepo, mockAuthorizer, nil, nil)
+
+		user, err := u.UpdateUserRole(ctx, "2", "operation")
+		assert.NoError(t, err)
+		assert.Equal(t, "operation", user.Role)
+	})
+
+	t.Run("last admin", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionUserManage).Return(admin, nil)
+		mockUserRepo.EXPECT().GetRolePermissions("cs").Return([]entity.Permission{entity.PermissionPaymentRead}, nil)
+		mockUserRepo.EXPECT().UpdateUserRole("1", "cs").Return(nil, entity.ErrorConflict("cannot remove the last admin"))
+
+		u := NewUserUsecase(mockUserRepo, mockAuthorizer, nil, nil)
+
+		_, err := u.UpdateUserRole(ctx, "1", "cs")
+		assert.EqualError(t, err, "cannot remove the last admin")
+	})
+}
+
+func TestUser_UpdateUserStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUserRepo := am.NewMockUserRepository(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+	mockRevocations := azm.NewMockRevocationList(ctrl)
+
+	t.Run("disable revokes tokens", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionUserManage).Return(admin, nil)
+		mockUserRepo.EXPECT().UpdateUserStatus("2", entity.UserStatusDisabled).Return(&entity.User{ID: "2", Status: entity.UserStatusDisabled}, nil)
+		mockRevocations.EXPECT().RevokeUser("2").Return(nil)
+
+		u := NewUserUsecase(mockUserRepo, mockAuthorizer, mockRevocations, nil)
+
+		user, err := u.UpdateUserStatus(ctx, "2", entity.UserStatusDisabled)
+		assert.NoError(t, err)
+		assert.Equal(t, entity.UserStatusDisabled, user.Status)
+	})
+
+	t.Run("enable", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionUserManage).Return(admin, nil)
+		mockUserRepo.EXPECT().UpdateUserStatus("2", entity.UserStatusActive).Return(&entity.User{ID: "2", Status: entity.UserStatusActive}, nil)
+
+		u := NewUserUsecase(mockUserRepo, mockAuthorizer, mockRevocations, nil)
+
+		_, err := u.UpdateUserStatus(ctx, "2", entity.UserStatusActive)
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionUserManage).Return(admin, nil)
+
+		u := NewUserUsecase(mockUserRepo, mockAuthorizer, mockRevocations, nil)
+
+		_, err := u.UpdateUserStatus(ctx, "2", "gone")
+		assert.Error(t, err)
+	})
+}
+
+func TestUser_DeleteUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUserRepo := am.NewMockUserRepository(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+
+	mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionUserManage).Return(admin, nil)
+	mockUserRepo.EXPECT().DeleteUser("2").Return(nil)
+
+	u := NewUserUsecase(mockUserRepo, mockAuthorizer, nil, nil)
+	assert.NoError(t, u.DeleteUser(ctx, "2"))
+}
+
+func TestUser_ListLockouts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockLockoutRepo := am.NewMockLockoutRepository(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+
+	t.Run("success", func(t *testing.T) {
+		expected := []*entity.LoginLockout{{Scope: entity.LockoutScopeAccount, Key: "cs@test.com", Failures: 6}}
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionUserManage).Return(admin, nil)
+		mockLockoutRepo.EXPECT().ListLockouts(gomock.Any()).Return(expected, nil)
+
+		u := NewUserUsecase(nil, mockAuthorizer, nil, mockLockoutRepo)
+
+		items, err := u.ListLockouts(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, items)
+	})
+
+	t.Run("missing permission", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionUserManage).Return(nil, entity.ErrorForbidden("missing permission user:manage"))
+
+		u := NewUserUsecase(nil, mockAuthorizer, nil, mockLockoutRepo)
+
+		_, err := u.ListLockouts(ctx)
+		assert.EqualError(t, err, "missing permission user:manage")
+	})
+}
+
+func TestUser_ClearLockout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockLockoutRepo := am.NewMockLockoutRepository(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+
+	t.Run("account", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionUserManage).Return(admin, nil)
+		mockLockoutRepo.EXPECT().ClearLockout(entity.LockoutScopeAccount, "cs@test.com").Return(nil)
+
+		u := NewUserUsecase(nil, mockAuthorizer, nil, mockLockoutRepo)
+		assert.NoError(t, u.ClearLockout(ctx, entity.LockoutScopeAccount, " CS@test.com"))
+	})
+
+	t.Run("ip", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionUserManage).Return(admin, nil)
+		mockLockoutRepo.EXPECT().ClearLockout(entity.LockoutScopeIP, "10.0.0.1").Return(nil)
+
+		u := NewUserUsecase(nil, mockAuthorizer, nil, mockLockoutRepo)
+		assert.NoError(t, u.ClearLockout(ctx, entity.LockoutScopeIP, "10.0.0.1"))
+	})
+
+	t.Run("invalid scope", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Authorize(ctx, entity.PermissionUserManage).Return(admin, nil)
+
+		u := NewUserUsecase(nil, mockAuthorizer, nil, mockLockoutRepo)
+
+		err := u.ClearLockout(ctx, "user", "1")
+		assert.Error(t, err)
+	})
+}
@@ -21,167 +21,2636 @@ import (
 )
 
 const (
+	ApiKeyAuthScopes = "apiKeyAuth.Scopes"
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for AnalyticsInterval.
+const (
+	Day   AnalyticsInterval = "day"
+	Hour  AnalyticsInterval = "hour"
+	Month AnalyticsInterval = "month"
+	Week  AnalyticsInterval = "week"
+)
+
+// Defines values for ExportJobFormat.
+const (
+	ExportJobFormatCsv  ExportJobFormat = "csv"
+	ExportJobFormatXlsx ExportJobFormat = "xlsx"
+)
+
+// Defines values for ExportJobStatus.
+const (
+	ExportJobStatusCompleted ExportJobStatus = "completed"
+	ExportJobStatusExpired   ExportJobStatus = "expired"
+	ExportJobStatusFailed    ExportJobStatus = "failed"
+	ExportJobStatusPending   ExportJobStatus = "pending"
+	ExportJobStatusRunning   ExportJobStatus = "running"
+)
+
+// Defines values for ExportJobInputFormat.
+const (
+	ExportJobInputFormatCsv  ExportJobInputFormat = "csv"
+	ExportJobInputFormatXlsx ExportJobInputFormat = "xlsx"
+)
+
+// Defines values for LockoutScope.
+const (
+	Account LockoutScope = "account"
+	Ip      LockoutScope = "ip"
+)
+
+// Defines values for MerchantStatus.
+const (
+	MerchantStatusActive   MerchantStatus = "active"
+	MerchantStatusInactive MerchantStatus = "inactive"
+)
+
+// Defines values for PaymentEventOutcome.
+const (
+	PaymentEventOutcomeApproved PaymentEventOutcome = "approved"
+	PaymentEventOutcomeFlagged  PaymentEventOutcome = "flagged"
+)
+
+// Defines values for PaymentEventType.
+const (
+	Created       PaymentEventType = "created"
+	NoteAdded     PaymentEventType = "note_added"
+	Refunded      PaymentEventType = "refunded"
+	Reviewed      PaymentEventType = "reviewed"
+	StatusChanged PaymentEventType = "status_changed"
+)
+
+// Defines values for PaymentReviewOutcome.
+const (
+	PaymentReviewOutcomeApproved PaymentReviewOutcome = "approved"
+	PaymentReviewOutcomeFlagged  PaymentReviewOutcome = "flagged"
+)
+
+// Defines values for PaymentStatus.
+const (
+	PaymentStatusCompleted         PaymentStatus = "completed"
+	PaymentStatusFailed            PaymentStatus = "failed"
+	PaymentStatusPartiallyRefunded PaymentStatus = "partially_refunded"
+	PaymentStatusPending           PaymentStatus = "pending"
+	PaymentStatusProcessing        PaymentStatus = "processing"
+	PaymentStatusRefunded          PaymentStatus = "refunded"
+)
+
+// Defines values for PaymentSummaryScope.
+const (
+	PaymentSummaryScopeAll      PaymentSummaryScope = "all"
+	PaymentSummaryScopeFiltered PaymentSummaryScope = "filtered"
+)
+
+// Defines values for UserStatus.
+const (
+	UserStatusActive   UserStatus = "active"
+	UserStatusDisabled UserStatus = "disabled"
+)
+
+// Defines values for PutDashboardV1PaymentIdReviewJSONBodyOutcome.
+const (
+	Approved PutDashboardV1PaymentIdReviewJSONBodyOutcome = "approved"
+	Flagged  PutDashboardV1PaymentIdReviewJSONBodyOutcome = "flagged"
+)
+
+// Defines values for GetDashboardV1PaymentsParamsSummaryScope.
+const (
+	GetDashboardV1PaymentsParamsSummaryScopeAll      GetDashboardV1PaymentsParamsSummaryScope = "all"
+	GetDashboardV1PaymentsParamsSummaryScopeFiltered GetDashboardV1PaymentsParamsSummaryScope = "filtered"
+)
+
+// Defines values for GetDashboardV1PaymentsExportParamsFormat.
+const (
+	Csv  GetDashboardV1PaymentsExportParamsFormat = "csv"
+	Xlsx GetDashboardV1PaymentsExportParamsFormat = "xlsx"
+)
+
+// APIKey defines model for APIKey.
+type APIKey struct {
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	CreatedBy  *string    `json:"created_by,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	Id         *string    `json:"id,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+
+	// MerchantId The only merchant whose payments the key sees, absent for a team key
+	MerchantId *string `json:"merchant_id,omitempty"`
+	Name       *string `json:"name,omitempty"`
+
+	// Prefix The key starts with dpk_<prefix>_, to tell keys apart
+	Prefix    *string    `json:"prefix,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	Scopes    *[]string  `json:"scopes,omitempty"`
+}
+
+// APIKeyInput defines model for APIKeyInput.
+type APIKeyInput struct {
+	// ExpiresAt Omit for a key that does not expire
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// MerchantId Limit the key to the payments of one merchant, omit for a team key
+	MerchantId *string `json:"merchant_id,omitempty"`
+	Name       string  `json:"name"`
+
+	// Scopes Permissions of the key, each held by the creator. user:manage and api_key:manage cannot be granted.
+	Scopes []string `json:"scopes"`
+}
+
+// AnalyticsInterval defines model for AnalyticsInterval.
+type AnalyticsInterval string
+
+// CurrencyAmount defines model for CurrencyAmount.
+type CurrencyAmount struct {
+	Amount      *string `json:"amount,omitempty"`
+	AmountMinor *int64  `json:"amount_minor,omitempty"`
+	Currency    *string `json:"currency,omitempty"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 }
 
+// ExportJob defines model for ExportJob.
+type ExportJob struct {
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+
+	// DownloadUrl Set once the export is completed
+	DownloadUrl *string `json:"download_url,omitempty"`
+
+	// Error Why a failed export failed
+	Error *string `json:"error,omitempty"`
+
+	// ExpiresAt When the file of a completed export is removed
+	ExpiresAt *time.Time       `json:"expires_at,omitempty"`
+	Format    *ExportJobFormat `json:"format,omitempty"`
+	Id        *string          `json:"id,omitempty"`
+
+	// Progress Percentage of total_rows written, 100 once completed
+	Progress    *int             `json:"progress,omitempty"`
+	RowsWritten *int             `json:"rows_written,omitempty"`
+	Status      *ExportJobStatus `json:"status,omitempty"`
+
+	// TotalRows Payments matching the filter when the export was created
+	TotalRows *int `json:"total_rows,omitempty"`
+}
+
+// ExportJobFormat defines model for ExportJob.Format.
+type ExportJobFormat string
+
+// ExportJobStatus defines model for ExportJob.Status.
+type ExportJobStatus string
+
+// ExportJobInput Payment list filters and sort to export, see the payment list parameters
+type ExportJobInput struct {
+	AmountMax   *int64                `json:"amount_max,omitempty"`
+	AmountMin   *int64                `json:"amount_min,omitempty"`
+	CreatedFrom *time.Time            `json:"created_from,omitempty"`
+	CreatedTo   *time.Time            `json:"created_to,omitempty"`
+	Currency    *string               `json:"currency,omitempty"`
+	Format      *ExportJobInputFormat `json:"format,omitempty"`
+	Id          *string               `json:"id,omitempty"`
+	MerchantId  *string               `json:"merchant_id,omitempty"`
+	Q           *string               `json:"q,omitempty"`
+	Reviewed    *bool                 `json:"reviewed,omitempty"`
+	Sort        *string               `json:"sort,omitempty"`
+
+	// Status Payment lifecycle status. Allowed transitions: pending -> processing | failed, processing -> completed | failed, completed -> partially_refunded | refunded, partially_refunded -> refunded. The refund statuses are only reached by creating refunds.
+	Status *PaymentStatus `json:"status,omitempty"`
+}
+
+// ExportJobInputFormat defines model for ExportJobInput.Format.
+type ExportJobInputFormat string
+
+// LockoutScope Failed logins are counted per account, keyed by email, and per client IP.
+type LockoutScope string
+
+// LoginLockout defines model for LoginLockout.
+type LoginLockout struct {
+	// Failures Failed logins since the count was last reset
+	Failures      *int       `json:"failures,omitempty"`
+	Key           *string    `json:"key,omitempty"`
+	LastFailureAt *time.Time `json:"last_failure_at,omitempty"`
+	LockedUntil   *time.Time `json:"locked_until,omitempty"`
+
+	// Scope Failed logins are counted per account, keyed by email, and per client IP.
+	Scope *LockoutScope `json:"scope,omitempty"`
+}
+
+// Merchant defines model for Merchant.
+type Merchant struct {
+	ContactEmail *string    `json:"contact_email,omitempty"`
+	CreatedAt    *time.Time `json:"created_at,omitempty"`
+	DisplayName  *string    `json:"display_name,omitempty"`
+	Id           *string    `json:"id,omitempty"`
+	LegalName    *string    `json:"legal_name,omitempty"`
+
+	// SettlementCurrency ISO 4217 currency code the merchant is settled in
+	SettlementCurrency *string `json:"settlement_currency,omitempty"`
+
+	// Status Inactive merchants cannot receive new payments.
+	Status *MerchantStatus `json:"status,omitempty"`
+}
+
+// MerchantInput defines model for MerchantInput.
+type MerchantInput struct {
+	ContactEmail       string `json:"contact_email"`
+	DisplayName        string `json:"display_name"`
+	LegalName          string `json:"legal_name"`
+	SettlementCurrency string `json:"settlement_currency"`
+
+	// Status Inactive merchants cannot receive new payments.
+	Status *MerchantStatus `json:"status,omitempty"`
+}
+
+// MerchantReport defines model for MerchantReport.
+type MerchantReport struct {
+	// AverageAmount Average payment amount, rounded down to the minor unit
+	AverageAmount      *string `json:"average_amount,omitempty"`
+	AverageAmountMinor *int64  `json:"average_amount_minor,omitempty"`
+
+	// Count Number of payments
+	Count    *int    `json:"count,omitempty"`
+	Currency *string `json:"currency,omitempty"`
+	Failed   *int    `json:"failed,omitempty"`
+
+	// FailureRate Share of payments that failed, from 0 to 1
+	FailureRate   *float64   `json:"failure_rate,omitempty"`
+	LastPaymentAt *time.Time `json:"last_payment_at,omitempty"`
+	Merchant      *string    `json:"merchant,omitempty"`
+	MerchantId    *string    `json:"merchant_id,omitempty"`
+
+	// Succeeded Number of payments that reached completed, including the ones refunded since
+	Succeeded *int `json:"succeeded,omitempty"`
+
+	// SuccessRate Share of payments that succeeded, from 0 to 1
+	SuccessRate *float64 `json:"success_rate,omitempty"`
+
+	// Volume Summed amount of all payments
+	Volume      *string `json:"volume,omitempty"`
+	VolumeMinor *int64  `json:"volume_minor,omitempty"`
+}
+
+// MerchantStatus Inactive merchants cannot receive new payments.
+type MerchantStatus string
+
 // PaginationMeta defines model for PaginationMeta.
 type PaginationMeta struct {
 	// Limit Limit or page size used
 	Limit *int `json:"limit,omitempty"`
 
+	// NextCursor Cursor of the next page, absent on the last page
+	NextCursor *string `json:"next_cursor,omitempty"`
+
 	// Offset Offset used
 	Offset *int `json:"offset,omitempty"`
 
+	// PrevCursor Cursor of the previous page, absent on the first page
+	PrevCursor *string `json:"prev_cursor,omitempty"`
+
 	// Total Total number of items
 	Total *int `json:"total,omitempty"`
 }
 
-// Payment defines model for Payment.
-type Payment struct {
-	Amount    *string    `json:"amount,omitempty"`
-	CreatedAt *time.Time `json:"created_at,omitempty"`
-	Id        *string    `json:"id,omitempty"`
-	Merchant  *string    `json:"merchant,omitempty"`
-	Status    *string    `json:"status,omitempty"`
-}
+// Payment defines model for Payment.
+type Payment struct {
+	// Amount Decimal amount with as many decimals as the currency exponent (IDR 0, USD 2, BHD 3)
+	Amount *string `json:"amount,omitempty"`
+
+	// AmountMinor Amount in the minor unit of the currency
+	AmountMinor *int64     `json:"amount_minor,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+
+	// Currency ISO 4217 currency code
+	Currency *string `json:"currency,omitempty"`
+	Id       *string `json:"id,omitempty"`
+
+	// Merchant Display name of the merchant
+	Merchant   *string `json:"merchant,omitempty"`
+	MerchantId *string `json:"merchant_id,omitempty"`
+
+	// RefundedAmount Sum of all refunds of the payment, formatted like amount
+	RefundedAmount *string `json:"refunded_amount,omitempty"`
+
+	// Refunds Refunds of the payment, only included in single payment responses
+	Refunds *[]Refund `json:"refunds,omitempty"`
+
+	// Review Latest review recorded for a payment
+	Review *PaymentReview `json:"review,omitempty"`
+
+	// Reviewed Whether the payment has been reviewed at least once
+	Reviewed *bool `json:"reviewed,omitempty"`
+
+	// Status Payment lifecycle status. Allowed transitions: pending -> processing | failed, processing -> completed | failed, completed -> partially_refunded | refunded, partially_refunded -> refunded. The refund statuses are only reached by creating refunds.
+	Status *PaymentStatus `json:"status,omitempty"`
+}
+
+// PaymentBucket defines model for PaymentBucket.
+type PaymentBucket struct {
+	// Count Number of payments created in the bucket
+	Count *int `json:"count,omitempty"`
+
+	// End End of the bucket in the requested timezone, exclusive
+	End *time.Time `json:"end,omitempty"`
+
+	// Start Start of the bucket in the requested timezone, inclusive
+	Start *time.Time `json:"start,omitempty"`
+
+	// Statuses Count and summed amounts of every status that has payments in the bucket
+	Statuses *[]PaymentStatusSummary `json:"statuses,omitempty"`
+}
+
+// PaymentEvent One entry of a payment timeline. Only the fields relevant to the event type are set: from_status and to_status for status_changed, outcome for reviewed, amount for created and refunded. note holds the status change reason, review note, refund reason or note text.
+type PaymentEvent struct {
+	ActorEmail *string `json:"actor_email,omitempty"`
+	ActorId    *string `json:"actor_id,omitempty"`
+
+	// Amount Decimal amount in the currency of the payment
+	Amount      *string    `json:"amount,omitempty"`
+	AmountMinor *int64     `json:"amount_minor,omitempty"`
+	At          *time.Time `json:"at,omitempty"`
+
+	// FromStatus Payment lifecycle status. Allowed transitions: pending -> processing | failed, processing -> completed | failed, completed -> partially_refunded | refunded, partially_refunded -> refunded. The refund statuses are only reached by creating refunds.
+	FromStatus *PaymentStatus `json:"from_status,omitempty"`
+
+	// Id id of the record the event comes from
+	Id      *string              `json:"id,omitempty"`
+	Note    *string              `json:"note,omitempty"`
+	Outcome *PaymentEventOutcome `json:"outcome,omitempty"`
+
+	// ToStatus Payment lifecycle status. Allowed transitions: pending -> processing | failed, processing -> completed | failed, completed -> partially_refunded | refunded, partially_refunded -> refunded. The refund statuses are only reached by creating refunds.
+	ToStatus *PaymentStatus    `json:"to_status,omitempty"`
+	Type     *PaymentEventType `json:"type,omitempty"`
+}
+
+// PaymentEventOutcome defines model for PaymentEvent.Outcome.
+type PaymentEventOutcome string
+
+// PaymentEventType defines model for PaymentEvent.Type.
+type PaymentEventType string
+
+// PaymentNote defines model for PaymentNote.
+type PaymentNote struct {
+	AuthorEmail *string    `json:"author_email,omitempty"`
+	AuthorId    *string    `json:"author_id,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	Id          *string    `json:"id,omitempty"`
+	Note        *string    `json:"note,omitempty"`
+}
+
+// PaymentReview Latest review recorded for a payment
+type PaymentReview struct {
+	Id            *string               `json:"id,omitempty"`
+	Note          *string               `json:"note,omitempty"`
+	Outcome       *PaymentReviewOutcome `json:"outcome,omitempty"`
+	ReviewedAt    *time.Time            `json:"reviewed_at,omitempty"`
+	ReviewerEmail *string               `json:"reviewer_email,omitempty"`
+	ReviewerId    *string               `json:"reviewer_id,omitempty"`
+}
+
+// PaymentReviewOutcome defines model for PaymentReview.Outcome.
+type PaymentReviewOutcome string
+
+// PaymentStatus Payment lifecycle status. Allowed transitions: pending -> processing | failed, processing -> completed | failed, completed -> partially_refunded | refunded, partially_refunded -> refunded. The refund statuses are only reached by creating refunds.
+type PaymentStatus string
+
+// PaymentStatusSummary defines model for PaymentStatusSummary.
+type PaymentStatusSummary struct {
+	// Amounts Summed amounts per currency
+	Amounts *[]CurrencyAmount `json:"amounts,omitempty"`
+	Count   *int              `json:"count,omitempty"`
+
+	// Status Payment lifecycle status. Allowed transitions: pending -> processing | failed, processing -> completed | failed, completed -> partially_refunded | refunded, partially_refunded -> refunded. The refund statuses are only reached by creating refunds.
+	Status *PaymentStatus `json:"status,omitempty"`
+}
+
+// PaymentSummary defines model for PaymentSummary.
+type PaymentSummary struct {
+	// Completed Total number of completed payment
+	Completed *int `json:"completed,omitempty"`
+
+	// Failed Total number of failed payment
+	Failed *int `json:"failed,omitempty"`
+
+	// Pending Total number of pending payment
+	Pending *int `json:"pending,omitempty"`
+
+	// Scope Whether the summary covers all payments or only the ones matching the filter
+	Scope *PaymentSummaryScope `json:"scope,omitempty"`
+
+	// Statuses Count and summed amounts of every status that has payments
+	Statuses *[]PaymentStatusSummary `json:"statuses,omitempty"`
+
+	// Total Total all payment
+	Total *int `json:"total,omitempty"`
+}
+
+// PaymentSummaryScope Whether the summary covers all payments or only the ones matching the filter
+type PaymentSummaryScope string
+
+// RecoveryCodes defines model for RecoveryCodes.
+type RecoveryCodes struct {
+	// RecoveryCodes Single use codes accepted instead of a TOTP code, shown only once
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// Refund defines model for Refund.
+type Refund struct {
+	// Amount Decimal amount in the currency of the payment
+	Amount *string `json:"amount,omitempty"`
+
+	// AmountMinor Amount in the minor unit of the payment currency
+	AmountMinor *int64     `json:"amount_minor,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	CreatedBy   *string    `json:"created_by,omitempty"`
+	Id          *string    `json:"id,omitempty"`
+	Reason      *string    `json:"reason,omitempty"`
+}
+
+// TOTPEnrollment defines model for TOTPEnrollment.
+type TOTPEnrollment struct {
+	// ProvisioningUri otpauth URI to render as a QR code
+	ProvisioningUri string `json:"provisioning_uri"`
+
+	// Secret Base32 secret for authenticator apps that cannot scan QR codes
+	Secret string `json:"secret"`
+}
+
+// User defines model for User.
+type User struct {
+	ChallengeExpiresAt *time.Time `json:"challenge_expires_at,omitempty"`
+	ChallengeToken     *string    `json:"challenge_token,omitempty"`
+	Email              *string    `json:"email,omitempty"`
+
+	// ExpiresAt When the access token expires
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// Permissions Permissions granted to the role of the user
+	Permissions      *[]string  `json:"permissions,omitempty"`
+	RefreshExpiresAt *time.Time `json:"refresh_expires_at,omitempty"`
+
+	// RefreshToken Single use token exchanged for new tokens at /dashboard/v1/auth/refresh
+	RefreshToken *string `json:"refresh_token,omitempty"`
+	Role         *string `json:"role,omitempty"`
+
+	// Token Short-lived access token
+	Token *string `json:"token,omitempty"`
+
+	// TwoFactorRequired Set when the user has two-factor authentication, no tokens are returned and challenge_token is exchanged with a code at /dashboard/v1/auth/login/verify
+	TwoFactorRequired *bool `json:"two_factor_required,omitempty"`
+}
+
+// UserAccount defines model for UserAccount.
+type UserAccount struct {
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	Email     *string    `json:"email,omitempty"`
+	Id        *string    `json:"id,omitempty"`
+	Role      *string    `json:"role,omitempty"`
+
+	// Status Disabled users cannot log in and their tokens are revoked.
+	Status *UserStatus `json:"status,omitempty"`
+}
+
+// UserInput defines model for UserInput.
+type UserInput struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+
+	// Role A role granted at least one permission in role_permissions
+	Role string `json:"role"`
+}
+
+// UserStatus Disabled users cannot log in and their tokens are revoked.
+type UserStatus string
+
+// Limit defines model for limit.
+type Limit = int
+
+// Offset defines model for offset.
+type Offset = int
+
+// PaymentAmountMax defines model for paymentAmountMax.
+type PaymentAmountMax = int64
+
+// PaymentAmountMin defines model for paymentAmountMin.
+type PaymentAmountMin = int64
+
+// PaymentCreatedFrom defines model for paymentCreatedFrom.
+type PaymentCreatedFrom = time.Time
+
+// PaymentCreatedTo defines model for paymentCreatedTo.
+type PaymentCreatedTo = time.Time
+
+// PaymentCurrency defines model for paymentCurrency.
+type PaymentCurrency = string
+
+// PaymentId defines model for paymentId.
+type PaymentId = string
+
+// PaymentMerchantId defines model for paymentMerchantId.
+type PaymentMerchantId = string
+
+// PaymentReviewed defines model for paymentReviewed.
+type PaymentReviewed = bool
+
+// PaymentSearch defines model for paymentSearch.
+type PaymentSearch = string
+
+// Sort defines model for sort.
+type Sort = string
+
+// APIKeyCreatedResponse defines model for APIKeyCreatedResponse.
+type APIKeyCreatedResponse struct {
+	ApiKey *APIKey `json:"api_key,omitempty"`
+	Key    *string `json:"key,omitempty"`
+}
+
+// APIKeyListResponse defines model for APIKeyListResponse.
+type APIKeyListResponse struct {
+	ApiKeys *[]APIKey       `json:"api_keys,omitempty"`
+	Meta    *PaginationMeta `json:"meta,omitempty"`
+}
+
+// BadRequestError defines model for BadRequestError.
+type BadRequestError = Error
+
+// ConflictError defines model for ConflictError.
+type ConflictError = Error
+
+// ExportJobResponse defines model for ExportJobResponse.
+type ExportJobResponse struct {
+	Export *ExportJob `json:"export,omitempty"`
+}
+
+// ForbiddenError defines model for ForbiddenError.
+type ForbiddenError = Error
+
+// LockoutListResponse defines model for LockoutListResponse.
+type LockoutListResponse struct {
+	Lockouts *[]LoginLockout `json:"lockouts,omitempty"`
+}
+
+// LoginResponse defines model for LoginResponse.
+type LoginResponse = User
+
+// MerchantListResponse defines model for MerchantListResponse.
+type MerchantListResponse struct {
+	Merchants *[]Merchant     `json:"merchants,omitempty"`
+	Meta      *PaginationMeta `json:"meta,omitempty"`
+}
+
+// MerchantReportResponse defines model for MerchantReportResponse.
+type MerchantReportResponse struct {
+	Merchants *[]MerchantReport `json:"merchants,omitempty"`
+	Meta      *PaginationMeta   `json:"meta,omitempty"`
+}
+
+// MerchantResponse defines model for MerchantResponse.
+type MerchantResponse struct {
+	Merchant *Merchant `json:"merchant,omitempty"`
+}
+
+// NotFoundError defines model for NotFoundError.
+type NotFoundError = Error
+
+// PaymentAnalyticsResponse defines model for PaymentAnalyticsResponse.
+type PaymentAnalyticsResponse struct {
+	Buckets  *[]PaymentBucket   `json:"buckets,omitempty"`
+	Interval *AnalyticsInterval `json:"interval,omitempty"`
+	Timezone *string            `json:"timezone,omitempty"`
+}
+
+// PaymentCreateResponse defines model for PaymentCreateResponse.
+type PaymentCreateResponse struct {
+	Payment *Payment `json:"payment,omitempty"`
+}
+
+// PaymentDetailResponse defines model for PaymentDetailResponse.
+type PaymentDetailResponse struct {
+	Payment  *Payment        `json:"payment,omitempty"`
+	Timeline *[]PaymentEvent `json:"timeline,omitempty"`
+}
+
+// PaymentListResponse defines model for PaymentListResponse.
+type PaymentListResponse struct {
+	Meta     *PaginationMeta `json:"meta,omitempty"`
+	Payments *[]Payment      `json:"payments,omitempty"`
+	Summary  *PaymentSummary `json:"summary,omitempty"`
+}
+
+// PaymentNoteResponse defines model for PaymentNoteResponse.
+type PaymentNoteResponse struct {
+	Note *PaymentNote `json:"note,omitempty"`
+}
+
+// PaymentRefundResponse defines model for PaymentRefundResponse.
+type PaymentRefundResponse struct {
+	Payment *Payment `json:"payment,omitempty"`
+	Refund  *Refund  `json:"refund,omitempty"`
+}
+
+// PaymentReviewResponse defines model for PaymentReviewResponse.
+type PaymentReviewResponse struct {
+	Message *string `json:"message,omitempty"`
+
+	// Review Latest review recorded for a payment
+	Review *PaymentReview `json:"review,omitempty"`
+}
+
+// PaymentStatusResponse defines model for PaymentStatusResponse.
+type PaymentStatusResponse struct {
+	Message *string  `json:"message,omitempty"`
+	Payment *Payment `json:"payment,omitempty"`
+}
+
+// TooManyRequestsError defines model for TooManyRequestsError.
+type TooManyRequestsError = Error
+
+// UnauthorizedError defines model for UnauthorizedError.
+type UnauthorizedError = Error
+
+// UserAccountResponse defines model for UserAccountResponse.
+type UserAccountResponse struct {
+	User *UserAccount `json:"user,omitempty"`
+}
+
+// UserListResponse defines model for UserListResponse.
+type UserListResponse struct {
+	Meta  *PaginationMeta `json:"meta,omitempty"`
+	Users *[]UserAccount  `json:"users,omitempty"`
+}
+
+// GetDashboardV1AnalyticsMerchantsParams defines parameters for GetDashboardV1AnalyticsMerchants.
+type GetDashboardV1AnalyticsMerchantsParams struct {
+	// From only payments created at or after this time
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To only payments created before this time
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Currency only payments in this currency
+	Currency *string `form:"currency,omitempty" json:"currency,omitempty"`
+
+	// Sort Comma-separated sort fields, prefix a field with `-` to sort descending. Allowed fields: merchant_id, merchant, currency, count, volume, success_rate, failure_rate, average_amount and last_payment_at. Ties are broken by merchant_id and currency.
+	Sort *string `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Limit Limit number of items to return (max 100)
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Offset from start (0-based)
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// GetDashboardV1AnalyticsPaymentsParams defines parameters for GetDashboardV1AnalyticsPayments.
+type GetDashboardV1AnalyticsPaymentsParams struct {
+	// Interval width of the buckets, defaults to day
+	Interval *AnalyticsInterval `form:"interval,omitempty" json:"interval,omitempty"`
+
+	// From start of the range, inclusive, defaults to 30 days before to
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To end of the range, exclusive, defaults to now
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Timezone IANA timezone the buckets are aligned to
+	Timezone *string `form:"timezone,omitempty" json:"timezone,omitempty"`
+
+	// MerchantId merchant id
+	MerchantId *PaymentMerchantId `form:"merchant_id,omitempty" json:"merchant_id,omitempty"`
+}
+
+// GetDashboardV1ApiKeysParams defines parameters for GetDashboardV1ApiKeys.
+type GetDashboardV1ApiKeysParams struct {
+	// Limit Limit number of items to return (max 100)
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Offset from start (0-based)
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// PostDashboardV1AuthLoginJSONBody defines parameters for PostDashboardV1AuthLogin.
+type PostDashboardV1AuthLoginJSONBody struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// PostDashboardV1AuthLoginVerifyJSONBody defines parameters for PostDashboardV1AuthLoginVerify.
+type PostDashboardV1AuthLoginVerifyJSONBody struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+}
+
+// PostDashboardV1AuthLogoutJSONBody defines parameters for PostDashboardV1AuthLogout.
+type PostDashboardV1AuthLogoutJSONBody struct {
+	RefreshToken *string `json:"refresh_token,omitempty"`
+}
+
+// PostDashboardV1AuthPasswordResetJSONBody defines parameters for PostDashboardV1AuthPasswordReset.
+type PostDashboardV1AuthPasswordResetJSONBody struct {
+	Email string `json:"email"`
+}
+
+// PostDashboardV1AuthPasswordResetConfirmJSONBody defines parameters for PostDashboardV1AuthPasswordResetConfirm.
+type PostDashboardV1AuthPasswordResetConfirmJSONBody struct {
+	Password string `json:"password"`
+	Token    string `json:"token"`
+}
+
+// PostDashboardV1AuthRefreshJSONBody defines parameters for PostDashboardV1AuthRefresh.
+type PostDashboardV1AuthRefreshJSONBody struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// PostDashboardV1AuthTotpDisableJSONBody defines parameters for PostDashboardV1AuthTotpDisable.
+type PostDashboardV1AuthTotpDisableJSONBody struct {
+	Code string `json:"code"`
+}
+
+// PostDashboardV1AuthTotpEnableJSONBody defines parameters for PostDashboardV1AuthTotpEnable.
+type PostDashboardV1AuthTotpEnableJSONBody struct {
+	Code string `json:"code"`
+}
+
+// GetDashboardV1MerchantsParams defines parameters for GetDashboardV1Merchants.
+type GetDashboardV1MerchantsParams struct {
+	// Limit Limit number of items to return (max 100)
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Offset from start (0-based)
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Status status of merchant
+	Status *MerchantStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// PostDashboardV1PaymentIdNotesJSONBody defines parameters for PostDashboardV1PaymentIdNotes.
+type PostDashboardV1PaymentIdNotesJSONBody struct {
+	Note string `json:"note"`
+}
+
+// PostDashboardV1PaymentIdRefundsJSONBody defines parameters for PostDashboardV1PaymentIdRefunds.
+type PostDashboardV1PaymentIdRefundsJSONBody struct {
+	// Amount Decimal amount in the currency of the payment
+	Amount string  `json:"amount"`
+	Reason *string `json:"reason,omitempty"`
+}
+
+// PutDashboardV1PaymentIdReviewJSONBody defines parameters for PutDashboardV1PaymentIdReview.
+type PutDashboardV1PaymentIdReviewJSONBody struct {
+	Note    *string                                       `json:"note,omitempty"`
+	Outcome *PutDashboardV1PaymentIdReviewJSONBodyOutcome `json:"outcome,omitempty"`
+}
+
+// PutDashboardV1PaymentIdReviewJSONBodyOutcome defines parameters for PutDashboardV1PaymentIdReview.
+type PutDashboardV1PaymentIdReviewJSONBodyOutcome string
+
+// PutDashboardV1PaymentIdStatusJSONBody defines parameters for PutDashboardV1PaymentIdStatus.
+type PutDashboardV1PaymentIdStatusJSONBody struct {
+	Reason *string `json:"reason,omitempty"`
+
+	// Status Payment lifecycle status. Allowed transitions: pending -> processing | failed, processing -> completed | failed, completed -> partially_refunded | refunded, partially_refunded -> refunded. The refund statuses are only reached by creating refunds.
+	Status PaymentStatus `json:"status"`
+}
+
+// GetDashboardV1PaymentsParams defines parameters for GetDashboardV1Payments.
+type GetDashboardV1PaymentsParams struct {
+	// Limit Limit number of items to return (max 100)
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Offset from start (0-based)
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Sort Comma-separated sort fields, applied in order. Prefix a field with `-` to sort descending, e.g. `status,-amount,created_at`. Allowed fields: id, merchant, status, amount, created_at and, together with `q`, relevance. Ties are broken by id, an unknown field returns 400.
+	Sort *Sort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Cursor next_cursor or prev_cursor of a previous page. The cursor only fits the sort and filters it was issued for and cannot be combined with offset.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Q Search payment ids and merchant names, every word matches as a prefix (e.g. `tok sum` finds "Toko Sumber Rejeki"). Sorts by relevance unless `sort` is given.
+	Q *PaymentSearch `form:"q,omitempty" json:"q,omitempty"`
+
+	// Status status of payment
+	Status *PaymentStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Id payment id
+	Id *PaymentId `form:"id,omitempty" json:"id,omitempty"`
+
+	// MerchantId merchant id
+	MerchantId *PaymentMerchantId `form:"merchant_id,omitempty" json:"merchant_id,omitempty"`
+
+	// Reviewed only reviewed (true) or unreviewed (false) payments
+	Reviewed *PaymentReviewed `form:"reviewed,omitempty" json:"reviewed,omitempty"`
+
+	// CreatedFrom only payments created at or after this RFC 3339 time
+	CreatedFrom *PaymentCreatedFrom `form:"created_from,omitempty" json:"created_from,omitempty"`
+
+	// CreatedTo only payments created before this RFC 3339 time, must be after created_from
+	CreatedTo *PaymentCreatedTo `form:"created_to,omitempty" json:"created_to,omitempty"`
+
+	// Currency only payments in this currency, required by amount_min and amount_max
+	Currency *PaymentCurrency `form:"currency,omitempty" json:"currency,omitempty"`
+
+	// AmountMin only payments with at least this amount in minor units of currency (see amount_minor)
+	AmountMin *PaymentAmountMin `form:"amount_min,omitempty" json:"amount_min,omitempty"`
+
+	// AmountMax only payments with at most this amount in minor units, must not be less than amount_min
+	AmountMax *PaymentAmountMax `form:"amount_max,omitempty" json:"amount_max,omitempty"`
+
+	// SummaryScope compute the summary over all payments or only the ones matching the filter
+	SummaryScope *GetDashboardV1PaymentsParamsSummaryScope `form:"summary_scope,omitempty" json:"summary_scope,omitempty"`
+}
+
+// GetDashboardV1PaymentsParamsSummaryScope defines parameters for GetDashboardV1Payments.
+type GetDashboardV1PaymentsParamsSummaryScope string
+
+// PostDashboardV1PaymentsJSONBody defines parameters for PostDashboardV1Payments.
+type PostDashboardV1PaymentsJSONBody struct {
+	// Amount Decimal amount with at most as many decimals as the currency exponent
+	Amount string `json:"amount"`
+
+	// Currency ISO 4217 currency code, one of IDR, JPY, KRW, VND, USD, EUR, GBP, AUD, SGD, MYR, THB, PHP, BHD, JOD, KWD, OMR
+	Currency string `json:"currency"`
+
+	// MerchantId id of an active merchant
+	MerchantId string `json:"merchant_id"`
+}
+
+// PostDashboardV1PaymentsParams defines parameters for PostDashboardV1Payments.
+type PostDashboardV1PaymentsParams struct {
+	// IdempotencyKey client generated unique key for this request
+	IdempotencyKey *string `json:"Idempotency-Key,omitempty"`
+}
+
+// GetDashboardV1PaymentsExportParams defines parameters for GetDashboardV1PaymentsExport.
+type GetDashboardV1PaymentsExportParams struct {
+	// Format file format
+	Format *GetDashboardV1PaymentsExportParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// Sort Comma-separated sort fields, applied in order. Prefix a field with `-` to sort descending, e.g. `status,-amount,created_at`. Allowed fields: id, merchant, status, amount, created_at and, together with `q`, relevance. Ties are broken by id, an unknown field returns 400.
+	Sort *Sort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Q Search payment ids and merchant names, every word matches as a prefix (e.g. `tok sum` finds "Toko Sumber Rejeki"). Sorts by relevance unless `sort` is given.
+	Q *PaymentSearch `form:"q,omitempty" json:"q,omitempty"`
+
+	// Status status of payment
+	Status *PaymentStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Id payment id
+	Id *PaymentId `form:"id,omitempty" json:"id,omitempty"`
+
+	// MerchantId merchant id
+	MerchantId *PaymentMerchantId `form:"merchant_id,omitempty" json:"merchant_id,omitempty"`
+
+	// Reviewed only reviewed (true) or unreviewed (false) payments
+	Reviewed *PaymentReviewed `form:"reviewed,omitempty" json:"reviewed,omitempty"`
+
+	// CreatedFrom only payments created at or after this RFC 3339 time
+	CreatedFrom *PaymentCreatedFrom `form:"created_from,omitempty" json:"created_from,omitempty"`
+
+	// CreatedTo only payments created before this RFC 3339 time, must be after created_from
+	CreatedTo *PaymentCreatedTo `form:"created_to,omitempty" json:"created_to,omitempty"`
+
+	// Currency only payments in this currency, required by amount_min and amount_max
+	Currency *PaymentCurrency `form:"currency,omitempty" json:"currency,omitempty"`
+
+	// AmountMin only payments with at least this amount in minor units of currency (see amount_minor)
+	AmountMin *PaymentAmountMin `form:"amount_min,omitempty" json:"amount_min,omitempty"`
+
+	// AmountMax only payments with at most this amount in minor units, must not be less than amount_min
+	AmountMax *PaymentAmountMax `form:"amount_max,omitempty" json:"amount_max,omitempty"`
+}
+
+// GetDashboardV1PaymentsExportParamsFormat defines parameters for GetDashboardV1PaymentsExport.
+type GetDashboardV1PaymentsExportParamsFormat string
+
+// PutDashboardV1UserIdRoleJSONBody defines parameters for PutDashboardV1UserIdRole.
+type PutDashboardV1UserIdRoleJSONBody struct {
+	Role string `json:"role"`
+}
+
+// PutDashboardV1UserIdStatusJSONBody defines parameters for PutDashboardV1UserIdStatus.
+type PutDashboardV1UserIdStatusJSONBody struct {
+	// Status Disabled users cannot log in and their tokens are revoked.
+	Status UserStatus `json:"status"`
+}
+
+// GetDashboardV1UsersParams defines parameters for GetDashboardV1Users.
+type GetDashboardV1UsersParams struct {
+	// Limit Limit number of items to return (max 100)
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Offset from start (0-based)
+	Offset *Offset     `form:"offset,omitempty" json:"offset,omitempty"`
+	Role   *string     `form:"role,omitempty" json:"role,omitempty"`
+	Status *UserStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// PostDashboardV1ApiKeysJSONRequestBody defines body for PostDashboardV1ApiKeys for application/json ContentType.
+type PostDashboardV1ApiKeysJSONRequestBody = APIKeyInput
+
+// PostDashboardV1AuthLoginJSONRequestBody defines body for PostDashboardV1AuthLogin for application/json ContentType.
+type PostDashboardV1AuthLoginJSONRequestBody PostDashboardV1AuthLoginJSONBody
+
+// PostDashboardV1AuthLoginVerifyJSONRequestBody defines body for PostDashboardV1AuthLoginVerify for application/json ContentType.
+type PostDashboardV1AuthLoginVerifyJSONRequestBody PostDashboardV1AuthLoginVerifyJSONBody
+
+// PostDashboardV1AuthLogoutJSONRequestBody defines body for PostDashboardV1AuthLogout for application/json ContentType.
+type PostDashboardV1AuthLogoutJSONRequestBody PostDashboardV1AuthLogoutJSONBody
+
+// PostDashboardV1AuthPasswordResetJSONRequestBody defines body for PostDashboardV1AuthPasswordReset for application/json ContentType.
+type PostDashboardV1AuthPasswordResetJSONRequestBody PostDashboardV1AuthPasswordResetJSONBody
+
+// PostDashboardV1AuthPasswordResetConfirmJSONRequestBody defines body for PostDashboardV1AuthPasswordResetConfirm for application/json ContentType.
+type PostDashboardV1AuthPasswordResetConfirmJSONRequestBody PostDashboardV1AuthPasswordResetConfirmJSONBody
+
+// PostDashboardV1AuthRefreshJSONRequestBody defines body for PostDashboardV1AuthRefresh for application/json ContentType.
+type PostDashboardV1AuthRefreshJSONRequestBody PostDashboardV1AuthRefreshJSONBody
+
+// PostDashboardV1AuthTotpDisableJSONRequestBody defines body for PostDashboardV1AuthTotpDisable for application/json ContentType.
+type PostDashboardV1AuthTotpDisableJSONRequestBody PostDashboardV1AuthTotpDisableJSONBody
+
+// PostDashboardV1AuthTotpEnableJSONRequestBody defines body for PostDashboardV1AuthTotpEnable for application/json ContentType.
+type PostDashboardV1AuthTotpEnableJSONRequestBody PostDashboardV1AuthTotpEnableJSONBody
+
+// PostDashboardV1ExportsJSONRequestBody defines body for PostDashboardV1Exports for application/json ContentType.
+type PostDashboardV1ExportsJSONRequestBody = ExportJobInput
+
+// PutDashboardV1MerchantIdJSONRequestBody defines body for PutDashboardV1MerchantId for application/json ContentType.
+type PutDashboardV1MerchantIdJSONRequestBody = MerchantInput
+
+// PostDashboardV1MerchantsJSONRequestBody defines body for PostDashboardV1Merchants for application/json ContentType.
+type PostDashboardV1MerchantsJSONRequestBody = MerchantInput
+
+// PostDashboardV1PaymentIdNotesJSONRequestBody defines body for PostDashboardV1PaymentIdNotes for application/json ContentType.
+type PostDashboardV1PaymentIdNotesJSONRequestBody PostDashboardV1PaymentIdNotesJSONBody
+
+// PostDashboardV1PaymentIdRefundsJSONRequestBody defines body for PostDashboardV1PaymentIdRefunds for application/json ContentType.
+type PostDashboardV1PaymentIdRefundsJSONRequestBody PostDashboardV1PaymentIdRefundsJSONBody
+
+// PutDashboardV1PaymentIdReviewJSONRequestBody defines body for PutDashboardV1PaymentIdReview for application/json ContentType.
+type PutDashboardV1PaymentIdReviewJSONRequestBody PutDashboardV1PaymentIdReviewJSONBody
+
+// PutDashboardV1PaymentIdStatusJSONRequestBody defines body for PutDashboardV1PaymentIdStatus for application/json ContentType.
+type PutDashboardV1PaymentIdStatusJSONRequestBody PutDashboardV1PaymentIdStatusJSONBody
+
+// PostDashboardV1PaymentsJSONRequestBody defines body for PostDashboardV1Payments for application/json ContentType.
+type PostDashboardV1PaymentsJSONRequestBody PostDashboardV1PaymentsJSONBody
+
+// PutDashboardV1UserIdRoleJSONRequestBody defines body for PutDashboardV1UserIdRole for application/json ContentType.
+type PutDashboardV1UserIdRoleJSONRequestBody PutDashboardV1UserIdRoleJSONBody
+
+// PutDashboardV1UserIdStatusJSONRequestBody defines body for PutDashboardV1UserIdStatus for application/json ContentType.
+type PutDashboardV1UserIdStatusJSONRequestBody PutDashboardV1UserIdStatusJSONBody
+
+// PostDashboardV1UsersJSONRequestBody defines body for PostDashboardV1Users for application/json ContentType.
+type PostDashboardV1UsersJSONRequestBody = UserInput
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Payment count, volume, success and failure rates per merchant
+	// (GET /dashboard/v1/analytics/merchants)
+	GetDashboardV1AnalyticsMerchants(w http.ResponseWriter, r *http.Request, params GetDashboardV1AnalyticsMerchantsParams)
+	// Payment counts and amounts per status over time
+	// (GET /dashboard/v1/analytics/payments)
+	GetDashboardV1AnalyticsPayments(w http.ResponseWriter, r *http.Request, params GetDashboardV1AnalyticsPaymentsParams)
+	// Revoke an API key, requires api_key:manage
+	// (DELETE /dashboard/v1/api-key/{id})
+	DeleteDashboardV1ApiKeyId(w http.ResponseWriter, r *http.Request, id string)
+	// List of API keys, revoked ones included, requires api_key:manage
+	// (GET /dashboard/v1/api-keys)
+	GetDashboardV1ApiKeys(w http.ResponseWriter, r *http.Request, params GetDashboardV1ApiKeysParams)
+	// Create an API key for scripts, requires api_key:manage
+	// (POST /dashboard/v1/api-keys)
+	PostDashboardV1ApiKeys(w http.ResponseWriter, r *http.Request)
+	// Login with email + password
+	// (POST /dashboard/v1/auth/login)
+	PostDashboardV1AuthLogin(w http.ResponseWriter, r *http.Request)
+	// Complete a two-factor login
+	// (POST /dashboard/v1/auth/login/verify)
+	PostDashboardV1AuthLoginVerify(w http.ResponseWriter, r *http.Request)
+	// Revoke the access token of the request
+	// (POST /dashboard/v1/auth/logout)
+	PostDashboardV1AuthLogout(w http.ResponseWriter, r *http.Request)
+	// Mail a password reset link
+	// (POST /dashboard/v1/auth/password-reset)
+	PostDashboardV1AuthPasswordReset(w http.ResponseWriter, r *http.Request)
+	// Set a new password with a reset token
+	// (POST /dashboard/v1/auth/password-reset/confirm)
+	PostDashboardV1AuthPasswordResetConfirm(w http.ResponseWriter, r *http.Request)
+	// Exchange a refresh token for new tokens
+	// (POST /dashboard/v1/auth/refresh)
+	PostDashboardV1AuthRefresh(w http.ResponseWriter, r *http.Request)
+	// Start two-factor enrollment of the signed in user
+	// (POST /dashboard/v1/auth/totp)
+	PostDashboardV1AuthTotp(w http.ResponseWriter, r *http.Request)
+	// Disable two-factor authentication with a TOTP or recovery code
+	// (POST /dashboard/v1/auth/totp/disable)
+	PostDashboardV1AuthTotpDisable(w http.ResponseWriter, r *http.Request)
+	// Enable two-factor authentication with a code of the pending secret
+	// (POST /dashboard/v1/auth/totp/enable)
+	PostDashboardV1AuthTotpEnable(w http.ResponseWriter, r *http.Request)
+	// Queue an export of the filtered payments, for results too large to export directly
+	// (POST /dashboard/v1/exports)
+	PostDashboardV1Exports(w http.ResponseWriter, r *http.Request)
+	// Get the progress of an export of the current user
+	// (GET /dashboard/v1/exports/{id})
+	GetDashboardV1ExportsId(w http.ResponseWriter, r *http.Request, id string)
+	// Download the file of a completed export
+	// (GET /dashboard/v1/exports/{id}/download)
+	GetDashboardV1ExportsIdDownload(w http.ResponseWriter, r *http.Request, id string)
+	// List the accounts and client IPs locked out of login, requires user:manage
+	// (GET /dashboard/v1/lockouts)
+	GetDashboardV1Lockouts(w http.ResponseWriter, r *http.Request)
+	// Clear the failed logins of an account or client IP, requires user:manage
+	// (DELETE /dashboard/v1/lockouts/{scope}/{key})
+	DeleteDashboardV1LockoutsScopeKey(w http.ResponseWriter, r *http.Request, scope LockoutScope, key string)
+	// Delete a merchant without payments, requires merchant:manage
+	// (DELETE /dashboard/v1/merchant/{id})
+	DeleteDashboardV1MerchantId(w http.ResponseWriter, r *http.Request, id string)
+	// Get a merchant
+	// (GET /dashboard/v1/merchant/{id})
+	GetDashboardV1MerchantId(w http.ResponseWriter, r *http.Request, id string)
+	// Update a merchant, requires merchant:manage
+	// (PUT /dashboard/v1/merchant/{id})
+	PutDashboardV1MerchantId(w http.ResponseWriter, r *http.Request, id string)
+	// List of merchants
+	// (GET /dashboard/v1/merchants)
+	GetDashboardV1Merchants(w http.ResponseWriter, r *http.Request, params GetDashboardV1MerchantsParams)
+	// Create a merchant, requires merchant:manage
+	// (POST /dashboard/v1/merchants)
+	PostDashboardV1Merchants(w http.ResponseWriter, r *http.Request)
+	// Get a payment with its full timeline
+	// (GET /dashboard/v1/payment/{id})
+	GetDashboardV1PaymentId(w http.ResponseWriter, r *http.Request, id string)
+	// Leave a note on a payment
+	// (POST /dashboard/v1/payment/{id}/notes)
+	PostDashboardV1PaymentIdNotes(w http.ResponseWriter, r *http.Request, id string)
+	// Refund part or all of a completed payment, requires payment:refund
+	// (POST /dashboard/v1/payment/{id}/refunds)
+	PostDashboardV1PaymentIdRefunds(w http.ResponseWriter, r *http.Request, id string)
+	// Mark a payment as reviewed, requires payment:review
+	// (PUT /dashboard/v1/payment/{id}/review)
+	PutDashboardV1PaymentIdReview(w http.ResponseWriter, r *http.Request, id string)
+	// Move a payment to a new status, requires payment:update_status
+	// (PUT /dashboard/v1/payment/{id}/status)
+	PutDashboardV1PaymentIdStatus(w http.ResponseWriter, r *http.Request, id string)
+	// List of payments
+	// (GET /dashboard/v1/payments)
+	GetDashboardV1Payments(w http.ResponseWriter, r *http.Request, params GetDashboardV1PaymentsParams)
+	// Create a payment, requires payment:create
+	// (POST /dashboard/v1/payments)
+	PostDashboardV1Payments(w http.ResponseWriter, r *http.Request, params PostDashboardV1PaymentsParams)
+	// Export the filtered payments as a CSV or XLSX file
+	// (GET /dashboard/v1/payments/export)
+	GetDashboardV1PaymentsExport(w http.ResponseWriter, r *http.Request, params GetDashboardV1PaymentsExportParams)
+	// Delete a user without activity, requires user:manage
+	// (DELETE /dashboard/v1/user/{id})
+	DeleteDashboardV1UserId(w http.ResponseWriter, r *http.Request, id string)
+	// Change the role of a user, requires user:manage
+	// (PUT /dashboard/v1/user/{id}/role)
+	PutDashboardV1UserIdRole(w http.ResponseWriter, r *http.Request, id string)
+	// Revoke every access and refresh token of a user, requires user:manage
+	// (DELETE /dashboard/v1/user/{id}/sessions)
+	DeleteDashboardV1UserIdSessions(w http.ResponseWriter, r *http.Request, id string)
+	// Enable or disable a user, requires user:manage
+	// (PUT /dashboard/v1/user/{id}/status)
+	PutDashboardV1UserIdStatus(w http.ResponseWriter, r *http.Request, id string)
+	// Turn off two-factor authentication of a user who lost their device, requires user:manage
+	// (DELETE /dashboard/v1/user/{id}/totp)
+	DeleteDashboardV1UserIdTotp(w http.ResponseWriter, r *http.Request, id string)
+	// List of users, requires user:manage
+	// (GET /dashboard/v1/users)
+	GetDashboardV1Users(w http.ResponseWriter, r *http.Request, params GetDashboardV1UsersParams)
+	// Create a user, requires user:manage
+	// (POST /dashboard/v1/users)
+	PostDashboardV1Users(w http.ResponseWriter, r *http.Request)
+}
+
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+
+type Unimplemented struct{}
+
+// Payment count, volume, success and failure rates per merchant
+// (GET /dashboard/v1/analytics/merchants)
+func (_ Unimplemented) GetDashboardV1AnalyticsMerchants(w http.ResponseWriter, r *http.Request, params GetDashboardV1AnalyticsMerchantsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Payment counts and amounts per status over time
+// (GET /dashboard/v1/analytics/payments)
+func (_ Unimplemented) GetDashboardV1AnalyticsPayments(w http.ResponseWriter, r *http.Request, params GetDashboardV1AnalyticsPaymentsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke an API key, requires api_key:manage
+// (DELETE /dashboard/v1/api-key/{id})
+func (_ Unimplemented) DeleteDashboardV1ApiKeyId(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List of API keys, revoked ones included, requires api_key:manage
+// (GET /dashboard/v1/api-keys)
+func (_ Unimplemented) GetDashboardV1ApiKeys(w http.ResponseWriter, r *http.Request, params GetDashboardV1ApiKeysParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create an API key for scripts, requires api_key:manage
+// (POST /dashboard/v1/api-keys)
+func (_ Unimplemented) PostDashboardV1ApiKeys(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Login with email + password
+// (POST /dashboard/v1/auth/login)
+func (_ Unimplemented) PostDashboardV1AuthLogin(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Complete a two-factor login
+// (POST /dashboard/v1/auth/login/verify)
+func (_ Unimplemented) PostDashboardV1AuthLoginVerify(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke the access token of the request
+// (POST /dashboard/v1/auth/logout)
+func (_ Unimplemented) PostDashboardV1AuthLogout(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Mail a password reset link
+// (POST /dashboard/v1/auth/password-reset)
+func (_ Unimplemented) PostDashboardV1AuthPasswordReset(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set a new password with a reset token
+// (POST /dashboard/v1/auth/password-reset/confirm)
+func (_ Unimplemented) PostDashboardV1AuthPasswordResetConfirm(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Exchange a refresh token for new tokens
+// (POST /dashboard/v1/auth/refresh)
+func (_ Unimplemented) PostDashboardV1AuthRefresh(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Start two-factor enrollment of the signed in user
+// (POST /dashboard/v1/auth/totp)
+func (_ Unimplemented) PostDashboardV1AuthTotp(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Disable two-factor authentication with a TOTP or recovery code
+// (POST /dashboard/v1/auth/totp/disable)
+func (_ Unimplemented) PostDashboardV1AuthTotpDisable(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Enable two-factor authentication with a code of the pending secret
+// (POST /dashboard/v1/auth/totp/enable)
+func (_ Unimplemented) PostDashboardV1AuthTotpEnable(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Queue an export of the filtered payments, for results too large to export directly
+// (POST /dashboard/v1/exports)
+func (_ Unimplemented) PostDashboardV1Exports(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the progress of an export of the current user
+// (GET /dashboard/v1/exports/{id})
+func (_ Unimplemented) GetDashboardV1ExportsId(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Download the file of a completed export
+// (GET /dashboard/v1/exports/{id}/download)
+func (_ Unimplemented) GetDashboardV1ExportsIdDownload(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the accounts and client IPs locked out of login, requires user:manage
+// (GET /dashboard/v1/lockouts)
+func (_ Unimplemented) GetDashboardV1Lockouts(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Clear the failed logins of an account or client IP, requires user:manage
+// (DELETE /dashboard/v1/lockouts/{scope}/{key})
+func (_ Unimplemented) DeleteDashboardV1LockoutsScopeKey(w http.ResponseWriter, r *http.Request, scope LockoutScope, key string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a merchant without payments, requires merchant:manage
+// (DELETE /dashboard/v1/merchant/{id})
+func (_ Unimplemented) DeleteDashboardV1MerchantId(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a merchant
+// (GET /dashboard/v1/merchant/{id})
+func (_ Unimplemented) GetDashboardV1MerchantId(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update a merchant, requires merchant:manage
+// (PUT /dashboard/v1/merchant/{id})
+func (_ Unimplemented) PutDashboardV1MerchantId(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List of merchants
+// (GET /dashboard/v1/merchants)
+func (_ Unimplemented) GetDashboardV1Merchants(w http.ResponseWriter, r *http.Request, params GetDashboardV1MerchantsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a merchant, requires merchant:manage
+// (POST /dashboard/v1/merchants)
+func (_ Unimplemented) PostDashboardV1Merchants(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a payment with its full timeline
+// (GET /dashboard/v1/payment/{id})
+func (_ Unimplemented) GetDashboardV1PaymentId(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Leave a note on a payment
+// (POST /dashboard/v1/payment/{id}/notes)
+func (_ Unimplemented) PostDashboardV1PaymentIdNotes(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Refund part or all of a completed payment, requires payment:refund
+// (POST /dashboard/v1/payment/{id}/refunds)
+func (_ Unimplemented) PostDashboardV1PaymentIdRefunds(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Mark a payment as reviewed, requires payment:review
+// (PUT /dashboard/v1/payment/{id}/review)
+func (_ Unimplemented) PutDashboardV1PaymentIdReview(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Move a payment to a new status, requires payment:update_status
+// (PUT /dashboard/v1/payment/{id}/status)
+func (_ Unimplemented) PutDashboardV1PaymentIdStatus(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List of payments
+// (GET /dashboard/v1/payments)
+func (_ Unimplemented) GetDashboardV1Payments(w http.ResponseWriter, r *http.Request, params GetDashboardV1PaymentsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a payment, requires payment:create
+// (POST /dashboard/v1/payments)
+func (_ Unimplemented) PostDashboardV1Payments(w http.ResponseWriter, r *http.Request, params PostDashboardV1PaymentsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Export the filtered payments as a CSV or XLSX file
+// (GET /dashboard/v1/payments/export)
+func (_ Unimplemented) GetDashboardV1PaymentsExport(w http.ResponseWriter, r *http.Request, params GetDashboardV1PaymentsExportParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a user without activity, requires user:manage
+// (DELETE /dashboard/v1/user/{id})
+func (_ Unimplemented) DeleteDashboardV1UserId(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Change the role of a user, requires user:manage
+// (PUT /dashboard/v1/user/{id}/role)
+func (_ Unimplemented) PutDashboardV1UserIdRole(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke every access and refresh token of a user, requires user:manage
+// (DELETE /dashboard/v1/user/{id}/sessions)
+func (_ Unimplemented) DeleteDashboardV1UserIdSessions(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Enable or disable a user, requires user:manage
+// (PUT /dashboard/v1/user/{id}/status)
+func (_ Unimplemented) PutDashboardV1UserIdStatus(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Turn off two-factor authentication of a user who lost their device, requires user:manage
+// (DELETE /dashboard/v1/user/{id}/totp)
+func (_ Unimplemented) DeleteDashboardV1UserIdTotp(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List of users, requires user:manage
+// (GET /dashboard/v1/users)
+func (_ Unimplemented) GetDashboardV1Users(w http.ResponseWriter, r *http.Request, params GetDashboardV1UsersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a user, requires user:manage
+// (POST /dashboard/v1/users)
+func (_ Unimplemented) PostDashboardV1Users(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// GetDashboardV1AnalyticsMerchants operation middleware
+func (siw *ServerInterfaceWrapper) GetDashboardV1AnalyticsMerchants(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetDashboardV1AnalyticsMerchantsParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "currency" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "currency", r.URL.Query(), &params.Currency)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "currency", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDashboardV1AnalyticsMerchants(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDashboardV1AnalyticsPayments operation middleware
+func (siw *ServerInterfaceWrapper) GetDashboardV1AnalyticsPayments(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetDashboardV1AnalyticsPaymentsParams
+
+	// ------------- Optional query parameter "interval" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "interval", r.URL.Query(), &params.Interval)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "interval", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "timezone" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "timezone", r.URL.Query(), &params.Timezone)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "timezone", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "merchant_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "merchant_id", r.URL.Query(), &params.MerchantId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "merchant_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDashboardV1AnalyticsPayments(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteDashboardV1ApiKeyId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDashboardV1ApiKeyId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteDashboardV1ApiKeyId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDashboardV1ApiKeys operation middleware
+func (siw *ServerInterfaceWrapper) GetDashboardV1ApiKeys(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetDashboardV1ApiKeysParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDashboardV1ApiKeys(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1ApiKeys operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1ApiKeys(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1ApiKeys(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1AuthLogin operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1AuthLogin(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1AuthLogin(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1AuthLoginVerify operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1AuthLoginVerify(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1AuthLoginVerify(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1AuthLogout operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1AuthLogout(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1AuthLogout(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1AuthPasswordReset operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1AuthPasswordReset(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1AuthPasswordReset(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1AuthPasswordResetConfirm operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1AuthPasswordResetConfirm(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1AuthPasswordResetConfirm(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1AuthRefresh operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1AuthRefresh(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1AuthRefresh(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1AuthTotp operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1AuthTotp(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1AuthTotp(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1AuthTotpDisable operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1AuthTotpDisable(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1AuthTotpDisable(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1AuthTotpEnable operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1AuthTotpEnable(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1AuthTotpEnable(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1Exports operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1Exports(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1Exports(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDashboardV1ExportsId operation middleware
+func (siw *ServerInterfaceWrapper) GetDashboardV1ExportsId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDashboardV1ExportsId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDashboardV1ExportsIdDownload operation middleware
+func (siw *ServerInterfaceWrapper) GetDashboardV1ExportsIdDownload(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDashboardV1ExportsIdDownload(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDashboardV1Lockouts operation middleware
+func (siw *ServerInterfaceWrapper) GetDashboardV1Lockouts(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDashboardV1Lockouts(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteDashboardV1LockoutsScopeKey operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDashboardV1LockoutsScopeKey(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "scope" -------------
+	var scope LockoutScope
+
+	err = runtime.BindStyledParameterWithOptions("simple", "scope", chi.URLParam(r, "scope"), &scope, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "scope", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "key" -------------
+	var key string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "key", chi.URLParam(r, "key"), &key, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteDashboardV1LockoutsScopeKey(w, r, scope, key)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteDashboardV1MerchantId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDashboardV1MerchantId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteDashboardV1MerchantId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDashboardV1MerchantId operation middleware
+func (siw *ServerInterfaceWrapper) GetDashboardV1MerchantId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDashboardV1MerchantId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutDashboardV1MerchantId operation middleware
+func (siw *ServerInterfaceWrapper) PutDashboardV1MerchantId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutDashboardV1MerchantId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDashboardV1Merchants operation middleware
+func (siw *ServerInterfaceWrapper) GetDashboardV1Merchants(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetDashboardV1MerchantsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDashboardV1Merchants(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1Merchants operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1Merchants(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1Merchants(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDashboardV1PaymentId operation middleware
+func (siw *ServerInterfaceWrapper) GetDashboardV1PaymentId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDashboardV1PaymentId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1PaymentIdNotes operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1PaymentIdNotes(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1PaymentIdNotes(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1PaymentIdRefunds operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1PaymentIdRefunds(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1PaymentIdRefunds(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutDashboardV1PaymentIdReview operation middleware
+func (siw *ServerInterfaceWrapper) PutDashboardV1PaymentIdReview(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutDashboardV1PaymentIdReview(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutDashboardV1PaymentIdStatus operation middleware
+func (siw *ServerInterfaceWrapper) PutDashboardV1PaymentIdStatus(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutDashboardV1PaymentIdStatus(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDashboardV1Payments operation middleware
+func (siw *ServerInterfaceWrapper) GetDashboardV1Payments(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetDashboardV1PaymentsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "id", r.URL.Query(), &params.Id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "merchant_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "merchant_id", r.URL.Query(), &params.MerchantId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "merchant_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "reviewed" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "reviewed", r.URL.Query(), &params.Reviewed)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reviewed", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_from", r.URL.Query(), &params.CreatedFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_to", r.URL.Query(), &params.CreatedTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "currency" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "currency", r.URL.Query(), &params.Currency)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "currency", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "amount_min" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "amount_min", r.URL.Query(), &params.AmountMin)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "amount_min", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "amount_max" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "amount_max", r.URL.Query(), &params.AmountMax)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "amount_max", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "summary_scope" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "summary_scope", r.URL.Query(), &params.SummaryScope)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "summary_scope", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDashboardV1Payments(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1Payments operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1Payments(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostDashboardV1PaymentsParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1Payments(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDashboardV1PaymentsExport operation middleware
+func (siw *ServerInterfaceWrapper) GetDashboardV1PaymentsExport(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetDashboardV1PaymentsExportParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "id", r.URL.Query(), &params.Id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "merchant_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "merchant_id", r.URL.Query(), &params.MerchantId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "merchant_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "reviewed" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "reviewed", r.URL.Query(), &params.Reviewed)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reviewed", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_from", r.URL.Query(), &params.CreatedFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_to", r.URL.Query(), &params.CreatedTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "currency" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "currency", r.URL.Query(), &params.Currency)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "currency", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "amount_min" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "amount_min", r.URL.Query(), &params.AmountMin)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "amount_min", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "amount_max" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "amount_max", r.URL.Query(), &params.AmountMax)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "amount_max", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDashboardV1PaymentsExport(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteDashboardV1UserId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDashboardV1UserId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
 
-// PaymentSummary defines model for PaymentSummary.
-type PaymentSummary struct {
-	// Completed Total number of completed payment
-	Completed *int `json:"completed,omitempty"`
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	// Failed Total number of failed payment
-	Failed *int `json:"failed,omitempty"`
+	r = r.WithContext(ctx)
 
-	// Pending Total number of pending payment
-	Pending *int `json:"pending,omitempty"`
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteDashboardV1UserId(w, r, id)
+	}))
 
-	// Total Total all payment
-	Total *int `json:"total,omitempty"`
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// User defines model for User.
-type User struct {
-	Email *string `json:"email,omitempty"`
-	Role  *string `json:"role,omitempty"`
-	Token *string `json:"token,omitempty"`
+	handler.ServeHTTP(w, r)
 }
 
-// Limit defines model for limit.
-type Limit = int
+// PutDashboardV1UserIdRole operation middleware
+func (siw *ServerInterfaceWrapper) PutDashboardV1UserIdRole(w http.ResponseWriter, r *http.Request) {
 
-// Offset defines model for offset.
-type Offset = int
+	var err error
 
-// Sort defines model for sort.
-type Sort = string
+	// ------------- Path parameter "id" -------------
+	var id string
 
-// ForbiddenError defines model for ForbiddenError.
-type ForbiddenError = Error
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
-// LoginResponse defines model for LoginResponse.
-type LoginResponse = User
+	ctx := r.Context()
 
-// NotFoundError defines model for NotFoundError.
-type NotFoundError = Error
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-// PaymentListResponse defines model for PaymentListResponse.
-type PaymentListResponse struct {
-	Meta     *PaginationMeta `json:"meta,omitempty"`
-	Payments *[]Payment      `json:"payments,omitempty"`
-	Summary  *PaymentSummary `json:"summary,omitempty"`
-}
+	r = r.WithContext(ctx)
 
-// PaymentReviewResponse defines model for PaymentReviewResponse.
-type PaymentReviewResponse struct {
-	Message *string `json:"message,omitempty"`
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutDashboardV1UserIdRole(w, r, id)
+	}))
 
-// UnauthorizedError defines model for UnauthorizedError.
-type UnauthorizedError = Error
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// PostDashboardV1AuthLoginJSONBody defines parameters for PostDashboardV1AuthLogin.
-type PostDashboardV1AuthLoginJSONBody struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	handler.ServeHTTP(w, r)
 }
 
-// GetDashboardV1PaymentsParams defines parameters for GetDashboardV1Payments.
-type GetDashboardV1PaymentsParams struct {
-	// Limit Limit number of items to return (max 100)
-	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+// DeleteDashboardV1UserIdSessions operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDashboardV1UserIdSessions(w http.ResponseWriter, r *http.Request) {
 
-	// Offset Offset from start (0-based)
-	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+	var err error
 
-	// Sort Comma-separated sort fields. Common patterns: `-created_at` (prefix `-` = desc) `amount` (no prefix `-` = asc)
-	Sort *Sort `form:"sort,omitempty" json:"sort,omitempty"`
+	// ------------- Path parameter "id" -------------
+	var id string
 
-	// Status status of payment (completed , processing , or failed)
-	Status *string `form:"status,omitempty" json:"status,omitempty"`
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
-	// Id payment id
-	Id *string `form:"id,omitempty" json:"id,omitempty"`
-}
+	ctx := r.Context()
 
-// PostDashboardV1AuthLoginJSONRequestBody defines body for PostDashboardV1AuthLogin for application/json ContentType.
-type PostDashboardV1AuthLoginJSONRequestBody PostDashboardV1AuthLoginJSONBody
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Login with email + password
-	// (POST /dashboard/v1/auth/login)
-	PostDashboardV1AuthLogin(w http.ResponseWriter, r *http.Request)
-	// Allows marking a payment as reviewed only by operation role
-	// (PUT /dashboard/v1/payment/{id}/review)
-	PutDashboardV1PaymentIdReview(w http.ResponseWriter, r *http.Request, id string)
-	// List of payments
-	// (GET /dashboard/v1/payments)
-	GetDashboardV1Payments(w http.ResponseWriter, r *http.Request, params GetDashboardV1PaymentsParams)
-}
+	r = r.WithContext(ctx)
 
-// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteDashboardV1UserIdSessions(w, r, id)
+	}))
 
-type Unimplemented struct{}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// Login with email + password
-// (POST /dashboard/v1/auth/login)
-func (_ Unimplemented) PostDashboardV1AuthLogin(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
+	handler.ServeHTTP(w, r)
 }
 
-// Allows marking a payment as reviewed only by operation role
-// (PUT /dashboard/v1/payment/{id}/review)
-func (_ Unimplemented) PutDashboardV1PaymentIdReview(w http.ResponseWriter, r *http.Request, id string) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+// PutDashboardV1UserIdStatus operation middleware
+func (siw *ServerInterfaceWrapper) PutDashboardV1UserIdStatus(w http.ResponseWriter, r *http.Request) {
 
-// List of payments
-// (GET /dashboard/v1/payments)
-func (_ Unimplemented) GetDashboardV1Payments(w http.ResponseWriter, r *http.Request, params GetDashboardV1PaymentsParams) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	var err error
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
-}
+	// ------------- Path parameter "id" -------------
+	var id string
 
-type MiddlewareFunc func(http.Handler) http.Handler
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
-// PostDashboardV1AuthLogin operation middleware
-func (siw *ServerInterfaceWrapper) PostDashboardV1AuthLogin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostDashboardV1AuthLogin(w, r)
+		siw.Handler.PutDashboardV1UserIdStatus(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -191,8 +2660,8 @@ func (siw *ServerInterfaceWrapper) PostDashboardV1AuthLogin(w http.ResponseWrite
 	handler.ServeHTTP(w, r)
 }
 
-// PutDashboardV1PaymentIdReview operation middleware
-func (siw *ServerInterfaceWrapper) PutDashboardV1PaymentIdReview(w http.ResponseWriter, r *http.Request) {
+// DeleteDashboardV1UserIdTotp operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDashboardV1UserIdTotp(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -212,7 +2681,7 @@ func (siw *ServerInterfaceWrapper) PutDashboardV1PaymentIdReview(w http.Response
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PutDashboardV1PaymentIdReview(w, r, id)
+		siw.Handler.DeleteDashboardV1UserIdTotp(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -222,8 +2691,8 @@ func (siw *ServerInterfaceWrapper) PutDashboardV1PaymentIdReview(w http.Response
 	handler.ServeHTTP(w, r)
 }
 
-// GetDashboardV1Payments operation middleware
-func (siw *ServerInterfaceWrapper) GetDashboardV1Payments(w http.ResponseWriter, r *http.Request) {
+// GetDashboardV1Users operation middleware
+func (siw *ServerInterfaceWrapper) GetDashboardV1Users(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -234,7 +2703,7 @@ func (siw *ServerInterfaceWrapper) GetDashboardV1Payments(w http.ResponseWriter,
 	r = r.WithContext(ctx)
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetDashboardV1PaymentsParams
+	var params GetDashboardV1UsersParams
 
 	// ------------- Optional query parameter "limit" -------------
 
@@ -252,11 +2721,11 @@ func (siw *ServerInterfaceWrapper) GetDashboardV1Payments(w http.ResponseWriter,
 		return
 	}
 
-	// ------------- Optional query parameter "sort" -------------
+	// ------------- Optional query parameter "role" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	err = runtime.BindQueryParameter("form", true, false, "role", r.URL.Query(), &params.Role)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role", Err: err})
 		return
 	}
 
@@ -268,16 +2737,28 @@ func (siw *ServerInterfaceWrapper) GetDashboardV1Payments(w http.ResponseWriter,
 		return
 	}
 
-	// ------------- Optional query parameter "id" -------------
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDashboardV1Users(w, r, params)
+	}))
 
-	err = runtime.BindQueryParameter("form", true, false, "id", r.URL.Query(), &params.Id)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1Users operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1Users(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetDashboardV1Payments(w, r, params)
+		siw.Handler.PostDashboardV1Users(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -400,15 +2881,123 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/dashboard/v1/analytics/merchants", wrapper.GetDashboardV1AnalyticsMerchants)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/dashboard/v1/analytics/payments", wrapper.GetDashboardV1AnalyticsPayments)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/dashboard/v1/api-key/{id}", wrapper.DeleteDashboardV1ApiKeyId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/dashboard/v1/api-keys", wrapper.GetDashboardV1ApiKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/api-keys", wrapper.PostDashboardV1ApiKeys)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/dashboard/v1/auth/login", wrapper.PostDashboardV1AuthLogin)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/auth/login/verify", wrapper.PostDashboardV1AuthLoginVerify)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/auth/logout", wrapper.PostDashboardV1AuthLogout)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/auth/password-reset", wrapper.PostDashboardV1AuthPasswordReset)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/auth/password-reset/confirm", wrapper.PostDashboardV1AuthPasswordResetConfirm)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/auth/refresh", wrapper.PostDashboardV1AuthRefresh)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/auth/totp", wrapper.PostDashboardV1AuthTotp)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/auth/totp/disable", wrapper.PostDashboardV1AuthTotpDisable)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/auth/totp/enable", wrapper.PostDashboardV1AuthTotpEnable)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/exports", wrapper.PostDashboardV1Exports)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/dashboard/v1/exports/{id}", wrapper.GetDashboardV1ExportsId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/dashboard/v1/exports/{id}/download", wrapper.GetDashboardV1ExportsIdDownload)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/dashboard/v1/lockouts", wrapper.GetDashboardV1Lockouts)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/dashboard/v1/lockouts/{scope}/{key}", wrapper.DeleteDashboardV1LockoutsScopeKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/dashboard/v1/merchant/{id}", wrapper.DeleteDashboardV1MerchantId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/dashboard/v1/merchant/{id}", wrapper.GetDashboardV1MerchantId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/dashboard/v1/merchant/{id}", wrapper.PutDashboardV1MerchantId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/dashboard/v1/merchants", wrapper.GetDashboardV1Merchants)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/merchants", wrapper.PostDashboardV1Merchants)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/dashboard/v1/payment/{id}", wrapper.GetDashboardV1PaymentId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/payment/{id}/notes", wrapper.PostDashboardV1PaymentIdNotes)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/payment/{id}/refunds", wrapper.PostDashboardV1PaymentIdRefunds)
+	})
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/dashboard/v1/payment/{id}/review", wrapper.PutDashboardV1PaymentIdReview)
 	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/dashboard/v1/payment/{id}/status", wrapper.PutDashboardV1PaymentIdStatus)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/dashboard/v1/payments", wrapper.GetDashboardV1Payments)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/payments", wrapper.PostDashboardV1Payments)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/dashboard/v1/payments/export", wrapper.GetDashboardV1PaymentsExport)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/dashboard/v1/user/{id}", wrapper.DeleteDashboardV1UserId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/dashboard/v1/user/{id}/role", wrapper.PutDashboardV1UserIdRole)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/dashboard/v1/user/{id}/sessions", wrapper.DeleteDashboardV1UserIdSessions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/dashboard/v1/user/{id}/status", wrapper.PutDashboardV1UserIdStatus)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/dashboard/v1/user/{id}/totp", wrapper.DeleteDashboardV1UserIdTotp)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/dashboard/v1/users", wrapper.GetDashboardV1Users)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/users", wrapper.PostDashboardV1Users)
+	})
 
 	return r
 }
@@ -416,30 +3005,155 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/7RYW2/bOBb+KwfcfUixSiQ3eSgELLDZmWnRQdIpmnbmoRNMaPHYZiuSKkklcQP/98Eh",
-	"ZUuymdrTy5tFnut3rvQDq4xqjEbtHSsfWMMtV+jRhq9aKunph0BXWdl4aTQr2QUdg27VFC2YGUiPyoE3",
-	"YNG3VsOR4vcwKYonLGOSGD61aJcsY5orZGUnNmOuWqDiUf6Mt7Vn5dMiY4rfS9UqVk4K+pK6+8qYXzbE",
-	"L7XHOVq2WmXMzGYOEzb+Fs5hZo0C57n1cFQcT7lD8ZhVnaSkWUM7iqQdztiEFT8ZpfixQ4LVowCigpnE",
-	"WrgToEujoeHeo9WuhJvjyiLR/cX9DRw1FmfyHm6Ob+C/QHKfwA1XptV0qQ2M7rmrnvypH3EtGDd0DO+5",
-	"amq6GqhkG8ect1LP2Yocs+gaox2GhHhu7FQKgfoXa42lk8pojzq4zpumlhUn1/MPjvzfKArMszUzfdzy",
-	"usUoQCArz4rTjCl0js/JgI2eEpamBWFAGw8LfovQoFXSOWk0ZRyvKnQO/EI6sOhMayuMdvfO/tvijJXs",
-	"X3mf6nm8dXn0IzCMI/fOoQXpgLd+gdqTXyhg2vpgCZ0aKz+jYKuMXZi51G86nA4A5TDTyIKUZV2RefMR",
-	"NXAtoA2m6pmxKughk14Z/9y0WvzgOL0yHmakp9ygH/AJZ98lDm8SYjP2mi8Van8hnf8q3BtrGrReRocV",
-	"+r0GvuZzqYO4S6JeZayJNgQJoQPuFxEYiLerM24tX9K3a5XidnmghKuOetVLMtMPWPkUgB0PEFYD6N7g",
-	"rcS77wJelwzDrnLVxrq85PYjcAdRG4pEi/kHLtggBdYaVxl7p/tK/JpUb/WovoOf61CwS2ozeg6GquuW",
-	"11LEomPZblVMhlXxbiy1BPWYpO9RIee9LmqKMy5rFKRqrbWyKIiA1471+oL/G8zGMY1eDQIaHNyeelk6",
-	"9od7n0oGi59aaSkU76MZvZbrnVShbB6V5Y4nX9xgjIWGzxGc/IzURSk9N35MipTHe9aNw4R443m9K+Mt",
-	"HW+vVWNp6d0jgUrsNDtwxPVhHC9eywr/132fVEbtxiVjgy0hTghFv5jgHo+9VJjikWKsaJIiUmirBd+2",
-	"6bI7hfMUj/Pct27MQRVTI83oDBprqPtQzmUU5FgSh/SeDXRXfUPeLo1Oz/749SZ1o2IYy6fJzOgs3Su6",
-	"K/KE3HTGNagFubxXcEd4uOQv5jKv65SkswPTOGxAOxFAxWXQuJMX1tSYvIjtZvcmMXwy5rBqrfTLK2qT",
-	"UeUUuUVLjbb/er6ugV//eLverUlSvO2TbeF9E9s2rWjBCOljki9fmAuu5+dNA+evX9JYQesifpOT4qQI",
-	"DadBzRvJSnZ6UpycMto7/CJYlQvuFlPDrchvJzk13bymTTRAZlwoKQIutMeXggapcf7nNdPvE3Io7K4s",
-	"9l10/v9GLL9hGXg8Ng137s5YkY7CsOtHGQOO6+SK0LN42+L2Q+VpUTw2UDd0+XhvX2XsrJjs59rdOVbD",
-	"DS6+BuBO+gUEV+A/sHGFKMdh68ojf5BilccFJ8DapsLXDqPXdaqXIi5XAbH+8f7+Ib4EKVv6h6AUbBu5",
-	"4f6xHZjrr0E1vWB+A7rEebqfc+ttGtjO9rONX0rDBhBAHJb++2tCpI/0eV2bOweK24/UM/m619HGa7uN",
-	"F4yulzBdwiaUENrUo5kQgJ5jIvwvMBF+txv3lL89SR5XolW2l7DbdQ6gDP8vEN14CMQ5HcZKB8zR/jH9",
-	"2N8z3dD/UrruGLBWG9I+JTRc/Jj8H71Nv7G3HJqQpHMAt+veF2hv17nR2rqbSWWe16bi9cI4Xz4rnhVs",
-	"db36OwAA//90u1IKDxQAAA==",
+	"H4sIAAAAAAAC/+x9DXMbt7XoX8Hw9c0k0xVFSXbb6E3mXsVyEiV2oivJTdvYT4Z2QRLRLsAAWElsqv9+",
+	"5+AAu1gSS+5SlGy36XQmFhff5xPnC78NUlnMpGDC6MHhb4MZVbRghin7V84LbuAfGdOp4jPDpRgcDl7B",
+	"z0SUxRVTRI4JN6zQxEiimCmVIJ8V9I7sjUafD5IBhw6/lkzNB8lA0IINDt2wyUCnU1ZQHH9My9wMDvdH",
+	"yaCgd7woi8Hh3gj+4sL9lQzMfAb9uTBswtTg/j4ZyPFYs8gaf7S/k7GSBdGGKkM+G+1cUc2ytlW5kaLL",
+	"Ctcxiq5jRucFE+aokKUwr+nd8oqkyOfENdPklpspoYYUUhtiplwTarsSLkjBhVSkFNzohBSlNkRIQ64Y",
+	"yZnWxEypcI0vCy5aduMb0LvGjsZSFdTg2v/0bNBzW1x03VbO6Mp9AdqkpVJMpHPymWYs2JFUn6/ZFBfb",
+	"2NQLxahh2ddKFuu2lWJT2JlUhI4NU7i5s69fkIODgy+I4QVrWbXrfAm42Fg3u6PFLIcm+6P95zujvZ3R",
+	"3sVodGj//8fRnw9Ho0FS7y6jhu24edyutFFcTCKbupBdt3TFxlKxyG4c6l0xt9+FbazaqZHr9rn/4H06",
+	"5Fm3TS5wax7ZEqLYryVXsPN5gHSEiow0iCa6QT9rfHtvzo9XrfkkW16t+0R41jIlzxqTtQ7+mql0SuOT",
+	"FO5b+yy+xWXX6c7YDWe3LGs5f+U+k8+MKtnnxNJ9/eOY5pp9XkGpZVG+fWxFV1LmjIpwSeeMqnS6vCD8",
+	"ndQnrS2wq0OB2XRC2A1Tc3IrVUYKatIp04RqQslMsTG/I5+x4WRI3ht5TXRZvCdjLjJN3g4u5LUk5ygL",
+	"z9gv7Jq/HXw+JOdSGQ0opljObqhIGSmF5d/vtVTmPeGaTPgNE8O3bSz818a+C3r3iomJmTq52AqZc0NN",
+	"qZePQdvfgfG6hi3TYrvG3H9QbDw4HPyf3VpZ2MWveve0MSusA/a3PP0LWRR0RzPQMIDvQCsy5izPdELo",
+	"bJZzlgG1SpUxNSSneOoUm6Bceb/zHrQM2xMGZyLjYpIQhAyuO9lBIk48L6Lm/ZAc5bkExMP5DgnPkgr+",
+	"CXE9HfknpO4KiJIQIyfMTJlyq/j1fVJDdUguOGCKYuRKyWsmAOYwPBWkFNdC3gq3BVSONHk2GrXD3J5d",
+	"nLns1MuKMJn7ZKCYnkmhmYX90enJ92zupMGZ+wIfUikMExZA9tRTCgDa/UVLK9zrmWdKzpgyHMejM355",
+	"zebrsAGnHdwnA9e4Xn82u748GH9B99LR1Z+zfXb597uD6+FwGOWY7hd59QtLDW5vAZ2c+Do6PSHXbI6g",
+	"MVNGxmWewy8JuZ3ydAp0pqcABcuXpEgZrA7X+Yprs72zsf+2unD3U3L7pEpR+3fBTAeKm3BhV/YaWnc6",
+	"Ljim79mcwI5hnq9odsZ+LZk2L5WSqtfmV60NR4sswM0G4ODihuY8g2W8kGKc8/SpF5G6aXWNNijZjeUF",
+	"DJgk/KiYlqVCjHl5N5PKfCevtoAwzI61dht+xm4gdoyY4ODkF+iXDL6W6opnGRNdj9gRrHaKNXaGP25o",
+	"XrpNZ2xw+Gx0AOiqNZ3Auqp5DslcliST9s4ypTeMzJgquNZcCuDeNE3xFsN1cL5WbjwMuG80U4BetDRT",
+	"JgzsC5S8Em9P8KtU/J/M4t0rmV7L0myJ/nMcrTv9v5ITLtwalrlAF2C7zhU92xE32sqqdcKRxmZ393xj",
+	"5R3oUqU9e4EqPLS5TwZeId3SIXtx3f2U/QKeks/6OSvA+B/OGFDlBz0HXMJTnoZnSXQyUWxCDdPADGrN",
+	"G1CnulI1Dmtrx9QdSfqAFxb7gzRfy1Jkj8xYf5CGjGGew4pdEuF/2wrjPIsMm3jYHQmazw1P9RZgclWm",
+	"16wH4rolfGW7xfCWC8PUDc3X6lt+Eye+AwzGC/ZPKVhTSz3SnO5+R6+pMnQzzdQj/Y3My4IR3DSaG9Cs",
+	"IvOMaeM+kDFXyClOQwPOFk7b3/K6HXI/rduNncC9HhQlqThwh9x/ILdTKxkIz1gxk7B8a3dxGqBis5zO",
+	"7cV+ymjmrM0nVdudM98gcpk3oEUYVTKcxOlp9rSs+Hejgw5HBWFU5ZypavZK4dO0YKSaMp3vgEa+0tBw",
+	"XwPpmBnK8w8AJETbnAvWl4pe3jCxobLhMdqeHTcAv3EpnBnFr6fCawYTLaH11rSA/pKqsoz05jwxnqPL",
+	"oqBq3tUs4lr3OmevO7i/f5Bb4QdCGtZx1TDjRgyB2EnqpZ9ZRPkgdII4uq4DLrDfXnFkRP4pI7OQOmie",
+	"hxTSOAowZm6FBJxqEIqt8xKvVa+pugaz5VltOl0QYYkzq3Y8ShyoH/7iBMQvtD4DNBI+9hm8mWXUMHLu",
+	"7ZhLB/AYkrFSdZ1viDmrIgF9EQ/hQsrXVMydEUJvojfCTZNlP5YmojcOjJSXBRXzSyfrYO+ZFVS2r2JG",
+	"zS/t+gaHB6P7UMk0UhLoSsaU5ywjOVwndUKMmhM6oVyQnBp/F3ygunnRNteUgXHAOgulImnO4TxPTkGo",
+	"474J3HjH4IAD217OmurDGexv5wj3t6w2pBIkVikMz+1U7tJOGJBpRO7XDkPYwhtRGxA2AVwpGmYJ+KkS",
+	"JIPXXGsuJrBrZxvDq/UgWb4b7IVge9Mc9ZAUbSNtA3BH9Vxgy3Hgk6qaNVUsgwY0t4wPrAdHCNAtkHyp",
+	"mVq39GDGblSLBg5c6gfVUGB33dWTxj430ehgAKdmVHgRuA2WdxX4HkKH+wpXbVL1uVpwBBzEGrO7GVdM",
+	"95qAZ82B92KNcqrNZal7rj30hy4xk4spQ2+Cb0Vup1Kz2ukM/AWcEpoxcCtdaSY84zKMFvAtNin6gMId",
+	"KWBbKc+5RZZYH/ROxtdol2Co8nZu8MC8LUejgxR72X+zy8ReqBi6TTShM2p9UAHAAq9Ni0Ihr3sesE7l",
+	"zBvD3Tw/e8F8qBjNBu+SmhiWuq9Dee/hORGz0iyjcxPbFiKHCu6BBQdoptSQTDJtzSPYcZBsA40wjMrj",
+	"ipGhNmn9tFKwwEspC74lJFp0JRdcVH+vBNWCylPZ9bV3mFi/G6PplExZjiYP8K0oRo1UQ2smPiyooBOG",
+	"8RboOPM/pVS4QKeJosKwDP2kGyFIwcUJftyLYIuPAYEx7YFVu3wXQ6Ul8xGcrygL6D6VpRokg4wCNG4Z",
+	"u4YDlsJMg6HqZfmwFQyoirgSq98DtvZ8NBo+H8WAHQZNNTpBn+ejZDk0alG7SeqYlk6hLEuHU2lDCwLD",
+	"6ivBiFZ1WZ49qsl312tiSwyBa5dRzxKDbu1mi2wCFrSp3OvTJ5O3Ipc0uyxVHjd6SZEyS03Ov8c1qZbX",
+	"4Na7GdXTK0lVtnuzt4ut9e7erp8iNj3zQGzO+9N0TqhX9Ny8+Nd6Eb44kjPVjXlu3au0Xn2wI8UKeWNH",
+	"73ZsvlVNjqm+GSSDu1zfRemvk8owU3KimI6zvJQJA8wKOJ40NL9U8laTW8WNYSKBsFcEVRQ4z0YxGoAR",
+	"Lt0IsRtI4mNygn3OMPhlkAxUKQT+K5yyAhNCJYueRr2ByFa9ILLBUEB8DnwGAmE8OB3obmkVUDiIhly2",
+	"U10loaPTk5xr42ZFe6ON/jHSzZwQzVjDDGM7BGHMSZTD2hi/vqGjIb/doHMjgLI3NzGyR5+Apa+gmSq8",
+	"2VFNLxpap+ssff+1QxRbUsf8RSzwdWhZx6iokHD6xbAt4atzuJ+DlrCMrV+HpgwbDGYvZ2AYZcpbNRJQ",
+	"j9ARxArK88Ti84wFto5hAAXXa5AM+AzgUO+5/rK030ZkwZI8A6ZQKqbXbUBzL2zsRJa84R5FFMMQ+Wot",
+	"f4oh+lLoV6r/2zBthqmNHI5f0dzaeklNNAtdWqNOz6vH+hCNAN5RjHgdeJgX1QZhaGouLZSbJzHmgoqU",
+	"/bcnlh06hJPZmhrBNfi+LpcvAX615GjzmzSb0Dwy9OkFqUcnJyKTgmlOo0fPjMkZ0Npl2hrAfXL+I3m2",
+	"v/fnOkEAtDiLjnUgsyY4FsSMNtSfk+OzzRmB38cqTlAFWsevltsBfkdABux0//nztTe5/hDsOUELfBeh",
+	"M6PGMAWw/v8/H+38491vB/d/2CrQwitAsOmFc42vN1kA4bsVOOBCapavcTdM0Qm7rK9zC6ZU/F4pLT7y",
+	"WEH0BcsI6OreIlBnzTTwfG8E/4teChuTxy6Htmu3y2F8/T9UuV9BCP8aLXcVQiwrKai+hk3/EhvUSw1F",
+	"TUQsn09BFAerRGsODp5gctgIznkvXP5ouB/eP2R5lQfMFtPeKrHlRnZcejnT5WDvYs9luvyjt9moOeRy",
+	"pkEHk9Manq7BZ8YylnWBMZ6eAvsOy+oLTkK4SPMy83cEKZh3gLIMdYnweA+i2KHRedcPkNXqV8Dyz887",
+	"AROjdSIzl0XBfGaQvbjmeRTpB89GrRSJg0co0fXpQIqrJFFbxseJoKnhN7XY1N7EpljK4INgt9Vmmron",
+	"9LM5Cu6fCwqo+7y00wXPxnKo7KqMUqnIDLii5v9kBEz24fnuRdFGsDvLunXMfPHC/u5tk9DUjl9Z5CXe",
+	"Ya1mCx9iG1qTX9ptlTPFbjquEppyWeroSm1QTetS7W0+4gmAnxezdZtrHnXDt9Pab95mtWxOfcxSXtDc",
+	"Uw9GSGj0/mb4zSZZ1QH46dxe7AVs+rOT4zMySsib82Oyn5Cvvj0mB583ZeDzrobRBfFbpaQ2BawHQqAL",
+	"hBbVrgbVTXxlPbXhQbLOVttRqQ9FzQLwUFWy6XH+YKrWSeerRT955MVGq+Z0XhaeC/soNE85PiYRTxwu",
+	"3zm/9onFjRU/Hw33n7dPH2GlZy1zWTcgij9MXwMzdV41IHVOVtLNtesDkpYDzzYK22kaVJZssjarLdgQ",
+	"mVJNrhgTdQJnlcwtUY5HjDLbsrE0430jN6uOumiV2+wIHCNtG8S8HyNcJiKn9FJkHuo4jh/VRdmwjPhI",
+	"4oSwuzQvNcrG7WU92yOO5VSew8/dV8fFytXtPWB1ptQxs9ILy2et2TbUoywdYb4t9kV1DpCvmbgdwK5P",
+	"3CYiWRV72cVd3IiSXZb2ghEmjMKo4opafOzrkPwIfABFNMsz7fNDjb/EYVAszGqtg5qZQ6uyXrr9wxEZ",
+	"6f8CLy/+8xLDx7IEYp9SWTD7zROnT1m1P3qkh6E8Gx3agEwylbCmpaA0ohjVUiRuPNs2cX3dN9DJ7BCG",
+	"3Rl0xy6I/tRIFTN2QCOrCK40/WH3RdGw3y7S1yoZDm8qadnk2N3EQOvN+flo/3knHaCP7A/woCcT9TK+",
+	"eSA8q/MXU0hnrxEQMEgTV8FhjSj28cJLHxwmht4oOpsp77jL6WTS6m/adJ84VD1h7Wxq0skgCasGeDIY",
+	"4G4uaZZFV7aCIfzgTmEB620wYAzt15i6XcdOutAmemSngT1k62aVXRUyY7kqGCKNzcUf9Dmus0pJWbjg",
+	"UTgVz2gQL1nmwllq2mye8oabSafMRova60agsW4RkT2O9YKN6/QAblmN0IFhrgBSm72g9rmOWTpPcy8u",
+	"6voJRlGhOTTXh8S5oMkOxpGRmZIpwziNf1U2tuBH3652/NfN6t+q0aiCSNJ8fumpmPyrkmtJ7LPvWQu/",
+	"i6n/i3gdxYpfV6IE7VhXcxSdsERsrFHQLbva6820eduXlxWyoYYRJewfsaNEFJmWe7deY7XCpMvgTttJ",
+	"lVqIVIpcSNKlUKX9uGlvy/eD1uOoT3StEaTGt4hiEN9GbZJePTS2i43bYhxy6LV2YE9vnUfWcWd1eO9z",
+	"QegklTc2wCIwboLqJ71aa226kUiQ0GaY50AJ9ucWtvkYF4XtXg3WWNGC81nn8Igh8Bmz5zx/ITOml/FX",
+	"uc+Xqf++QNVoWyg1szYgbWspzPCiqw2jGd5OLn68OLUNkqXKJ8FZBdbcqzRj48l0h/9ynRdCzqLGxVUh",
+	"lAsLfxfduk/H2sh0+BhafT+boJsqahvsfC3YZhj9/sb6H17tmg3TUhtZMOXKFDkFcCJlprvpFoB1L4WS",
+	"eR63EYNexTWXEKt2WSq+DAFpZqAekzdnJ1hMUmRMYQ2u/zlbtnq65oe7u0aamafv/7s/Ovbxj4eBMv5f",
+	"NJ9Ixc20+PL826M9UBX2/5TxCTf6yz/hX1zrkqkv3UB/rIbBrzOmuMy+PBjhn5qlipkvv/vq/Ke/Hxyf",
+	"vvz29PuD07+dLv4dD4KArsv7/4pqdrBP8DOqxnUULPw1mzn+57w4OqXCH03TF9V/WQsU7daYLIMtRtxv",
+	"XG7Ogjie0jxnYsIuN0nuqHtjpG/sJlop0pvFovpSNDC+i+zXnY1edVWbNaHxLpbdW4KUzCtzus1pivLk",
+	"RqD7WnZsVUzF9HSjk/Z9q3NulTr+pNxl2+IouBDt7xpMxc3gY8DfXTd8dGaZx00MbUuZSmV2cn7Dsgbw",
+	"oid0Ky/HaF6qETsWWF3FsgI0rGphbuUOdg0pkIOlTMhqs4rVvNIWMmkiLOE6OCl0fWEgU/yYbOjd7g1T",
+	"fDx/KyKG9vsWwvOJYFvJ2epv0egmcRygw1E3D/iBXa+6J8D3tsSf5f3RCRNm5RZnVGuoCwn9ggCov6zY",
+	"6IJugVTvOUHgUmlUx+LCtrusf2py9VSv5du4v2DJbkltXLvNFHDMNb2CawwQRRU2kMsJcaVSzZRx1aQF",
+	"mwQWjSLI3GjdoghQRJaKm/k5QLwqsvc9m0MO6vJqWxLb8CcUZfiTzbQKivku0OGM70AKnDPnV9q1S2Oq",
+	"bDTaBpnDRYibpK4oMmvmQ3HviJKK2LAHlAHwM+YaBVUgMY25LgP5t52j0xNXkcSzert/gNsVo4opfxL4",
+	"19eeur/76cJnM1vuYb/Wo0yNmWFCKFTrgv6GG/Tizr+Rr6iYHM1mUFRxkAzgRojnuzccDUcwtZwxQWcc",
+	"cgKHo+GBRTQztfBZOEqfMrXbqFc1iek9R3V1qEYG3IIXT1mXRWv9qKS6MnJBMj4eMxWo675Gp4DLpL9j",
+	"+rqeWK0VAe0CyoiihiVkyidTpl01k2RlBdcKO6AA7+AbZir98a97VQLZ6+owkkap9Z83LD+9our0o1ab",
+	"7l9aesVK11SL3n+0dS7Wht6s6HM0gvE+6VX/dta52u1yLdsgwCIsalvThUs+wCC0hISRdgkJAygT0gwd",
+	"tfS1EOUYrXcbLKFBk5vVucWFxs80phbUhLRr+eygQ0MX1nX/bqFs7v5o1KZ9VO12W2r63SeDZ126L9Ze",
+	"tf321vdbrkERikrLRELR8PM72F1dZcJb9+PoYOEWMr9mqb5BMrjbaVx5FpNxYS1tMiCsvxQVARjy0YX/",
+	"X80JZNwmJKNQ4pexa1uBQgozHRI/DGAnzflE1NeuOgpCS+ir3asQoIvJlOak4JngkykiPYyriRTktRR2",
+	"Il/sByPwPF246ASN5lOoApAz4ovS6Xo9QM+yNPXWYIE+aqiH+DitbZ4rpcctz8y0GReiE+LSvexLHZiu",
+	"HKNMv/zOBcAjxfWWuZ8OI1UsJINwlObSDkYIHy895Mcg4JjIFlbP7qKrF/L2w4u5k6MfjiqMD5FggTLa",
+	"Vup6xt9CGby5eDFIepRMXMuMl18w2Iwvt5as/Pg4c9K80Kzk1Tp4mwK5si/lD0zH4UR/7oyXnd3feHaP",
+	"8M0ZetKbrOjY/h5yI7vuk2yZCXF80MJMF16vqC+oRpVs1fMSy2B/FrkvuJLv7sb5EChBz4P1PReKaNtu",
+	"z9Z3a1aItb2+WN+rWRO9l4g/sydCqPBl8avXTvRCrY0Yxiy0aMeZUI6vlFsWUyLS6qPQ4iJPADw9JvWB",
+	"LiwVhJCDrU48BaB31usTD4N5MphJHYHsqdRx0LpA069kNt9a3fGwds/9/f0iB7lfAvdeV3AvPojxASTD",
+	"E2AK7jLgAxhRanmo3jZPqIzXsCePPAscu34LxRooUaCRWyVtOAOaKsmEmboy77PRHsbvPDfTRqVAuxUq",
+	"fAq9K0C8PzJTzAGjdTZ9Yov8acJNVTnwYEQ0lgJ0dYrGpbLBEP7qgxliuioRSMqZTSp7Di7h0jA9JF9j",
+	"U1SmxlJNpDFMOLPM/jN7P6l1filYTMNfpKfSTG3u/gMoqsXSvdKc3dOa/C5aW24ddXagr+b7BQ+jrv0O",
+	"QjZai/P+vsFuLbZZUwwi7R8rVB2sIgPnw2mnhpfOK+TynrzbyHnXKp/S1dwhPBJLENWBBFCKUttqtBh7",
+	"4b4BktuBIGovGNw5Bh2WVtiMg4NFSZPndgg9JD9ZurR/uAIQVDdoUPfB6L/icWwLr7v4hX3xqdXYvTiS",
+	"6/efhOMvXBgcoaHTE7l5O4772iJR7L5YdKxrI2ca3lW7th4TsLxzalg+HxLriW+4nyujuov68jRhm7AM",
+	"WXwlJJA+uK4UIarJLcvz7ugJW9kWZi750Ts8HnDf5cLzSkIgMnGPxTyFrdBdJJaCJKq8AntebSji2eQO",
+	"lotpRZXXlEP+J9F1hEHOxTUG/2inDfjBwpgJ73PjGlmzj/StXwGoUOTWBTtiHov93XZBrJlwbaMVOyLM",
+	"qVvLmSuD87iSOiqMN+NOz2IZjXDC9rxtijEftx3O5kpyg9cAtG2wv4OnqhbQDY92XW7CataDeFrnudhN",
+	"FU52wWatf4IKqDJp5act1uZsjdwX+NRD8hKjThm65kPkq/nNJmjzwu1iW9jTIzhhBWMKEc1Lwwdqfc9i",
+	"CQYO9j5b5wMZ5iqchPgf6qoguKW5SB3HgFzx7Ch6On6/Gh9dI1JJt4DZYabeLKdpHWRQqX9wbyD4DgrI",
+	"Tbowko0DhLpcNFeMZvMg1giRUzckaO1AjInQjnh8VkVyPZnAbEYZh80/fjWtgWle6V8CZDOGrg3XjDSz",
+	"dkT7hgmGPjtaZQnY+wLGviQOy3zovm+CXxceyklpng/JiS2xxQQGAUGKDLItRMUVYWw2Fhf7dcSqC9ha",
+	"HFBbMSYtBCbH3m5onMdDbTuPa+LFlO9AWWfV1ryQ0uje4cKKq1UYtesCs0LM6gQvFx62vUtdtwvb5tez",
+	"Z+0hbh/KBtjfgdAHT9zu2mNZvaCzfEKqph1hJdIgdffGmZfi00KZ7XGgZtpPhAHh0WQulrABCf3poOfj",
+	"M7+XohtOW/nk83YWmPsSYrsK2Z3x+aVr/ziuj4W6yJ0wdX/9oS+/XvyRRyv9T8lK68FA8Hho+vTCKpQm",
+	"cQUxtAvAkCSnyhpTfc+MK5aafL6Jk9xXT/dO8g5OT4ceT+UfH20M+49Vcn3jDT+uCrvXTxt44J/rdhk0",
+	"DwJsXRm/J4SPfb+ng3QLj7kR2VDOmLgrcowY0jtyPOYpy2RawkEM9QyOQk8ZM0U+tP9tMqUq0uiKC6rm",
+	"8aQ/dmd2U33Tt2fba2ka6JkltsRYTdKYx58aXjBtaDGzf7Ih/oqT4U//jiLO49SaxxI2wfnwmfIOaP7K",
+	"N9/sEr38wPonEF0RvEGHrrHKl9x4g06O0W4SuNKDp21isAk/rwDN7m82NeR+97drNu8XluXBZSuWY+bI",
+	"eqZkZ1vJl/rUSI/Zv9CeLMfh0XqnfXW4gyS2OHzgaMvBY27NJM0ZVR/u8vdkQWe9wkbgTJDxNJ4DQAEc",
+	"e5txawTg48wj4YjxF9irShA3YcR49YAUdvfF0EypBHk2+iIhGbNZZ9RY51bhCycMB8k6+gqiUz9U4KNf",
+	"gt/d75GPtdhkzo1dP8m3EHMfoKpvswJdF5tAYFwHmfnUSNIrWeVTUf7pyqyTCjJOzUkG0feDztCvopvP",
+	"RrjncBOSM3pjHxQrjY+mvmZsphuXC/ywzBtOyyeG+fbNDM1HLLblOonj2u/S1aK2e5aaBjl6D+NIrUK0",
+	"q4a/Iht12xHasYQgm8IwDok9minon/Huh9hVfYAHMc1t3V82CfMuAuh04YJdbJchxD8StrL3b81WNona",
+	"fjwG4XShPuZMZ6n5iBQat6JjK8o/Wq1mTYIX6jkOHui24GAOK/O8qiq9iY0nhPCukIZ192tUkP7Bdvu4",
+	"dJmm78/XfG2+JrjuJePFd3+l2dQ1uNcZS+EsPzTbely15hWDKzjFKuFSNMr5tqIvnv0a9A0eY4iHvQSP",
+	"MtS0lFLhqnzQLHPZGwWWoaDCEhlmUmLMqO8Fz87a/N3lAq6YYyLrF41sxCC3ATJAr/PqS4eAl4rI3No/",
+	"ajJ7vNqM4QNwo50v3v3xs7dvh/ivz//rD6trFi4R/Woyd5t4bEJHeP7HXHyeIpcVDtTSo014yfNFT0j1",
+	"CEulJdXSEfqulp+2xXoW5MuZOyPDKkNAQNu21ycpQVcVSa9LAARl0ntVTu+WAjHqQXVw0h/Qw/T48vU1",
+	"VdeBeKM6eP0jgvgO81Ygvm2xDvHrQnxR69pJbl/VDOvBuyJwAFVf7/DZ6AtrgMdAZBf/ynVd/t9JDpyM",
+	"TLk2Us3X2dsqMjv3loGPl8y6S63Ny6SHws6N8Wix0Y3Zfxd2WxN2r6XVoT2RG+myEhCeEUIvrTHxsjKO",
+	"tdJ7s2E72et+9oCnMRmuaamlipkWgzch7ZOS9eOL7qYQPrGI1wD/GcrDjbkrRAXDY1ksG3Rm88gh48LW",
+	"iXZvl4gscDqmsrjiwnM/3Ed7CTScc7CKD3UunXPOqEqngx4d/Ms6XTucZD0ah5V8Onc6c2KtRxdXzeFr",
+	"JYv+vS5knz7uctOjCxaUf81F/z70LoLW0LFEv3X1bIOtALTJow1RSzsOeumDQmK1n/CRh85PPjzIwvdE",
+	"xvc1ljpvjg/em1hnlUta7BXnDJLtBXl/krFiJg0gFBR5fU+w/qs1VdBrRhQzijNNNB3bJDCjbA5YVWnW",
+	"Zm5BWQ8qsuCpScgVQ9YlFYd3ePMqJzaxD4DVta79e8b1uzBWf7tV3BgW1E/N5/iI5t5oZCfbG42GoxEI",
+	"pFJ7pPL1RaiQNtW2XpApldCgBna3jKytbediTyYu5SkjpeC/lvUqbFawT1BuKa+7cP4NXO/14v2T21ow",
+	"wB3sVdp0f0o3/mhufyNM31dqE1vhWo7JyfFZQr47/XtCvj/7KSF//eHYPuubkJdvzhLyzVenCTl6c5yQ",
+	"82+OE/L672cJufj2q4Scfntq3/1NyHc/Hifk+5+OE/Lj67PI47fhXo52/vHut4P7P3R4jTb2cJ4NdWo8",
+	"m730Vl4fM3M4Y/WIYXCUj22YQmn3ierqj6t1Vw63dksS1iBdxfNdixWatQs2by1+ekGvWVCxwGubwG5R",
+	"BW3URbW1x/GjUYwWPs+3kvPVZnzFQGDsuARI5ZT4BnfNM4S9h9cP3uj1BUk9r37pY5FXcmwbxeyCxFtK",
+	"efqPMZUD4s1rlQP/usv1XdS81P3O8Lti/R+qWP+eWdEhsyJxOpM9oRd4NDvwIrxEc19znbV0pMbQdAqT",
+	"/T+7Apj/y7eDagFQ63a0N9rb2dsfjUajYapv3g5i+/rkSrYiL4wnqeEjVi/O/woK999enf8Nmmzk3y81",
+	"U2tjpOFhDw3VqANTsZDA/aWqJUGfqGmfTByGTFvDia2CTbOCi8gwjNvXFSLiZCm62j7Z8uEiq2H636Oq",
+	"26Oqq6JLEC9rtWNu5lsL/q+Qetc/nxN1OrTiGzjvMzSawgDVQoNp1zkVEP/OZM4eDQe34k+IP5i1WCgl",
+	"/uLPlnwAwZtTv3sAtncXQe9Y+EIckt0jUJkraqV75ZchhZz7rh+KU/sFfHLVvzcov4cXO1q/z9GsHPSo",
+	"KLLa/4vVRfDF7OViU0GxtFVagqu/0o01fwLO3o0er3tK7+3vnPsRi5JIVanJj0aVvgpYT6btKmx9GIZ9",
+	"0VqsxRe6G4//LZn4BVyf5Hi8olxNxcHtZS2XmIHO4Spyw1O2VQzq6uC3l8cn8e7HLIEKNfCVLvEtZAk1",
+	"mPC7TXnpJ/h2hMWETfGqY5KRx6DHSDCqn3bdlpvk0xeKT+QkeZBMszOqG89NSpW7x0gPd3ft+2tTqc3h",
+	"X0Z/GQ3u393/7wCdMDVZltAAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
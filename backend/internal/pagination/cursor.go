@@ -0,0 +1,67 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+)
+
+// Cursor points at the row a keyset page starts after, or ends before when Before is set.
+type Cursor struct {
+	// Query fingerprints the sort and filter the cursor was created for.
+	Query  string `json:"q"`
+	Before bool   `json:"b,omitempty"`
+	// Key holds the sort key values of the row, the row id last.
+	Key []string `json:"k"`
+}
+
+// Signer encodes cursors into opaque tokens and rejects tokens that were tampered with.
+type Signer struct {
+	secret []byte
+}
+
+func NewSigner(secret []byte) *Signer {
+	return &Signer{secret: secret}
+}
+
+func (s *Signer) Encode(c Cursor) string {
+	payload, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(s.sign(payload))
+}
+
+func (s *Signer) Decode(token string) (*Cursor, error) {
+	encodedPayload, encodedSig, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, entity.ErrorValidation("invalid cursor")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, entity.ErrorValidation("invalid cursor")
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(encodedSig)
+	if err != nil || !hmac.Equal(sig, s.sign(payload)) {
+		return nil, entity.ErrorValidation("invalid cursor")
+	}
+	var c Cursor
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return nil, entity.ErrorValidation("invalid cursor")
+	}
+	return &c, nil
+}
+
+// Fingerprint returns a short digest of v, used to bind a cursor to the query it came from.
+func Fingerprint(v any) string {
+	body, _ := json.Marshal(v)
+	sum := sha256.Sum256(body)
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
+
+func (s *Signer) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,38 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSigner(t *testing.T) {
+	signer := NewSigner([]byte("secret"))
+	cursor := Cursor{Query: Fingerprint("status,-amount"), Before: true, Key: []string{"completed", "20000", "5"}}
+
+	t.Run("round trip", func(t *testing.T) {
+		decoded, err := signer.Decode(signer.Encode(cursor))
+		assert.NoError(t, err)
+		assert.Equal(t, &cursor, decoded)
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		other := signer.Encode(Cursor{Query: cursor.Query, Key: []string{"completed", "1", "1"}})
+		token := signer.Encode(cursor)
+		_, err := signer.Decode(other[:len(other)-43] + token[len(token)-43:])
+		var appErr *entity.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, entity.ErrorCodeValidation, appErr.Code)
+	})
+
+	t.Run("other secret", func(t *testing.T) {
+		_, err := NewSigner([]byte("other")).Decode(signer.Encode(cursor))
+		assert.Error(t, err)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := signer.Decode("not-a-cursor")
+		assert.Error(t, err)
+	})
+}
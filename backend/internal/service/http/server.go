@@ -3,6 +3,8 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -10,9 +12,13 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/fajrinajiseno/mygolangapp/internal/authz"
 	"github.com/fajrinajiseno/mygolangapp/internal/config"
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/fajrinajiseno/mygolangapp/internal/jwtkey"
 	"github.com/fajrinajiseno/mygolangapp/internal/middleware"
 	"github.com/fajrinajiseno/mygolangapp/internal/openapigen"
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/getkin/kin-openapi/openapi3filter"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
@@ -30,28 +36,42 @@ const (
 	writeTimeout = 10
 	idleTimeout  = 60
 	corsMaxAge   = 300
+	jwksMaxAge   = 300
 )
 
-func NewServer(apiHandler openapigen.ServerInterface, openapiYamlPath string) *Server {
+// permissionsExtension lists on an operation the permissions its caller must be granted.
+const permissionsExtension = "x-permissions"
+
+func NewServer(apiHandler openapigen.ServerInterface, openapiYamlPath string, authorizer authz.Authorizer, keys *jwtkey.Keyring,
+	revocations middleware.RevocationChecker, apiKeys middleware.APIKeyAuthenticator) *Server {
 	swagger, err := openapigen.GetSwagger()
 	if err != nil {
 		log.Fatalf("failed to load swagger: %v", err)
 	}
+	for path, item := range swagger.Paths.Map() {
+		for method, op := range item.Operations() {
+			if _, err := operationPermissions(op); err != nil {
+				log.Fatalf("invalid %s on %s %s: %v", permissionsExtension, method, path, err)
+			}
+		}
+	}
 	openapiJSON, err := loadOpenAPIAsJSON(openapiYamlPath)
 	if err != nil {
 		log.Fatalf("failed to loadOpenAPIAsJSON: %v", err)
 	}
 
+	authenticate := middleware.NewAuthMiddleware(keys, revocations, apiKeys)
+
 	r := chi.NewRouter()
 
 	r.Use(middleware.LoggingMiddleware)
-	r.Use(middleware.ContextMiddleware)
+	r.Use(middleware.NewContextMiddleware(keys, apiKeys))
 
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{config.Cors},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "Idempotency-Key", middleware.APIKeyHeader},
+		ExposedHeaders:   []string{"Link", "Idempotent-Replayed", "Content-Disposition"},
 		AllowCredentials: true,
 		MaxAge:           corsMaxAge,
 	}))
@@ -65,6 +85,16 @@ func NewServer(apiHandler openapigen.ServerInterface, openapiYamlPath string) *S
 		}
 	})
 
+	// the public keys other services verify our access tokens with
+	r.Get("/.well-known/jwks.json", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", jwksMaxAge))
+		if err := json.NewEncoder(w).Encode(keys.JWKS()); err != nil {
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+	})
+
 	r.Handle("/docs/*", swgui.New("Dashboard API Docs", "/openapi.json", "/docs/"))
 	r.Get("/docs", func(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, "/docs/", http.StatusTemporaryRedirect)
@@ -75,9 +105,32 @@ func NewServer(apiHandler openapigen.ServerInterface, openapiYamlPath string) *S
 			swagger,
 			&oapinethttpmw.Options{
 				Options: openapi3filter.Options{
-					AuthenticationFunc: middleware.AuthMiddleware,
+					AuthenticationFunc: func(ctx context.Context, in *openapi3filter.AuthenticationInput) error {
+						if err := authenticate(ctx, in); err != nil {
+							return err
+						}
+						// validated at startup
+						permissions, _ := operationPermissions(in.RequestValidationInput.Route.Operation)
+						_, err := authorizer.Authorize(in.RequestValidationInput.Request.Context(), permissions...)
+						return err
+					},
 				},
-				ErrorHandler: func(w http.ResponseWriter, message string, statusCode int) {
+				ErrorHandlerWithOpts: func(_ context.Context, err error, w http.ResponseWriter, _ *http.Request, opts oapinethttpmw.ErrorHandlerOpts) {
+					statusCode, message := opts.StatusCode, err.Error()
+					var appErr *entity.AppError
+					if errors.As(err, &appErr) {
+						message = appErr.Message
+						switch appErr.Code {
+						case entity.ErrorCodeForbidden:
+							statusCode = http.StatusForbidden
+						case entity.ErrorCodeNotFound, entity.ErrorCodeUnauthorized:
+							// the user of a valid token no longer exists or was disabled
+							statusCode = http.StatusUnauthorized
+						default:
+							statusCode = http.StatusInternalServerError
+						}
+					}
+
 					w.Header().Set("Content-Type", "application/json")
 					w.WriteHeader(statusCode)
 
@@ -89,7 +142,7 @@ func NewServer(apiHandler openapigen.ServerInterface, openapiYamlPath string) *S
 						Message: message,
 					}
 
-					err := json.NewEncoder(w).Encode(resp)
+					err = json.NewEncoder(w).Encode(resp)
 					if err != nil {
 						http.Error(w, "internal server error", http.StatusInternalServerError)
 						return
@@ -145,6 +198,29 @@ func (s *Server) Routes() http.Handler {
 	return s.router
 }
 
+// operationPermissions reads the x-permissions extension of op, which must only list known
+// permissions.
+func operationPermissions(op *openapi3.Operation) ([]entity.Permission, error) {
+	raw, ok := op.Extensions[permissionsExtension]
+	if !ok {
+		return nil, nil
+	}
+	list, ok := raw.([]any)
+	if !ok {
+		return nil, errors.New("not a list")
+	}
+	permissions := make([]entity.Permission, 0, len(list))
+	for _, v := range list {
+		name, _ := v.(string)
+		p := entity.Permission(name)
+		if !p.Valid() {
+			return nil, fmt.Errorf("unknown permission %v", v)
+		}
+		permissions = append(permissions, p)
+	}
+	return permissions, nil
+}
+
 func loadOpenAPIAsJSON(yamlPath string) ([]byte, error) {
 	yamlData, err := os.ReadFile(yamlPath)
 	if err != nil {
@@ -2,6 +2,8 @@ package http_test
 
 import (
 	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -9,8 +11,11 @@ import (
 	"time"
 
 	"github.com/fajrinajiseno/mygolangapp/internal/api"
+	azm "github.com/fajrinajiseno/mygolangapp/internal/authz/mock"
 	"github.com/fajrinajiseno/mygolangapp/internal/config"
 	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/fajrinajiseno/mygolangapp/internal/jwtkey"
+	akm "github.com/fajrinajiseno/mygolangapp/internal/module/apikey/usecase/mock"
 	ah "github.com/fajrinajiseno/mygolangapp/internal/module/auth/handler"
 	aum "github.com/fajrinajiseno/mygolangapp/internal/module/auth/usecase/mock"
 	ph "github.com/fajrinajiseno/mygolangapp/internal/module/payment/handler"
@@ -21,14 +26,21 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+func notRevoked(ctrl *gomock.Controller) *azm.MockRevocationList {
+	revocations := azm.NewMockRevocationList(ctrl)
+	revocations.EXPECT().IsRevoked(gomock.Any()).Return(false).AnyTimes()
+	return revocations
+}
+
 func TestProtectedEndpointWithoutToken(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockAuthUC := aum.NewMockAuthUsecase(ctrl)
 	mockPaymentUC := pum.NewMockPaymentUsecase(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
 
-	authH := ah.NewAuthHandler(mockPaymentUC, mockAuthUC)
+	authH := ah.NewAuthHandler(mockPaymentUC, mockAuthUC, nil, nil)
 	paymentH := ph.NewPaymentHandler(mockPaymentUC)
 
 	apiHandler := &api.APIHandler{
@@ -36,7 +48,7 @@ func TestProtectedEndpointWithoutToken(t *testing.T) {
 		Payment: paymentH,
 	}
 
-	srv := srv.NewServer(apiHandler, "../../../../openapi.yaml")
+	srv := srv.NewServer(apiHandler, "../../../../openapi.yaml", mockAuthorizer, jwtkey.NewHMACKeyring(config.JwtSecret), notRevoked(ctrl), nil)
 	ts := httptest.NewServer(srv.Routes())
 	defer ts.Close()
 
@@ -52,6 +64,7 @@ func TestLoginAndAccessProtected(t *testing.T) {
 
 	const hour24 = 24
 	claims := jwt.MapClaims{
+		"jti": "token-1",
 		"sub": "1",
 		"exp": time.Now().Add(hour24 * time.Hour).Unix(),
 		"iat": time.Now().Unix(),
@@ -61,18 +74,27 @@ func TestLoginAndAccessProtected(t *testing.T) {
 
 	mockAuthUC := aum.NewMockAuthUsecase(ctrl)
 	mockAuthUC.EXPECT().
-		Login("alice@example.com", "password").
-		Return(signed, &entity.User{
-			ID:           "1",
-			Email:        "alice@example.com",
-			PasswordHash: "password",
-			Role:         "operation",
+		Login("alice@example.com", "password", gomock.Any()).
+		Return(&entity.LoginResult{
+			Tokens: &entity.AuthTokens{AccessToken: signed, RefreshToken: "refresh"},
+			User: &entity.User{
+				ID:           "1",
+				Email:        "alice@example.com",
+				PasswordHash: "password",
+				Role:         "operation",
+				Permissions:  []entity.Permission{entity.PermissionPaymentRead, entity.PermissionPaymentReview},
+			},
 		}, nil)
 
+	user := &entity.User{ID: "1", Role: "operation"}
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+	mockAuthorizer.EXPECT().Authorize(gomock.Any(), entity.PermissionPaymentRead).Return(user, nil)
+	mockAuthorizer.EXPECT().Authorize(gomock.Any(), entity.PermissionPaymentReview).Return(user, nil)
+
 	mockPaymentUC := pum.NewMockPaymentUsecase(ctrl)
 	mockPaymentUC.EXPECT().
-		ListPayment("completed", "1", "-created_at", 10, 1).
-		Return([]*entity.Payment{
+		ListPayment(gomock.Any(), entity.PaymentFilter{Status: "completed", ID: "1"}, "-created_at", 10, 1, "", entity.PaymentSummaryScopeAll).
+		Return(&entity.PaymentPage{Payments: []*entity.Payment{
 			{
 				ID:        "1",
 				Merchant:  "merchant 1",
@@ -80,18 +102,18 @@ func TestLoginAndAccessProtected(t *testing.T) {
 				Amount:    100,
 				CreatedAt: time.Now(),
 			},
-		}, &entity.PaymentSummary{
+		}, Summary: &entity.PaymentSummary{
 			TotalByFiler:   2,
 			Total:          4,
 			TotalCompleted: 2,
 			TotalFailed:    1,
 			TotalPending:   1,
-		}, nil)
+		}}, nil)
 	mockPaymentUC.EXPECT().
-		ReviewPayment(gomock.Any(), "1").
-		Return("Success Review", nil)
+		ReviewPayment(gomock.Any(), "1", entity.ReviewOutcomeFlagged, "double charge").
+		Return(&entity.PaymentReview{ID: "1", PaymentID: "1", ReviewerID: "1", Outcome: entity.ReviewOutcomeFlagged, Note: "double charge", ReviewedAt: time.Now()}, nil)
 
-	authH := ah.NewAuthHandler(mockPaymentUC, mockAuthUC)
+	authH := ah.NewAuthHandler(mockPaymentUC, mockAuthUC, nil, nil)
 	paymentH := ph.NewPaymentHandler(mockPaymentUC)
 
 	apiHandler := &api.APIHandler{
@@ -99,7 +121,7 @@ func TestLoginAndAccessProtected(t *testing.T) {
 		Payment: paymentH,
 	}
 
-	srv := srv.NewServer(apiHandler, "../../../../openapi.yaml")
+	srv := srv.NewServer(apiHandler, "../../../../openapi.yaml", mockAuthorizer, jwtkey.NewHMACKeyring(config.JwtSecret), notRevoked(ctrl), nil)
 	ts := httptest.NewServer(srv.Routes())
 	defer ts.Close()
 
@@ -110,10 +132,16 @@ func TestLoginAndAccessProtected(t *testing.T) {
 	defer resLogin.Body.Close()
 	require.Equal(t, http.StatusOK, resLogin.StatusCode)
 
-	var resp map[string]string
+	var resp struct {
+		Token        string   `json:"token"`
+		RefreshToken string   `json:"refresh_token"`
+		Permissions  []string `json:"permissions"`
+	}
 	json.NewDecoder(resLogin.Body).Decode(&resp)
-	respToken := resp["token"]
+	respToken := resp.Token
 	require.NotEmpty(t, respToken)
+	require.Equal(t, "refresh", resp.RefreshToken)
+	require.Equal(t, []string{"payment:read", "payment:review"}, resp.Permissions)
 
 	client := &http.Client{}
 	reqGetPayment, _ := http.NewRequest("GET", ts.URL+"/dashboard/v1/payments?status=completed&id=1&sort=-created_at&limit=10&offset=1", nil)
@@ -124,10 +152,301 @@ func TestLoginAndAccessProtected(t *testing.T) {
 	require.Equal(t, http.StatusOK, resGetPayment.StatusCode)
 
 	client2 := &http.Client{}
-	reqPaymentReview, _ := http.NewRequest("PUT", ts.URL+"/dashboard/v1/payment/1/review", nil)
+	reviewBody, _ := json.Marshal(map[string]string{"outcome": "flagged", "note": "double charge"})
+	reqPaymentReview, _ := http.NewRequest("PUT", ts.URL+"/dashboard/v1/payment/1/review", bytes.NewReader(reviewBody))
 	reqPaymentReview.Header.Set("Authorization", "Bearer "+respToken)
+	reqPaymentReview.Header.Set("Content-Type", "application/json")
 	resPaymentReview, err := client2.Do(reqPaymentReview)
 	require.NoError(t, err)
 	defer resPaymentReview.Body.Close()
 	require.Equal(t, http.StatusOK, resPaymentReview.StatusCode)
 }
+
+func TestUpdatePaymentStatusConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const hour24 = 24
+	claims := jwt.MapClaims{
+		"jti": "token-1",
+		"sub": "1",
+		"exp": time.Now().Add(hour24 * time.Hour).Unix(),
+		"iat": time.Now().Unix(),
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, _ := token.SignedString(config.JwtSecret)
+
+	mockAuthUC := aum.NewMockAuthUsecase(ctrl)
+	mockPaymentUC := pum.NewMockPaymentUsecase(ctrl)
+	mockPaymentUC.EXPECT().
+		UpdatePaymentStatus(gomock.Any(), "1", entity.PaymentStatusCompleted, "").
+		Return(nil, entity.ErrorConflict("cannot change payment status from failed to completed"))
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+	mockAuthorizer.EXPECT().
+		Authorize(gomock.Any(), entity.PermissionPaymentUpdateStatus).
+		Return(&entity.User{ID: "1", Role: "operation"}, nil).
+		Times(2)
+
+	apiHandler := &api.APIHandler{
+		Auth:    ah.NewAuthHandler(mockPaymentUC, mockAuthUC, nil, nil),
+		Payment: ph.NewPaymentHandler(mockPaymentUC),
+	}
+
+	srv := srv.NewServer(apiHandler, "../../../../openapi.yaml", mockAuthorizer, jwtkey.NewHMACKeyring(config.JwtSecret), notRevoked(ctrl), nil)
+	ts := httptest.NewServer(srv.Routes())
+	defer ts.Close()
+
+	body, _ := json.Marshal(map[string]string{"status": "completed"})
+	req, _ := http.NewRequest("PUT", ts.URL+"/dashboard/v1/payment/1/status", bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+signed)
+	req.Header.Set("Content-Type", "application/json")
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusConflict, res.StatusCode)
+
+	badBody, _ := json.Marshal(map[string]string{"status": "settled"})
+	badReq, _ := http.NewRequest("PUT", ts.URL+"/dashboard/v1/payment/1/status", bytes.NewReader(badBody))
+	badReq.Header.Set("Authorization", "Bearer "+signed)
+	badReq.Header.Set("Content-Type", "application/json")
+	badRes, err := http.DefaultClient.Do(badReq)
+	require.NoError(t, err)
+	defer badRes.Body.Close()
+	require.Equal(t, http.StatusBadRequest, badRes.StatusCode)
+}
+
+func TestReviewPaymentWithoutPermission(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const hour24 = 24
+	claims := jwt.MapClaims{
+		"jti": "token-2",
+		"sub": "2",
+		"exp": time.Now().Add(hour24 * time.Hour).Unix(),
+		"iat": time.Now().Unix(),
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, _ := token.SignedString(config.JwtSecret)
+
+	mockAuthUC := aum.NewMockAuthUsecase(ctrl)
+	mockPaymentUC := pum.NewMockPaymentUsecase(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+	mockAuthorizer.EXPECT().
+		Authorize(gomock.Any(), entity.PermissionPaymentReview).
+		Return(nil, entity.ErrorForbidden("missing permission payment:review"))
+	mockAuthorizer.EXPECT().
+		Authorize(gomock.Any(), entity.PermissionPaymentReview).
+		Return(nil, entity.ErrorNotFound("user not found"))
+
+	apiHandler := &api.APIHandler{
+		Auth:    ah.NewAuthHandler(mockPaymentUC, mockAuthUC, nil, nil),
+		Payment: ph.NewPaymentHandler(mockPaymentUC),
+	}
+
+	srv := srv.NewServer(apiHandler, "../../../../openapi.yaml", mockAuthorizer, jwtkey.NewHMACKeyring(config.JwtSecret), notRevoked(ctrl), nil)
+	ts := httptest.NewServer(srv.Routes())
+	defer ts.Close()
+
+	reviewBody, _ := json.Marshal(map[string]string{"outcome": "flagged"})
+	req, _ := http.NewRequest("PUT", ts.URL+"/dashboard/v1/payment/1/review", bytes.NewReader(reviewBody))
+	req.Header.Set("Authorization", "Bearer "+signed)
+	req.Header.Set("Content-Type", "application/json")
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	var resp struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+	require.Equal(t, "missing permission payment:review", resp.Message)
+
+	goneReq, _ := http.NewRequest("PUT", ts.URL+"/dashboard/v1/payment/1/review", bytes.NewReader(reviewBody))
+	goneReq.Header.Set("Authorization", "Bearer "+signed)
+	goneReq.Header.Set("Content-Type", "application/json")
+	goneRes, err := http.DefaultClient.Do(goneReq)
+	require.NoError(t, err)
+	defer goneRes.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, goneRes.StatusCode)
+}
+
+func TestRevokedToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const hour24 = 24
+	claims := jwt.MapClaims{
+		"jti": "revoked",
+		"sub": "1",
+		"exp": time.Now().Add(hour24 * time.Hour).Unix(),
+		"iat": time.Now().Unix(),
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, _ := token.SignedString(config.JwtSecret)
+
+	mockAuthUC := aum.NewMockAuthUsecase(ctrl)
+	mockPaymentUC := pum.NewMockPaymentUsecase(ctrl)
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+	mockRevocations := azm.NewMockRevocationList(ctrl)
+	mockRevocations.EXPECT().
+		IsRevoked(gomock.Any()).
+		DoAndReturn(func(c *entity.TokenClaims) bool { return c.ID == "revoked" })
+
+	apiHandler := &api.APIHandler{
+		Auth:    ah.NewAuthHandler(mockPaymentUC, mockAuthUC, nil, nil),
+		Payment: ph.NewPaymentHandler(mockPaymentUC),
+	}
+
+	srv := srv.NewServer(apiHandler, "../../../../openapi.yaml", mockAuthorizer, jwtkey.NewHMACKeyring(config.JwtSecret), mockRevocations, nil)
+	ts := httptest.NewServer(srv.Routes())
+	defer ts.Close()
+
+	req, _ := http.NewRequest("GET", ts.URL+"/dashboard/v1/payments", nil)
+	req.Header.Set("Authorization", "Bearer "+signed)
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, res.StatusCode)
+}
+
+func TestPasswordResetWithoutToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockResetUC := aum.NewMockPasswordResetUsecase(ctrl)
+	mockResetUC.EXPECT().RequestPasswordReset(gomock.Any(), "cs@test.com").Return(nil)
+	mockResetUC.EXPECT().ResetPassword(gomock.Any(), "token", "new-password").Return(entity.ErrorUnauthorized("password reset token expired"))
+
+	apiHandler := &api.APIHandler{
+		Auth: ah.NewAuthHandler(pum.NewMockPaymentUsecase(ctrl), aum.NewMockAuthUsecase(ctrl), mockResetUC, nil),
+	}
+	srv := srv.NewServer(apiHandler, "../../../../openapi.yaml", azm.NewMockAuthorizer(ctrl), jwtkey.NewHMACKeyring(config.JwtSecret), notRevoked(ctrl), nil)
+	ts := httptest.NewServer(srv.Routes())
+	defer ts.Close()
+
+	res, err := http.Post(ts.URL+"/dashboard/v1/auth/password-reset", "application/json", bytes.NewBufferString(`{"email":"cs@test.com"}`))
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, http.StatusNoContent, res.StatusCode)
+
+	// rejected by the request validator before reaching the usecase
+	res, err = http.Post(ts.URL+"/dashboard/v1/auth/password-reset/confirm", "application/json", bytes.NewBufferString(`{"token":"token","password":"short"}`))
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res, err = http.Post(ts.URL+"/dashboard/v1/auth/password-reset/confirm", "application/json", bytes.NewBufferString(`{"token":"token","password":"new-password"}`))
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, res.StatusCode)
+}
+
+func TestAPIKeyAccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	key := &entity.APIKey{ID: "7", CreatedBy: "1", MerchantID: "2", Scopes: []entity.Permission{entity.PermissionPaymentRead}}
+	mockAPIKeys := akm.NewMockAPIKeyUsecase(ctrl)
+	mockAPIKeys.EXPECT().AuthenticateAPIKey("dpk_valid_secret").Return(key, nil).AnyTimes()
+	mockAPIKeys.EXPECT().AuthenticateAPIKey("dpk_valid_wrong").Return(nil, entity.ErrorUnauthorized("invalid api key")).MinTimes(1)
+
+	user := &entity.User{ID: "1", Role: "operation", Permissions: []entity.Permission{entity.PermissionPaymentRead}}
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+	mockAuthorizer.EXPECT().Authorize(gomock.Any(), entity.PermissionPaymentRead).Return(user, nil).Times(2)
+
+	mockPaymentUC := pum.NewMockPaymentUsecase(ctrl)
+	mockPaymentUC.EXPECT().
+		ListPayment(gomock.Any(), entity.PaymentFilter{MerchantID: "2"}, "-created_at", 20, 0, "", entity.PaymentSummaryScopeFiltered).
+		Return(&entity.PaymentPage{Payments: []*entity.Payment{}, Summary: &entity.PaymentSummary{}}, nil)
+
+	apiHandler := &api.APIHandler{
+		Payment: ph.NewPaymentHandler(mockPaymentUC),
+	}
+	srv := srv.NewServer(apiHandler, "../../../../openapi.yaml", mockAuthorizer, jwtkey.NewHMACKeyring(config.JwtSecret), notRevoked(ctrl), mockAPIKeys)
+	ts := httptest.NewServer(srv.Routes())
+	defer ts.Close()
+
+	get := func(path, apiKey string) int {
+		req, _ := http.NewRequest("GET", ts.URL+path, nil)
+		req.Header.Set("X-API-Key", apiKey)
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		res.Body.Close()
+		return res.StatusCode
+	}
+
+	// the merchant of the key is applied to the filter and the summary
+	require.Equal(t, http.StatusOK, get("/dashboard/v1/payments", "dpk_valid_secret"))
+	require.Equal(t, http.StatusForbidden, get("/dashboard/v1/payments?merchant_id=3", "dpk_valid_secret"))
+	require.Equal(t, http.StatusUnauthorized, get("/dashboard/v1/payments", "dpk_valid_wrong"))
+
+	// operations without apiKeyAuth only take bearer tokens
+	require.Equal(t, http.StatusUnauthorized, get("/dashboard/v1/merchants", "dpk_valid_secret"))
+
+	// a bearer token next to a key would leave the handler without the merchant of the key
+	signed, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"jti": "1", "sub": "1", "exp": time.Now().Add(time.Hour).Unix(), "iat": time.Now().Unix(),
+	}).SignedString(config.JwtSecret)
+	req, _ := http.NewRequest("GET", ts.URL+"/dashboard/v1/payments", nil)
+	req.Header.Set("Authorization", "Bearer "+signed)
+	req.Header.Set("X-API-Key", "dpk_valid_secret")
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, res.StatusCode)
+}
+
+func TestKeyRotation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	legacy, err := jwtkey.NewHMACKey("", config.JwtSecret, time.Time{})
+	require.NoError(t, err)
+	rotated, err := jwtkey.NewPrivateKey("rotated", private, time.Now().Add(-2*time.Hour))
+	require.NoError(t, err)
+	keys, err := jwtkey.NewKeyring(time.Hour, legacy, rotated)
+	require.NoError(t, err)
+
+	claims := jwt.MapClaims{"jti": "1", "sub": "1", "exp": time.Now().Add(time.Hour).Unix(), "iat": time.Now().Unix()}
+	current, err := keys.Sign(claims)
+	require.NoError(t, err)
+	retired, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(config.JwtSecret)
+
+	mockAuthorizer := azm.NewMockAuthorizer(ctrl)
+	mockAuthorizer.EXPECT().
+		Authorize(gomock.Any(), entity.PermissionPaymentRead).
+		Return(nil, entity.ErrorForbidden("missing permission payment:read"))
+
+	apiHandler := &api.APIHandler{Payment: ph.NewPaymentHandler(pum.NewMockPaymentUsecase(ctrl))}
+	srv := srv.NewServer(apiHandler, "../../../../openapi.yaml", mockAuthorizer, keys, notRevoked(ctrl), nil)
+	ts := httptest.NewServer(srv.Routes())
+	defer ts.Close()
+
+	get := func(token string) int {
+		req, _ := http.NewRequest("GET", ts.URL+"/dashboard/v1/payments", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		res.Body.Close()
+		return res.StatusCode
+	}
+	// authenticated, then denied by the authorizer
+	require.Equal(t, http.StatusForbidden, get(current))
+	// JWT_SECRET was replaced longer than the grace period ago
+	require.Equal(t, http.StatusUnauthorized, get(retired))
+
+	res, err := http.Get(ts.URL + "/.well-known/jwks.json")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	var set jwtkey.JWKS
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&set))
+	require.Len(t, set.Keys, 1)
+	require.Equal(t, "rotated", set.Keys[0].KeyID)
+	require.Equal(t, "EdDSA", set.Keys[0].Algorithm)
+}
@@ -0,0 +1,51 @@
+package transport
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+)
+
+// DecodeJSONBody decodes the request body into dst and writes a bad request error on failure.
+func DecodeJSONBody(w http.ResponseWriter, r *http.Request, dst any) bool {
+	if r.Body == nil {
+		WriteAppError(w, entity.ErrorBadRequest("empty body"))
+		return false
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		WriteAppError(w, entity.ErrorBadRequest("failed to read body"))
+		return false
+	}
+
+	if err := json.Unmarshal(body, dst); err != nil {
+		WriteAppError(w, entity.ErrorBadRequest("invalid json: "+err.Error()))
+		return false
+	}
+	return true
+}
+
+// DecodeOptionalJSONBody works like DecodeJSONBody but leaves dst untouched when the body is empty.
+func DecodeOptionalJSONBody(w http.ResponseWriter, r *http.Request, dst any) bool {
+	if r.Body == nil {
+		return true
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		WriteAppError(w, entity.ErrorBadRequest("failed to read body"))
+		return false
+	}
+	if len(body) == 0 {
+		return true
+	}
+
+	if err := json.Unmarshal(body, dst); err != nil {
+		WriteAppError(w, entity.ErrorBadRequest("invalid json: "+err.Error()))
+		return false
+	}
+	return true
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/fajrinajiseno/mygolangapp/internal/entity"
 )
@@ -28,6 +29,8 @@ func CodeToStatus(code entity.Code) int {
 		return http.StatusConflict
 	case entity.ErrorCodeUnavailable:
 		return http.StatusServiceUnavailable
+	case entity.ErrorCodeTooManyRequests:
+		return http.StatusTooManyRequests
 	default:
 		return http.StatusInternalServerError
 	}
@@ -35,6 +38,9 @@ func CodeToStatus(code entity.Code) int {
 
 func WriteAppError(w http.ResponseWriter, appErr *entity.AppError) {
 	status := CodeToStatus(appErr.Code)
+	if retry, ok := appErr.Details.(entity.RetryAfter); ok {
+		w.Header().Set("Retry-After", strconv.Itoa(retry.Seconds))
+	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 	resp := ErrorResponse{
@@ -1,18 +1,40 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"log"
+	"strings"
 	"time"
+	// embedded timezone database so analytics timezones resolve on hosts without one
+	_ "time/tzdata"
 
 	"github.com/fajrinajiseno/mygolangapp/internal/api"
+	"github.com/fajrinajiseno/mygolangapp/internal/authz"
 	"github.com/fajrinajiseno/mygolangapp/internal/config"
+	"github.com/fajrinajiseno/mygolangapp/internal/entity"
+	"github.com/fajrinajiseno/mygolangapp/internal/jwtkey"
+	"github.com/fajrinajiseno/mygolangapp/internal/mail"
+	akh "github.com/fajrinajiseno/mygolangapp/internal/module/apikey/handler"
+	akr "github.com/fajrinajiseno/mygolangapp/internal/module/apikey/repository"
+	aku "github.com/fajrinajiseno/mygolangapp/internal/module/apikey/usecase"
 	ah "github.com/fajrinajiseno/mygolangapp/internal/module/auth/handler"
 	ar "github.com/fajrinajiseno/mygolangapp/internal/module/auth/repository"
 	au "github.com/fajrinajiseno/mygolangapp/internal/module/auth/usecase"
+	eh "github.com/fajrinajiseno/mygolangapp/internal/module/export/handler"
+	er "github.com/fajrinajiseno/mygolangapp/internal/module/export/repository"
+	eu "github.com/fajrinajiseno/mygolangapp/internal/module/export/usecase"
+	mh "github.com/fajrinajiseno/mygolangapp/internal/module/merchant/handler"
+	mr "github.com/fajrinajiseno/mygolangapp/internal/module/merchant/repository"
+	mu "github.com/fajrinajiseno/mygolangapp/internal/module/merchant/usecase"
 	ph "github.com/fajrinajiseno/mygolangapp/internal/module/payment/handler"
 	pr "github.com/fajrinajiseno/mygolangapp/internal/module/payment/repository"
 	pu "github.com/fajrinajiseno/mygolangapp/internal/module/payment/usecase"
+	uh "github.com/fajrinajiseno/mygolangapp/internal/module/user/handler"
+	uu "github.com/fajrinajiseno/mygolangapp/internal/module/user/usecase"
+	"github.com/fajrinajiseno/mygolangapp/internal/pagination"
 	srv "github.com/fajrinajiseno/mygolangapp/internal/service/http"
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/joho/godotenv"
@@ -20,10 +42,14 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// revocationReload is how long a token revoked by another instance may still be accepted.
+const revocationReload = 30 * time.Second
+
 func main() {
 	_ = godotenv.Load()
 
-	db, err := sql.Open("sqlite3", "dashboard.db?_foreign_keys=1")
+	// WAL lets the export worker stream payments while requests keep writing
+	db, err := sql.Open("sqlite3", "dashboard.db?_foreign_keys=1&_journal_mode=WAL&_busy_timeout=5000")
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -37,51 +63,407 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	jwtKeyGrace, err := time.ParseDuration(config.JwtKeyGrace)
+	if err != nil {
+		panic(err)
+	}
+	// a replaced key must verify the tokens it signed until they expire
+	if jwtKeyGrace < JwtExpiredDuration {
+		log.Fatal("JWT_KEY_GRACE must not be shorter than JWT_EXPIRED")
+	}
+	refreshTokenTTL, err := time.ParseDuration(config.RefreshTokenExpired)
+	if err != nil {
+		panic(err)
+	}
+	exportTTL, err := time.ParseDuration(config.ExportTTL)
+	if err != nil {
+		panic(err)
+	}
+	passwordResetTTL, err := time.ParseDuration(config.PasswordResetExpired)
+	if err != nil {
+		panic(err)
+	}
+
+	jwtKeys, err := jwtkey.Load(config.JwtKeysDir, config.JwtSecret, jwtKeyGrace)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	mailer, err := newMailer()
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	userRepo := ar.NewUserRepo(db)
+	refreshTokenRepo := ar.NewRefreshTokenRepo(db)
+	revocationRepo := ar.NewRevocationRepo(db)
+	passwordResetRepo := ar.NewPasswordResetRepo(db)
+	twoFactorRepo := ar.NewTwoFactorRepo(db)
+	lockoutRepo := ar.NewLockoutRepo(db)
 	paymentRepo := pr.NewPaymentRepo(db)
+	merchantRepo := mr.NewMerchantRepo(db)
+	exportRepo := er.NewExportRepo(db)
+	apiKeyRepo := akr.NewAPIKeyRepo(db)
 
-	authUC := au.NewAuthUsecase(userRepo, config.JwtSecret, JwtExpiredDuration)
-	paymentUC := pu.NewPaymentUsecase(paymentRepo, userRepo)
+	authorizer := authz.NewAuthorizer(userRepo)
+	revocations, err := authz.NewRevocationList(revocationRepo, revocationReload)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	authH := ah.NewAuthHandler(paymentUC, authUC)
+	authUC := au.NewAuthUsecase(userRepo, refreshTokenRepo, twoFactorRepo, lockoutRepo, revocations, authorizer, jwtKeys, JwtExpiredDuration, refreshTokenTTL)
+	passwordResetUC := au.NewPasswordResetUsecase(userRepo, passwordResetRepo, revocations, mailer, passwordResetTTL, config.PasswordResetURL)
+	twoFactorUC := au.NewTwoFactorUsecase(twoFactorRepo, authorizer, config.TotpIssuer)
+	paymentUC := pu.NewPaymentUsecase(paymentRepo, authorizer, merchantRepo, pagination.NewSigner(config.CursorSecret))
+	merchantUC := mu.NewMerchantUsecase(merchantRepo, authorizer)
+	userUC := uu.NewUserUsecase(userRepo, authorizer, revocations, lockoutRepo)
+	exportUC := eu.NewExportUsecase(exportRepo, authorizer, paymentUC, config.ExportDir, exportTTL)
+	apiKeyUC := aku.NewAPIKeyUsecase(apiKeyRepo, merchantRepo, authorizer)
+
+	authH := ah.NewAuthHandler(paymentUC, authUC, passwordResetUC, twoFactorUC)
 	paymentH := ph.NewPaymentHandler(paymentUC)
+	merchantH := mh.NewMerchantHandler(merchantUC)
+	exportH := eh.NewExportHandler(exportUC)
+	userH := uh.NewUserHandler(userUC)
+	apiKeyH := akh.NewAPIKeyHandler(apiKeyUC)
 
 	apiHandler := &api.APIHandler{
-		Auth:    authH,
-		Payment: paymentH,
+		Auth:     authH,
+		Payment:  paymentH,
+		Merchant: merchantH,
+		Export:   exportH,
+		User:     userH,
+		APIKey:   apiKeyH,
 	}
 
-	server := srv.NewServer(apiHandler, config.OpenapiYamlLocation)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go exportUC.Run(ctx)
+	go revocations.Run(ctx)
+
+	server := srv.NewServer(apiHandler, config.OpenapiYamlLocation, authorizer, jwtKeys, revocations, apiKeyUC)
 
 	addr := config.HttpAddress
 	log.Printf("starting server on %s", addr)
 	server.Start(addr)
+	// mail the reset links requested before the shutdown
+	passwordResetUC.Wait()
+}
+
+func newMailer() (mail.Mailer, error) {
+	if config.SmtpAddr == "" {
+		return mail.NewFileMailer(config.MailDir, config.MailFrom)
+	}
+	return mail.NewSMTPMailer(config.SmtpAddr, config.SmtpUsername, config.SmtpPassword, config.MailFrom)
+}
+
+// defaultRolePermissions are the grants of a new database.
+var defaultRolePermissions = map[string][]entity.Permission{
+	"cs": {
+		entity.PermissionPaymentRead,
+		entity.PermissionPaymentNote,
+		entity.PermissionMerchantRead,
+	},
+	"admin": {
+		entity.PermissionPaymentRead,
+		entity.PermissionPaymentCreate,
+		entity.PermissionPaymentReview,
+		entity.PermissionPaymentUpdateStatus,
+		entity.PermissionPaymentRefund,
+		entity.PermissionPaymentNote,
+		entity.PermissionMerchantRead,
+		entity.PermissionMerchantManage,
+		entity.PermissionUserManage,
+		entity.PermissionAPIKeyManage,
+	},
+	"operation": {
+		entity.PermissionPaymentRead,
+		entity.PermissionPaymentCreate,
+		entity.PermissionPaymentReview,
+		entity.PermissionPaymentUpdateStatus,
+		entity.PermissionPaymentRefund,
+		entity.PermissionPaymentNote,
+		entity.PermissionMerchantRead,
+		entity.PermissionMerchantManage,
+	},
+}
+
+// migrations upgrade the data of an existing database, migrations[i] takes it from
+// PRAGMA user_version i to i+1. Each runs once, so grants an operator removed since are not
+// given back on the next start.
+var migrations = []func(db *sql.DB) error{
+	// the admin role, managing users and API keys
+	func(db *sql.DB) error {
+		return grant(db, "admin", defaultRolePermissions["admin"])
+	},
+}
+
+func grant(db *sql.DB, role string, permissions []entity.Permission) error {
+	for _, p := range permissions {
+		if _, err := db.Exec("INSERT OR IGNORE INTO role_permissions(role, permission) VALUES (?, ?)", role, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkPaymentsSchema refuses a database created before payments referenced merchants and
+// stored amounts in minor units with a currency. CREATE TABLE IF NOT EXISTS would keep its
+// payments table and every query on it would fail, and its amounts have no currency to be
+// converted with.
+func checkPaymentsSchema(db *sql.DB) error {
+	rows, err := db.Query("PRAGMA table_info(payments)")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	columns := map[string]string{}
+	for rows.Next() {
+		var (
+			cid, notNull, pk int
+			name, typ        string
+			dflt             sql.NullString
+		)
+		if err := rows.Scan(&cid, &name, &typ, &notNull, &dflt, &pk); err != nil {
+			return err
+		}
+		columns[name] = strings.ToUpper(typ)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(columns) == 0 {
+		// new database
+		return nil
+	}
+	if _, ok := columns["merchant_id"]; !ok || columns["amount"] != "INTEGER" || columns["currency"] == "" {
+		return errors.New("dashboard.db holds payments of an older schema without merchant_id, currency and integer amounts, " +
+			"it cannot be migrated: move it away to start with a new database")
+	}
+	return nil
 }
 
 func initDB(db *sql.DB) error {
+	if err := checkPaymentsSchema(db); err != nil {
+		return err
+	}
 	// create tables if not exists
 	stmts := []string{
 		`CREATE TABLE IF NOT EXISTS users (
 		  id INTEGER PRIMARY KEY AUTOINCREMENT,
 		  email TEXT NOT NULL UNIQUE,
 		  password_hash TEXT NOT NULL,
-		  role TEXT NOT NULL
+		  role TEXT NOT NULL,
+		  status TEXT NOT NULL DEFAULT 'active',
+		  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);`,
+		// emails are unique regardless of case, also in databases created before
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_nocase ON users(email COLLATE NOCASE);`,
+		`CREATE TABLE IF NOT EXISTS merchants (
+		  id INTEGER PRIMARY KEY AUTOINCREMENT,
+		  legal_name TEXT NOT NULL,
+		  display_name TEXT NOT NULL,
+		  status TEXT NOT NULL,
+		  settlement_currency TEXT NOT NULL,
+		  contact_email TEXT NOT NULL,
+		  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
 		);`,
 		`CREATE TABLE IF NOT EXISTS payments (
 		  id INTEGER PRIMARY KEY AUTOINCREMENT,
-		  merchant TEXT NOT NULL,
+		  merchant_id INTEGER NOT NULL REFERENCES merchants(id),
+		  status TEXT NOT NULL,
+		  amount INTEGER NOT NULL,
+		  currency TEXT NOT NULL,
+		  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);`,
+		`CREATE INDEX IF NOT EXISTS idx_payments_merchant_id ON payments(merchant_id);`,
+		`CREATE TABLE IF NOT EXISTS payment_reviews (
+		  id INTEGER PRIMARY KEY AUTOINCREMENT,
+		  payment_id INTEGER NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+		  reviewer_id INTEGER NOT NULL REFERENCES users(id),
+		  outcome TEXT NOT NULL,
+		  note TEXT NOT NULL DEFAULT '',
+		  reviewed_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);`,
+		`CREATE INDEX IF NOT EXISTS idx_payment_reviews_payment_id ON payment_reviews(payment_id);`,
+		`CREATE TABLE IF NOT EXISTS payment_status_history (
+		  id INTEGER PRIMARY KEY AUTOINCREMENT,
+		  payment_id INTEGER NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+		  from_status TEXT NOT NULL,
+		  to_status TEXT NOT NULL,
+		  changed_by INTEGER NOT NULL REFERENCES users(id),
+		  reason TEXT NOT NULL DEFAULT '',
+		  changed_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);`,
+		`CREATE INDEX IF NOT EXISTS idx_payment_status_history_payment_id ON payment_status_history(payment_id);`,
+		`CREATE TABLE IF NOT EXISTS refunds (
+		  id INTEGER PRIMARY KEY AUTOINCREMENT,
+		  payment_id INTEGER NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+		  amount INTEGER NOT NULL CHECK (amount > 0),
+		  reason TEXT NOT NULL DEFAULT '',
+		  created_by INTEGER NOT NULL REFERENCES users(id),
+		  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);`,
+		`CREATE INDEX IF NOT EXISTS idx_refunds_payment_id ON refunds(payment_id);`,
+		`CREATE TABLE IF NOT EXISTS payment_notes (
+		  id INTEGER PRIMARY KEY AUTOINCREMENT,
+		  payment_id INTEGER NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+		  author_id INTEGER NOT NULL REFERENCES users(id),
+		  body TEXT NOT NULL,
+		  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);`,
+		`CREATE INDEX IF NOT EXISTS idx_payment_notes_payment_id ON payment_notes(payment_id);`,
+		`CREATE TABLE IF NOT EXISTS idempotency_keys (
+		  idempotency_key TEXT NOT NULL,
+		  user_id INTEGER NOT NULL REFERENCES users(id),
+		  request_hash TEXT NOT NULL,
+		  payment_id INTEGER NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+		  response TEXT NOT NULL,
+		  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		  PRIMARY KEY (user_id, idempotency_key)
+		);`,
+		`CREATE TABLE IF NOT EXISTS export_jobs (
+		  id INTEGER PRIMARY KEY AUTOINCREMENT,
+		  user_id INTEGER NOT NULL REFERENCES users(id),
+		  format TEXT NOT NULL,
+		  filter TEXT NOT NULL,
+		  sort TEXT NOT NULL DEFAULT '',
 		  status TEXT NOT NULL,
-		  amount REAL NOT NULL,
+		  total_rows INTEGER NOT NULL DEFAULT 0,
+		  rows_written INTEGER NOT NULL DEFAULT 0,
+		  error TEXT NOT NULL DEFAULT '',
+		  file_path TEXT NOT NULL DEFAULT '',
+		  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		  started_at DATETIME,
+		  completed_at DATETIME,
+		  expires_at DATETIME
+		);`,
+		`CREATE INDEX IF NOT EXISTS idx_export_jobs_status ON export_jobs(status);`,
+		// refresh_tokens stores the SHA-256 of each token, family_id groups the tokens
+		// rotated from one login
+		`CREATE TABLE IF NOT EXISTS refresh_tokens (
+		  id INTEGER PRIMARY KEY AUTOINCREMENT,
+		  user_id INTEGER NOT NULL REFERENCES users(id),
+		  family_id TEXT NOT NULL,
+		  token_hash TEXT NOT NULL UNIQUE,
+		  expires_at DATETIME NOT NULL,
+		  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		  used_at DATETIME,
+		  revoked_at DATETIME
+		);`,
+		`CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family ON refresh_tokens(family_id);`,
+		// revoked_tokens lists access tokens revoked before they expire by jti,
+		// user_token_revocations revokes every token of a user issued before revoked_before
+		`CREATE TABLE IF NOT EXISTS revoked_tokens (
+		  jti TEXT PRIMARY KEY,
+		  user_id INTEGER NOT NULL REFERENCES users(id),
+		  expires_at DATETIME NOT NULL,
+		  revoked_at DATETIME NOT NULL
+		);`,
+		`CREATE TABLE IF NOT EXISTS user_token_revocations (
+		  user_id INTEGER PRIMARY KEY REFERENCES users(id),
+		  revoked_before DATETIME NOT NULL
+		);`,
+		`CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user ON refresh_tokens(user_id);`,
+		// password_reset_tokens stores the SHA-256 of each mailed reset token
+		`CREATE TABLE IF NOT EXISTS password_reset_tokens (
+		  id INTEGER PRIMARY KEY AUTOINCREMENT,
+		  user_id INTEGER NOT NULL REFERENCES users(id),
+		  token_hash TEXT NOT NULL UNIQUE,
+		  expires_at DATETIME NOT NULL,
+		  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		  used_at DATETIME
+		);`,
+		`CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_user ON password_reset_tokens(user_id);`,
+		// user_totp holds the authenticator secret of a user, pending until enabled_at is set.
+		// last_step is the time step of the last accepted code so codes cannot be replayed.
+		`CREATE TABLE IF NOT EXISTS user_totp (
+		  user_id INTEGER PRIMARY KEY REFERENCES users(id),
+		  secret TEXT NOT NULL,
+		  enabled_at DATETIME,
+		  last_step INTEGER NOT NULL DEFAULT 0,
 		  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
 		);`,
+		`CREATE TABLE IF NOT EXISTS totp_recovery_codes (
+		  id INTEGER PRIMARY KEY AUTOINCREMENT,
+		  user_id INTEGER NOT NULL REFERENCES users(id),
+		  code_hash TEXT NOT NULL,
+		  used_at DATETIME
+		);`,
+		`CREATE INDEX IF NOT EXISTS idx_totp_recovery_codes_user ON totp_recovery_codes(user_id);`,
+		// login_challenges are issued when the password of a user with two-factor
+		// authentication is verified and exchanged with a code for tokens
+		`CREATE TABLE IF NOT EXISTS login_challenges (
+		  id INTEGER PRIMARY KEY AUTOINCREMENT,
+		  user_id INTEGER NOT NULL REFERENCES users(id),
+		  token_hash TEXT NOT NULL UNIQUE,
+		  expires_at DATETIME NOT NULL,
+		  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		  attempts INTEGER NOT NULL DEFAULT 0,
+		  used_at DATETIME
+		);`,
+		// login_failures counts the recent failed logins per account and per ip, scope is
+		// account or ip and key the email or the address
+		`CREATE TABLE IF NOT EXISTS login_failures (
+		  scope TEXT NOT NULL,
+		  key TEXT NOT NULL,
+		  failures INTEGER NOT NULL,
+		  last_failure_at DATETIME NOT NULL,
+		  locked_until DATETIME,
+		  PRIMARY KEY (scope, key)
+		);`,
+		// api_keys let scripts call the operations allowing apiKeyAuth, a key without
+		// merchant_id sees every merchant
+		`CREATE TABLE IF NOT EXISTS api_keys (
+		  id INTEGER PRIMARY KEY AUTOINCREMENT,
+		  name TEXT NOT NULL,
+		  prefix TEXT NOT NULL UNIQUE,
+		  secret_hash TEXT NOT NULL,
+		  scopes TEXT NOT NULL,
+		  merchant_id INTEGER REFERENCES merchants(id) ON DELETE CASCADE,
+		  created_by INTEGER NOT NULL REFERENCES users(id),
+		  expires_at DATETIME,
+		  last_used_at DATETIME,
+		  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		  revoked_at DATETIME
+		);`,
+		`CREATE TABLE IF NOT EXISTS role_permissions (
+		  role TEXT NOT NULL,
+		  permission TEXT NOT NULL,
+		  PRIMARY KEY (role, permission)
+		);`,
+		// payment_search indexes the text CS agents look payments up by, its rowid is the
+		// payment id. Requires the sqlite_fts5 build tag.
+		`CREATE VIRTUAL TABLE IF NOT EXISTS payment_search USING fts5(
+		  payment_id, merchant, legal_name,
+		  tokenize = 'unicode61 remove_diacritics 2', prefix = '2 3'
+		);`,
+		`CREATE TRIGGER IF NOT EXISTS payment_search_insert AFTER INSERT ON payments BEGIN
+		  INSERT INTO payment_search(rowid, payment_id, merchant, legal_name)
+		  SELECT NEW.id, NEW.id, display_name, legal_name FROM merchants WHERE id = NEW.merchant_id;
+		END;`,
+		`CREATE TRIGGER IF NOT EXISTS payment_search_update AFTER UPDATE OF merchant_id ON payments BEGIN
+		  UPDATE payment_search SET (merchant, legal_name) = (SELECT display_name, legal_name FROM merchants WHERE id = NEW.merchant_id)
+		  WHERE rowid = NEW.id;
+		END;`,
+		`CREATE TRIGGER IF NOT EXISTS payment_search_delete AFTER DELETE ON payments BEGIN
+		  DELETE FROM payment_search WHERE rowid = OLD.id;
+		END;`,
+		`CREATE TRIGGER IF NOT EXISTS payment_search_merchant_update AFTER UPDATE OF display_name, legal_name ON merchants BEGIN
+		  UPDATE payment_search SET merchant = NEW.display_name, legal_name = NEW.legal_name
+		  WHERE rowid IN (SELECT id FROM payments WHERE merchant_id = NEW.id);
+		END;`,
+		// index payments created before the search table existed
+		`INSERT INTO payment_search(rowid, payment_id, merchant, legal_name)
+		  SELECT p.id, p.id, m.display_name, m.legal_name FROM payments p JOIN merchants m ON m.id = p.merchant_id
+		  WHERE p.id NOT IN (SELECT rowid FROM payment_search);`,
 	}
 	for _, s := range stmts {
 		if _, err := db.Exec(s); err != nil {
 			return err
 		}
 	}
-	// seed payments if empty
+	// seed merchants and payments if empty
 	var cnt int
 	row := db.QueryRow("SELECT COUNT(1) FROM payments")
 	if err := row.Scan(&cnt); err != nil {
@@ -90,30 +472,65 @@ func initDB(db *sql.DB) error {
 	if cnt == 0 {
 		payments := []struct {
 			merchant  string
-			amount    float64
+			amount    int64
+			currency  string
 			status    string
 			createdAt string
 		}{
-			{"merchant 1", 100.0, "pending", time.Now().Format(time.RFC3339)},
-			{"merchant 2", 200.0, "completed", time.Now().Format(time.RFC3339)},
-			{"merchant 3", 150.5, "failed", time.Now().Add(-24 * time.Hour).Format(time.RFC3339)},
-			{"merchant 4", 100.0, "pending", time.Now().Add(-24 * time.Hour).Format(time.RFC3339)},
-			{"merchant 5", 200.0, "completed", time.Now().Add(-24 * time.Hour).Format(time.RFC3339)},
-			{"merchant 6", 150.5, "failed", time.Now().Add(-24 * time.Hour).Format(time.RFC3339)},
-			{"merchant 7", 100.0, "pending", time.Now().Add(-24 * time.Hour).Format(time.RFC3339)},
-			{"merchant 8", 200.0, "completed", time.Now().Add(-48 * time.Hour).Format(time.RFC3339)},
-			{"merchant 9", 150.5, "failed", time.Now().Add(-48 * time.Hour).Format(time.RFC3339)},
-			{"merchant 10", 100.0, "pending", time.Now().Add(-48 * time.Hour).Format(time.RFC3339)},
-			{"merchant 11", 200.0, "completed", time.Now().Add(-48 * time.Hour).Format(time.RFC3339)},
-			{"merchant 12", 150.5, "failed", time.Now().Add(-48 * time.Hour).Format(time.RFC3339)},
+			{"merchant 1", 100000, "IDR", "pending", time.Now().Format(time.RFC3339)},
+			{"merchant 2", 200000, "IDR", "completed", time.Now().Format(time.RFC3339)},
+			{"merchant 3", 150500, "IDR", "failed", time.Now().Add(-24 * time.Hour).Format(time.RFC3339)},
+			{"merchant 4", 100000, "IDR", "pending", time.Now().Add(-24 * time.Hour).Format(time.RFC3339)},
+			{"merchant 5", 20000, "USD", "completed", time.Now().Add(-24 * time.Hour).Format(time.RFC3339)},
+			{"merchant 6", 15050, "USD", "failed", time.Now().Add(-24 * time.Hour).Format(time.RFC3339)},
+			{"merchant 7", 100000, "IDR", "pending", time.Now().Add(-24 * time.Hour).Format(time.RFC3339)},
+			{"merchant 8", 200000, "IDR", "completed", time.Now().Add(-48 * time.Hour).Format(time.RFC3339)},
+			{"merchant 9", 150500, "IDR", "failed", time.Now().Add(-48 * time.Hour).Format(time.RFC3339)},
+			{"merchant 10", 100000, "IDR", "pending", time.Now().Add(-48 * time.Hour).Format(time.RFC3339)},
+			{"merchant 11", 2500, "BHD", "completed", time.Now().Add(-48 * time.Hour).Format(time.RFC3339)},
+			{"merchant 12", 150500, "IDR", "failed", time.Now().Add(-48 * time.Hour).Format(time.RFC3339)},
 		}
-		for _, p := range payments {
-			if _, err := db.Exec("INSERT INTO payments(merchant, amount, status, created_at) VALUES (?, ?, ?, ?)", p.merchant, p.amount, p.status, p.createdAt); err != nil {
+		for i, p := range payments {
+			res, err := db.Exec("INSERT INTO merchants(legal_name, display_name, status, settlement_currency, contact_email) VALUES (?, ?, ?, ?, ?)",
+				"PT "+p.merchant, p.merchant, "active", p.currency, fmt.Sprintf("finance@merchant%d.test", i+1))
+			if err != nil {
+				return err
+			}
+			merchantID, err := res.LastInsertId()
+			if err != nil {
+				return err
+			}
+			if _, err := db.Exec("INSERT INTO payments(merchant_id, amount, currency, status, created_at) VALUES (?, ?, ?, ?, ?)", merchantID, p.amount, p.currency, p.status, p.createdAt); err != nil {
 				return err
 			}
 		}
 	}
 
+	// seed role permissions if empty, a seeded database has the grants of every migration
+	row = db.QueryRow("SELECT COUNT(1) FROM role_permissions")
+	if err := row.Scan(&cnt); err != nil {
+		return err
+	}
+	var version int
+	if cnt == 0 {
+		for role, permissions := range defaultRolePermissions {
+			if err := grant(db, role, permissions); err != nil {
+				return err
+			}
+		}
+		version = len(migrations)
+	} else if err := db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return err
+	}
+	for ; version < len(migrations); version++ {
+		if err := migrations[version](db); err != nil {
+			return fmt.Errorf("migrate database to version %d: %w", version+1, err)
+		}
+	}
+	if _, err := db.Exec(fmt.Sprintf("PRAGMA user_version = %d", version)); err != nil {
+		return err
+	}
+
 	// seed admin user if not exists
 	row = db.QueryRow("SELECT COUNT(1) FROM users")
 	if err := row.Scan(&cnt); err != nil {
@@ -130,6 +547,9 @@ func initDB(db *sql.DB) error {
 		if _, err := db.Exec("INSERT INTO users(email, password_hash, role) VALUES (?, ?, ?)", "operation@test.com", string(hash), "operation"); err != nil {
 			return err
 		}
+		if _, err := db.Exec("INSERT INTO users(email, password_hash, role) VALUES (?, ?, ?)", "admin@test.com", string(hash), "admin"); err != nil {
+			return err
+		}
 	}
 
 	const dbLifetime = time.Minute * 5
@@ -3,22 +3,43 @@ package main
 import (
 	"bufio"
 	"bytes"
+	"crypto/ed25519"
 	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
 	"encoding/base64"
+	"encoding/pem"
+	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
+
+	"github.com/fajrinajiseno/mygolangapp/internal/jwtkey"
 )
 
 var KeyLength = 32
 
+var RSABits = 2048
+
 func main() {
+	dir := flag.String("dir", "", "add a signing key to this JWT_KEYS_DIR instead of replacing JWT_SECRET")
+	alg := flag.String("alg", "HS256", "algorithm of the key added to -dir: HS256, RS256 or EdDSA")
+	activate := flag.Duration("activate", 0, "sign with the key added to -dir only after this delay")
+	flag.Parse()
+
 	key := make([]byte, KeyLength)
 	if _, err := rand.Read(key); err != nil {
 		panic(err)
 	}
 	secret := base64.StdEncoding.EncodeToString(key)
 
+	if *dir != "" {
+		writeKey(*dir, *alg, secret, *activate)
+		return
+	}
+
 	envFile := ".env"
 	content, _ := os.ReadFile(envFile)
 	lines := bytes.Split(content, []byte("\n"))
@@ -51,3 +72,43 @@ func main() {
 
 	fmt.Println("✅ New JWT_SECRET saved to .env")
 }
+
+// writeKey adds a key to dir named by its activation time, the older keys stay in place so
+// the tokens they signed keep verifying.
+func writeKey(dir, alg, secret string, activate time.Duration) {
+	kid := time.Now().Add(activate).UTC().Format(jwtkey.KeyIDLayout)
+	var name string
+	var content []byte
+	switch alg {
+	case "HS256":
+		name, content = kid+jwtkey.SecretExt, []byte(secret+"\n")
+	case "RS256", "EdDSA":
+		var private any
+		var err error
+		if alg == "RS256" {
+			private, err = rsa.GenerateKey(rand.Reader, RSABits)
+		} else {
+			_, private, err = ed25519.GenerateKey(rand.Reader)
+		}
+		if err != nil {
+			panic(err)
+		}
+		der, err := x509.MarshalPKCS8PrivateKey(private)
+		if err != nil {
+			panic(err)
+		}
+		name, content = kid+jwtkey.PEMExt, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	default:
+		fmt.Fprintf(os.Stderr, "unsupported algorithm %s\n", alg)
+		os.Exit(1)
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		panic(err)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		panic(err)
+	}
+	fmt.Printf("✅ New %s signing key %s saved to %s\n", alg, kid, path)
+}